@@ -1,31 +1,49 @@
 package client
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
 
 // errorHints maps Slack API error codes to helpful hints.
 var errorHints = map[string]string{
-	"channel_not_found":    "Verify the channel ID is correct. Use 'slack-chat-api channels list' to find channel IDs.",
-	"not_in_channel":       "The bot must be invited to the channel. Use /invite @yourbot in Slack.",
-	"invalid_auth":         "Token is invalid or expired. Run 'slack-chat-api config set-token' to set a new token.",
-	"token_revoked":        "Token has been revoked. Run 'slack-chat-api config set-token' to set a new token.",
-	"ratelimited":          "Rate limit exceeded. Wait a moment and try again.",
-	"user_not_found":       "Verify the user ID is correct. Use 'slack-chat-api users list' to find user IDs.",
-	"message_not_found":    "Message not found. Verify the channel ID and timestamp are correct.",
-	"cant_delete_message":  "Cannot delete this message. You can only delete messages sent by the bot.",
-	"cant_update_message":  "Cannot update this message. You can only update messages sent by the bot.",
-	"already_archived":     "Channel is already archived.",
-	"not_archived":         "Channel is not archived.",
-	"name_taken":           "A channel with this name already exists.",
-	"invalid_name":         "Invalid channel name. Use lowercase letters, numbers, and hyphens only.",
-	"no_permission":        "The bot lacks permission for this action. Check the app's OAuth scopes.",
-	"missing_scope":        "Missing required OAuth scope. Update your app's permissions at api.slack.com/apps.",
-	"account_inactive":     "The user account is inactive or disabled.",
-	"is_archived":          "Cannot perform this action on an archived channel.",
-	"too_many_attachments": "Message has too many attachments. Reduce and try again.",
-	"msg_too_long":         "Message is too long. Maximum is 40,000 characters.",
+	"channel_not_found":            "Verify the channel ID is correct. Use 'slack-chat-api channels list' to find channel IDs.",
+	"not_in_channel":               "The bot must be invited to the channel. Use /invite @yourbot in Slack.",
+	"invalid_auth":                 "Token is invalid or expired. Run 'slack-chat-api config set-token' to set a new token.",
+	"token_revoked":                "Token has been revoked. Run 'slack-chat-api config set-token' to set a new token.",
+	"token_expired":                "Token has expired. Run 'slack-chat-api config login' to re-authorize; tokens from login are refreshed automatically.",
+	"ratelimited":                  "Rate limit exceeded. Wait a moment and try again.",
+	"user_not_found":               "Verify the user ID is correct. Use 'slack-chat-api users list' to find user IDs.",
+	"message_not_found":            "Message not found. Verify the channel ID and timestamp are correct.",
+	"cant_delete_message":          "Cannot delete this message. You can only delete messages sent by the bot.",
+	"cant_update_message":          "Cannot update this message. You can only update messages sent by the bot.",
+	"already_archived":             "Channel is already archived.",
+	"not_archived":                 "Channel is not archived.",
+	"name_taken":                   "A channel with this name already exists.",
+	"invalid_name":                 "Invalid channel name. Use lowercase letters, numbers, and hyphens only.",
+	"no_permission":                "The bot lacks permission for this action. Check the app's OAuth scopes.",
+	"missing_scope":                "Missing required OAuth scope. Update your app's permissions at api.slack.com/apps, or run 'slack-chat-api config doctor' to check all commands.",
+	"account_inactive":             "The user account is inactive or disabled.",
+	"is_archived":                  "Cannot perform this action on an archived channel.",
+	"too_many_attachments":         "Message has too many attachments. Reduce and try again.",
+	"msg_too_long":                 "Message is too long. Maximum is 40,000 characters.",
+	"file_not_found":               "File not found. Use 'slack-chat-api files list' to find file IDs.",
+	"cant_delete_file":             "Cannot delete this file. You can only delete files uploaded by the bot.",
+	"time_in_past":                 "The scheduled time is in the past. Choose a time in the future.",
+	"time_too_far":                 "Messages can be scheduled at most 120 days ahead.",
+	"user_not_in_channel":          "The user must be a member of the channel to see an ephemeral message.",
+	"already_pinned":               "This message is already pinned.",
+	"no_pin":                       "This message is not pinned. Use 'slack-chat-api pins list' to see pinned items.",
+	"invalid_scheduled_message_id": "Scheduled message not found. Use 'slack-chat-api messages scheduled list' to find IDs; it may already have been posted.",
+	"cannot_parse":                 "Slack could not understand the reminder time. Try a form such as \"every monday at 9am\".",
+	"already_complete":             "This reminder is already complete.",
+	"cannot_complete_recurring":    "Recurring reminders cannot be completed. Delete the reminder instead.",
+	"snooze_not_active":            "Notifications are not snoozed. Use 'slack-chat-api dnd info' to check.",
+	"invalid_presence":             "Presence must be auto or away.",
+	"no_such_subteam":              "User group not found. Use 'slack-chat-api usergroups list --include-disabled' to find groups.",
+	"handle_already_exists":        "The handle is already used by another user group or a user. Choose another handle.",
+	"paid_teams_only":              "User groups are only available on paid Slack plans.",
 }
 
 // WrapError wraps a Slack API error with context and a helpful hint if available.
@@ -34,6 +52,15 @@ func WrapError(operation string, err error) error {
 		return nil
 	}
 
+	// An API error's code is matched exactly, so user_not_in_channel doesn't
+	// get the hint for not_in_channel
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if hint, ok := errorHints[apiErr.Code]; ok {
+			return fmt.Errorf("%s: %w\nHint: %s", operation, err, hint)
+		}
+	}
+
 	errStr := err.Error()
 
 	// Check for known error codes
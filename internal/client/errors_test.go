@@ -122,3 +122,12 @@ func TestWrapError_OperationContext(t *testing.T) {
 	assert.Contains(t, errStr, "archive channel C123")
 	assert.Contains(t, errStr, "already_archived")
 }
+
+func TestWrapError_APIErrorCodeMatchedExactly(t *testing.T) {
+	// user_not_in_channel contains not_in_channel; each gets its own hint
+	for i := 0; i < 20; i++ {
+		err := WrapError("send ephemeral message", &APIError{Code: "user_not_in_channel"})
+		assert.Contains(t, err.Error(), "ephemeral")
+		assert.NotContains(t, err.Error(), "/invite")
+	}
+}
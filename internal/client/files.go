@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// OpenUploads opens local files for upload. A path of "-" reads stdin, which
+// needs stdinName since there is no file name to show in Slack. The returned
+// function closes the opened files.
+func OpenUploads(paths []string, stdin io.Reader, stdinName string) ([]FileUpload, func(), error) {
+	var uploads []FileUpload
+	var opened []*os.File
+	closeAll := func() {
+		for _, f := range opened {
+			_ = f.Close()
+		}
+	}
+
+	usedStdin := false
+	for _, path := range paths {
+		if path == "-" {
+			if usedStdin {
+				closeAll()
+				return nil, nil, errors.New("stdin can only be uploaded once")
+			}
+			if stdinName == "" {
+				closeAll()
+				return nil, nil, errors.New("--filename is required when uploading from stdin")
+			}
+			usedStdin = true
+			if stdin == nil {
+				stdin = os.Stdin
+			}
+			// The upload URL needs the exact length up front
+			data, err := io.ReadAll(stdin)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("reading stdin: %w", err)
+			}
+			uploads = append(uploads, FileUpload{Name: stdinName, Content: bytes.NewReader(data), Size: int64(len(data))})
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		opened = append(opened, f)
+		info, err := f.Stat()
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		if info.IsDir() {
+			closeAll()
+			return nil, nil, fmt.Errorf("%s is a directory", path)
+		}
+		uploads = append(uploads, FileUpload{Name: filepath.Base(path), Content: f, Size: info.Size()})
+	}
+
+	return uploads, closeAll, nil
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+// ResolveUsers turns user references into user IDs. A reference is a user
+// ID, a handle with or without "@" (matched against usernames and display
+// names), or an email address. The user list is fetched only if some
+// reference is not already an ID.
+func ResolveUsers(c *Client, refs []string) ([]string, error) {
+	ids := make([]string, len(refs))
+	var users []User
+	for i, ref := range refs {
+		if validate.UserID(ref) == nil {
+			ids[i] = ref
+			continue
+		}
+		if users == nil {
+			var err error
+			users, err = c.ListUsers(math.MaxInt32)
+			if err != nil {
+				return nil, WrapError("list users", err)
+			}
+		}
+		id, err := matchUser(users, ref)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+var usergroupIDRegex = regexp.MustCompile(`^S[A-Z0-9]+$`)
+
+// ExpandUsergroups replaces references to user groups, given as a group ID
+// or @handle, with the IDs of the group's members; other references are
+// returned as they are, for ResolveUsers. Groups are only listed if some
+// reference could be one. If they cannot be listed, for instance because
+// the token lacks usergroups:read, @handles are left to match users.
+func ExpandUsergroups(c *Client, refs []string) ([]string, error) {
+	byID := false
+	candidates := false
+	for _, ref := range refs {
+		switch {
+		case usergroupIDRegex.MatchString(ref):
+			byID, candidates = true, true
+		case strings.HasPrefix(ref, "@"):
+			candidates = true
+		}
+	}
+	if !candidates {
+		return refs, nil
+	}
+
+	groups, err := c.ListUsergroups(false, true)
+	if err != nil {
+		if byID {
+			return nil, WrapError("list user groups", err)
+		}
+		return refs, nil
+	}
+
+	var out []string
+	for _, ref := range refs {
+		handle := strings.ToLower(strings.TrimPrefix(ref, "@"))
+		expanded := false
+		for _, g := range groups {
+			if g.ID == ref || (strings.HasPrefix(ref, "@") && strings.ToLower(g.Handle) == handle) {
+				out = append(out, g.Users...)
+				expanded = true
+				break
+			}
+		}
+		switch {
+		case expanded:
+		case usergroupIDRegex.MatchString(ref):
+			return nil, fmt.Errorf("user group %s not found or disabled: see 'slack-chat-api usergroups list'", ref)
+		default:
+			out = append(out, ref)
+		}
+	}
+	return out, nil
+}
+
+func matchUser(users []User, ref string) (string, error) {
+	name := strings.ToLower(strings.TrimPrefix(ref, "@"))
+	email := !strings.HasPrefix(ref, "@") && strings.Contains(ref, "@")
+
+	var matches []User
+	for _, u := range users {
+		switch {
+		case email && strings.EqualFold(u.Profile.Email, ref),
+			!email && (strings.ToLower(u.Name) == name || strings.ToLower(u.Profile.DisplayName) == name):
+			matches = append(matches, u)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("user %q not found: use a user ID, @handle or email (see 'slack-chat-api users list')", ref)
+	case 1:
+		return matches[0].ID, nil
+	}
+	ids := make([]string, len(matches))
+	for i, u := range matches {
+		ids[i] = u.ID
+	}
+	return "", fmt.Errorf("user %q is ambiguous (%s): use a user ID instead", ref, strings.Join(ids, ", "))
+}
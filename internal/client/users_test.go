@@ -0,0 +1,75 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func TestResolveUsers(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	alice := slack.User{ID: "U0000ALICE", Name: "alice"}
+	alice.Profile.DisplayName = "Alice B"
+	srv.AddUser(alice)
+	c := NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	ids, err := ResolveUsers(c, []string{"U0000ALICE", "@alice", "tester", "tester@example.com", "@alice b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U0000ALICE", "U0000ALICE", slacktest.UserID, slacktest.UserID, "U0000ALICE"}, ids)
+
+	_, err = ResolveUsers(c, []string{"@nobody"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestResolveUsers_IDsNeedNoLookup(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	_, err := ResolveUsers(c, []string{"U0123ABC", "W0123ABC"})
+	require.NoError(t, err)
+	assert.Zero(t, srv.Calls("users.list"))
+}
+
+func TestExpandUsergroups(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	oncall := srv.AddUsergroup(slack.Usergroup{Name: "On-call", Handle: "oncall", Users: []string{"U0000ALICE", slacktest.UserID}})
+	c := NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	refs, err := ExpandUsergroups(c, []string{"@OnCall", "@alice", "U0000BOB", oncall})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U0000ALICE", slacktest.UserID, "@alice", "U0000BOB", "U0000ALICE", slacktest.UserID}, refs)
+
+	_, err = ExpandUsergroups(c, []string{"S0000NONE"})
+	assert.Error(t, err)
+}
+
+func TestExpandUsergroups_NoCandidates(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	refs, err := ExpandUsergroups(c, []string{"U0000ALICE", "bob@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U0000ALICE", "bob@example.com"}, refs)
+	assert.Zero(t, srv.Calls("usergroups.list"))
+}
+
+func TestExpandUsergroups_WithoutScope(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	srv.AddToken("xoxb-limited", slacktest.BotUserID, true, "users:read")
+	c := NewWithConfig(srv.URL, "xoxb-limited", nil)
+
+	// @handles fall through to user matching
+	refs, err := ExpandUsergroups(c, []string{"@alice"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"@alice"}, refs)
+}
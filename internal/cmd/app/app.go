@@ -0,0 +1,17 @@
+package app
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates the app command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "app",
+		Short: "Manage the Slack app configuration",
+	}
+
+	cmd.AddCommand(newManifestCmd())
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+var testRequirements = []scopes.Requirement{
+	{Command: "messages react", Token: scopes.Bot, Scopes: []string{"reactions:write"}},
+	{Command: "messages send", Token: scopes.Bot, Scopes: []string{"chat:write"}},
+	{Command: "search all", Token: scopes.User, Scopes: []string{"search:read"}},
+	{Command: "workspace info", Token: scopes.Bot, Scopes: []string{"team:read"}},
+}
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	orig := output.Writer
+	output.Writer = &buf
+	t.Cleanup(func() { output.Writer = orig })
+	return &buf
+}
+
+func TestSelectRequirements(t *testing.T) {
+	all, err := selectRequirements(testRequirements, nil)
+	require.NoError(t, err)
+	assert.Equal(t, testRequirements, all)
+
+	selected, err := selectRequirements(testRequirements, []string{"messages", "search  all"})
+	require.NoError(t, err)
+	assert.Len(t, selected, 3)
+
+	_, err = selectRequirements(testRequirements, []string{"messages nope"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown command")
+}
+
+func TestRunManifest_YAML(t *testing.T) {
+	buf := captureOutput(t)
+
+	err := runManifest(testRequirements, &manifestOptions{commands: []string{"messages send"}})
+	require.NoError(t, err)
+
+	out := buf.String()
+	assert.Contains(t, out, "name: Slack Chat API")
+	assert.Contains(t, out, "    bot:\n      - chat:write\n")
+	assert.NotContains(t, out, "reactions:write")
+	assert.NotContains(t, out, "user:")
+}
+
+func TestRunManifest_JSON(t *testing.T) {
+	buf := captureOutput(t)
+	output.OutputFormat = output.FormatJSON
+	defer func() { output.OutputFormat = output.FormatText }()
+
+	err := runManifest(testRequirements, &manifestOptions{name: "Custom"})
+	require.NoError(t, err)
+
+	var m struct {
+		DisplayInformation struct {
+			Name string `json:"name"`
+		} `json:"display_information"`
+		OAuthConfig struct {
+			Scopes struct {
+				Bot  []string `json:"bot"`
+				User []string `json:"user"`
+			} `json:"scopes"`
+		} `json:"oauth_config"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &m))
+	assert.Equal(t, "Custom", m.DisplayInformation.Name)
+	assert.Equal(t, []string{"chat:write", "reactions:write", "team:read"}, m.OAuthConfig.Scopes.Bot)
+	assert.Equal(t, []string{"search:read"}, m.OAuthConfig.Scopes.User)
+}
+
+func TestRunManifest_File(t *testing.T) {
+	captureOutput(t)
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+
+	err := runManifest(testRequirements, &manifestOptions{file: path, tokenRotation: true})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "token_rotation_enabled: true")
+}
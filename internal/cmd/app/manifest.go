@@ -0,0 +1,110 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/manifest"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type manifestOptions struct {
+	commands      []string
+	name          string
+	tokenRotation bool
+	file          string
+}
+
+func newManifestCmd() *cobra.Command {
+	opts := &manifestOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "manifest",
+		Short: "Generate a Slack app manifest from the scopes commands need",
+		Long: `Generate a Slack app manifest requesting the OAuth scopes the CLI's commands
+need. Paste the output into api.slack.com/apps -> Create New App -> From an app
+manifest, or into an existing app's App Manifest page.
+
+Use --commands to request only the scopes for a subset of commands. Entries may
+be a command ("messages send") or a command group ("search").
+
+The output is YAML; use -o json for a JSON manifest.
+
+Examples:
+  slack-chat-api app manifest
+  slack-chat-api app manifest --commands "messages send,messages react"
+  slack-chat-api app manifest --commands search --name "Search Bot"
+  slack-chat-api app manifest --file slack-app-manifest.yaml`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runManifest(scopes.Collect(cmd.Root()), opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.commands, "commands", nil, "Only include scopes for these commands or command groups")
+	cmd.Flags().StringVar(&opts.name, "name", "", "App display name (default \"Slack Chat API\")")
+	cmd.Flags().BoolVar(&opts.tokenRotation, "token-rotation", false, "Enable token rotation (cannot be disabled once set on an app)")
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "Write the manifest to a file instead of stdout")
+
+	return cmd
+}
+
+func runManifest(reqs []scopes.Requirement, opts *manifestOptions) error {
+	selected, err := selectRequirements(reqs, opts.commands)
+	if err != nil {
+		return err
+	}
+
+	m := manifest.Generate(selected, manifest.Options{
+		Name:          opts.name,
+		TokenRotation: opts.tokenRotation,
+	})
+
+	if opts.file == "" && output.IsJSON() {
+		return output.PrintJSON(m)
+	}
+
+	data, err := m.YAML()
+	if err != nil {
+		return err
+	}
+
+	if opts.file != "" {
+		if err := os.WriteFile(opts.file, data, 0644); err != nil {
+			return fmt.Errorf("writing manifest: %w", err)
+		}
+		output.Printf("Manifest written to %s\n", opts.file)
+		return nil
+	}
+
+	output.Printf("%s", data)
+	return nil
+}
+
+// selectRequirements keeps the requirements for the named commands or groups.
+// An empty selection keeps everything.
+func selectRequirements(reqs []scopes.Requirement, commands []string) ([]scopes.Requirement, error) {
+	if len(commands) == 0 {
+		return reqs, nil
+	}
+
+	var selected []scopes.Requirement
+	for _, name := range commands {
+		name = strings.Join(strings.Fields(name), " ")
+		matched := false
+		for _, r := range reqs {
+			if r.Command == name || strings.HasPrefix(r.Command, name+" ") {
+				selected = append(selected, r)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unknown command %q: no command with that name calls the Slack API", name)
+		}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,83 @@
+package bookmarks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type addOptions struct {
+	emoji string
+}
+
+func newAddCmd() *cobra.Command {
+	opts := &addOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "add <channel> <title> <link>",
+		Short:       "Bookmark a link in a channel",
+		Annotations: scopes.BotScopes("bookmarks:write"),
+		Long: `Add a link to the bookmarks bar at the top of a channel.
+
+Examples:
+  slack-chat-api bookmarks add C1234567890 "Runbook" https://wiki.example.com/runbook
+  slack-chat-api bookmarks add C1234567890 "Dashboard" https://grafana.example.com --emoji :chart_with_upwards_trend:`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(args[0], args[1], args[2], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.emoji, "emoji", "", "Emoji shown with the bookmark (e.g., :book:)")
+
+	return cmd
+}
+
+func runAdd(channel, title, link string, opts *addOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+	if title == "" {
+		return fmt.Errorf("bookmark title cannot be empty")
+	}
+	if err := validateLink(link); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := c.AddBookmark(channel, title, link, emojiName(opts.emoji))
+	if err != nil {
+		return client.WrapError("add bookmark", err)
+	}
+	return printBookmark("Added", b)
+}
+
+// validateLink requires an absolute http(s) URL, which is all Slack accepts
+// for link bookmarks
+func validateLink(link string) error {
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid link %q: must be an http or https URL", link)
+	}
+	return nil
+}
+
+// emojiName puts an emoji in the :name: form Slack expects for bookmarks
+func emojiName(emoji string) string {
+	if emoji == "" {
+		return ""
+	}
+	return ":" + validate.Emoji(emoji) + ":"
+}
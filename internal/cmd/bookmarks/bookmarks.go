@@ -0,0 +1,42 @@
+package bookmarks
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+)
+
+// NewCmd creates the bookmarks command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "bookmarks",
+		Aliases: []string{"bookmark", "bm"},
+		Short:   "Manage channel bookmarks",
+	}
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newAddCmd())
+	cmd.AddCommand(newEditCmd())
+	cmd.AddCommand(newRemoveCmd())
+
+	return cmd
+}
+
+// printBookmark reports a bookmark that was just added or edited
+func printBookmark(action string, b *client.Bookmark) error {
+	if output.IsJSON() {
+		return output.PrintJSON(b)
+	}
+	output.Printf("%s bookmark %s: %s\n", action, b.ID, formatTitle(b))
+	output.KeyValue("Link", b.Link)
+	return nil
+}
+
+// formatTitle shows a bookmark's title with its emoji, if any
+func formatTitle(b *client.Bookmark) string {
+	if b.Emoji == "" {
+		return b.Title
+	}
+	return b.Emoji + " " + b.Title
+}
@@ -0,0 +1,67 @@
+package bookmarks
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	t.Cleanup(func() { output.Writer = orig })
+	return buf
+}
+
+func TestBookmarksWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	channel := srv.AddChannel(slacktest.Channel{Name: "inc-42", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+	buf := captureOutput(t)
+
+	require.NoError(t, runAdd(channel, "Runbook", "https://wiki.example.com/runbook", &addOptions{emoji: "book"}, c))
+	bookmarks := srv.Bookmarks(channel)
+	require.Len(t, bookmarks, 1)
+	assert.Equal(t, ":book:", bookmarks[0].Emoji)
+	id := bookmarks[0].ID
+
+	require.NoError(t, runEdit(channel, id, &editOptions{title: "Runbook v2", setTitle: true, setEmoji: true}, c))
+	bookmarks = srv.Bookmarks(channel)
+	assert.Equal(t, "Runbook v2", bookmarks[0].Title)
+	assert.Equal(t, "https://wiki.example.com/runbook", bookmarks[0].Link, "unchanged fields are kept")
+	assert.Empty(t, bookmarks[0].Emoji)
+
+	buf.Reset()
+	require.NoError(t, runList(channel, &listOptions{}, c))
+	assert.Contains(t, buf.String(), "Runbook v2")
+
+	require.NoError(t, runRemove(channel, id, &removeOptions{}, c))
+	assert.Empty(t, srv.Bookmarks(channel))
+
+	err := runRemove(channel, id, &removeOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not_found")
+}
+
+func TestBookmarks_Validation(t *testing.T) {
+	err := runAdd("C123", "Runbook", "wiki/runbook", &addOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "http or https")
+
+	err = runEdit("C123", "Bk123", &editOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nothing to change")
+
+	err = runEdit("C123", "Bk123", &editOptions{setTitle: true}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "title cannot be empty")
+}
@@ -0,0 +1,92 @@
+package bookmarks
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type editOptions struct {
+	title string
+	link  string
+	emoji string
+
+	// Which of the above were given; empty values are valid changes
+	setTitle bool
+	setLink  bool
+	setEmoji bool
+}
+
+func newEditCmd() *cobra.Command {
+	opts := &editOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "edit <channel> <bookmark-id>",
+		Short:       "Change a bookmark's title, link or emoji",
+		Annotations: scopes.BotScopes("bookmarks:write"),
+		Long: `Change a bookmark's title, link or emoji. Fields not given are left as
+they are; --emoji "" removes the emoji.
+
+Examples:
+  slack-chat-api bookmarks edit C1234567890 Bk1234567890 --title "Runbook (v2)"
+  slack-chat-api bookmarks edit C1234567890 Bk1234567890 --link https://wiki.example.com/v2 --emoji :book:`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.setTitle = cmd.Flags().Changed("title")
+			opts.setLink = cmd.Flags().Changed("link")
+			opts.setEmoji = cmd.Flags().Changed("emoji")
+			return runEdit(args[0], args[1], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.title, "title", "", "New title")
+	cmd.Flags().StringVar(&opts.link, "link", "", "New link")
+	cmd.Flags().StringVar(&opts.emoji, "emoji", "", "New emoji (empty to remove)")
+
+	return cmd
+}
+
+func runEdit(channel, id string, opts *editOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+
+	var update client.BookmarkUpdate
+	if opts.setTitle {
+		if opts.title == "" {
+			return fmt.Errorf("bookmark title cannot be empty")
+		}
+		update.Title = &opts.title
+	}
+	if opts.setLink {
+		if err := validateLink(opts.link); err != nil {
+			return err
+		}
+		update.Link = &opts.link
+	}
+	if opts.setEmoji {
+		emoji := emojiName(opts.emoji)
+		update.Emoji = &emoji
+	}
+	if update.Title == nil && update.Link == nil && update.Emoji == nil {
+		return fmt.Errorf("nothing to change: use --title, --link or --emoji")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	b, err := c.EditBookmark(channel, id, update)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("edit bookmark %s", id), err)
+	}
+	return printBookmark("Updated", b)
+}
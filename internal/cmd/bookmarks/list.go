@@ -0,0 +1,63 @@
+package bookmarks
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type listOptions struct{}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	return &cobra.Command{
+		Use:         "list <channel>",
+		Short:       "List a channel's bookmarks",
+		Annotations: scopes.BotScopes("bookmarks:read"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(args[0], opts, nil)
+		},
+	}
+}
+
+func runList(channel string, opts *listOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	bookmarks, err := c.ListBookmarks(channel)
+	if err != nil {
+		return client.WrapError("list bookmarks", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(bookmarks)
+	}
+
+	if len(bookmarks) == 0 {
+		output.Println("No bookmarks")
+		return nil
+	}
+
+	headers := []string{"ID", "TITLE", "LINK"}
+	rows := make([][]string, 0, len(bookmarks))
+	for i := range bookmarks {
+		b := &bookmarks[i]
+		rows = append(rows, []string{b.ID, formatTitle(b), b.Link})
+	}
+	output.Table(headers, rows)
+	return nil
+}
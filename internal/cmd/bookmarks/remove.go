@@ -0,0 +1,50 @@
+package bookmarks
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type removeOptions struct{}
+
+func newRemoveCmd() *cobra.Command {
+	opts := &removeOptions{}
+
+	return &cobra.Command{
+		Use:         "remove <channel> <bookmark-id>",
+		Aliases:     []string{"rm"},
+		Short:       "Remove a bookmark from a channel",
+		Annotations: scopes.BotScopes("bookmarks:write"),
+		Args:        cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(args[0], args[1], opts, nil)
+		},
+	}
+}
+
+func runRemove(channel, id string, opts *removeOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.RemoveBookmark(channel, id); err != nil {
+		return client.WrapError(fmt.Sprintf("remove bookmark %s", id), err)
+	}
+
+	output.Println("Bookmark removed")
+	return nil
+}
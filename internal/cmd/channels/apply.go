@@ -0,0 +1,594 @@
+package channels
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/naming"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type applyOptions struct {
+	file         string
+	dryRun       bool
+	policy       string
+	ignorePolicy bool
+}
+
+func newApplyCmd() *cobra.Command {
+	opts := &applyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update channels from a YAML spec",
+		Annotations: scopes.BotScopes("channels:read", "groups:read", "channels:manage", "groups:write", "channels:join",
+			"users:read", "users:read.email", "usergroups:read", "chat:write", "pins:read", "pins:write", "bookmarks:read", "bookmarks:write"),
+		Long: `Create or update channels to match a YAML spec. The current state of each
+channel is compared with the spec and the resulting plan is shown, then
+applied. Applying the same spec again changes nothing.
+
+Apply only adds: members, pins and bookmarks not in the spec are left alone.
+Fields left out of the spec are not managed. A channel's private flag cannot
+be changed once it exists.
+
+Spec format:
+  channels:
+    - name: inc-2026-10-db-outage
+      private: false
+      topic: Database outage
+      purpose: Coordinate the response
+      members: ["@alice", bob@example.com, U1234567890, "@oncall"]
+      welcome: |
+        Welcome! Runbook and status page are bookmarked above.
+      bookmarks:
+        - title: Runbook
+          link: https://wiki.example.com/runbooks/db
+          emoji: ":book:"
+
+Members are user IDs, @handles or emails; a user group's @handle or ID
+stands for all of its members. The welcome message is posted and pinned unless a pinned message with the
+same text is already there.
+
+New channels must comply with the channel naming policy, if one is
+configured (see 'slack-chat-api channels lint --help'), unless given
+--ignore-policy. Existing channels are not checked.
+
+Examples:
+  slack-chat-api channels apply -f incident.yaml --dry-run
+  slack-chat-api channels apply -f incident.yaml
+  slack-chat-api channels apply -f incident.yaml --dry-run -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.file, "file", "f", "", "YAML spec file")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the plan without applying it")
+	cmd.Flags().StringVar(&opts.policy, "policy", "", "Naming policy file (default $SLACK_CHANNEL_POLICY or the config directory's "+naming.PolicyFile+")")
+	cmd.Flags().BoolVar(&opts.ignorePolicy, "ignore-policy", false, "Create channels even if their names break the naming policy")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// applySpec is the YAML spec read by channels apply
+type applySpec struct {
+	Channels []channelSpec `yaml:"channels"`
+}
+
+// channelSpec is the desired state of one channel. Empty fields are not
+// managed.
+type channelSpec struct {
+	Name      string         `yaml:"name"`
+	Private   bool           `yaml:"private"`
+	Topic     string         `yaml:"topic"`
+	Purpose   string         `yaml:"purpose"`
+	Members   []string       `yaml:"members"`
+	Welcome   string         `yaml:"welcome"`
+	Bookmarks []bookmarkSpec `yaml:"bookmarks"`
+}
+
+type bookmarkSpec struct {
+	Title string `yaml:"title" json:"title"`
+	Link  string `yaml:"link" json:"link"`
+	Emoji string `yaml:"emoji" json:"emoji,omitempty"`
+}
+
+// Plan actions
+const (
+	actionCreate         = "create"
+	actionJoin           = "join"
+	actionSetTopic       = "set_topic"
+	actionSetPurpose     = "set_purpose"
+	actionInvite         = "invite"
+	actionPinWelcome     = "pin_welcome"
+	actionAddBookmark    = "add_bookmark"
+	actionUpdateBookmark = "update_bookmark"
+)
+
+// change is one step of a channel's plan. Done and Error are set once it is
+// applied.
+type change struct {
+	Action   string        `json:"action"`
+	Value    string        `json:"value,omitempty"`
+	Users    []string      `json:"users,omitempty"`
+	Bookmark *bookmarkSpec `json:"bookmark,omitempty"`
+	Done     bool          `json:"done,omitempty"`
+	Error    string        `json:"error,omitempty"`
+
+	bookmarkID string
+}
+
+// channelPlan lists the changes that bring a channel in line with its spec
+type channelPlan struct {
+	Name    string   `json:"name"`
+	ID      string   `json:"id,omitempty"`
+	Exists  bool     `json:"exists"`
+	Private bool     `json:"private"`
+	Changes []change `json:"changes"`
+}
+
+func runApply(opts *applyOptions, c *client.Client) error {
+	spec, err := loadApplySpec(opts.file)
+	if err != nil {
+		return err
+	}
+	var policy *naming.Policy
+	if !opts.ignorePolicy {
+		if policy, err = naming.Load(opts.policy); err != nil {
+			return err
+		}
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	plans, err := planApply(c, spec)
+	if err != nil {
+		return err
+	}
+	if err := checkPlanNames(plans, policy); err != nil {
+		return err
+	}
+	total := 0
+	for _, p := range plans {
+		total += len(p.Changes)
+	}
+
+	if opts.dryRun || total == 0 {
+		if output.IsJSON() {
+			return output.PrintJSON(plans)
+		}
+		printPlan(plans)
+		if total > 0 {
+			output.Println("\nDry run: no changes made")
+		}
+		return nil
+	}
+
+	if !output.IsJSON() {
+		printPlan(plans)
+		output.Println()
+	}
+	failed := 0
+	for i := range plans {
+		failed += applyPlan(c, &plans[i])
+	}
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(plans); err != nil {
+			return err
+		}
+	} else {
+		for _, p := range plans {
+			for _, ch := range p.Changes {
+				if ch.Error != "" {
+					output.Printf("Failed: #%s: %s: %s\n", p.Name, ch.describe(), ch.Error)
+				}
+			}
+		}
+		output.Printf("Applied %d of %d change(s)\n", total-failed, total)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d change(s) failed", failed, total)
+	}
+	return nil
+}
+
+// loadApplySpec reads and checks a spec file
+func loadApplySpec(path string) (*applySpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	var spec applySpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("invalid spec %s: %w", path, err)
+	}
+	if len(spec.Channels) == 0 {
+		return nil, fmt.Errorf("invalid spec %s: no channels", path)
+	}
+
+	seen := make(map[string]bool)
+	for _, ch := range spec.Channels {
+		if err := validate.ChannelName(ch.Name); err != nil {
+			return nil, fmt.Errorf("invalid spec %s: %w", path, err)
+		}
+		if seen[ch.Name] {
+			return nil, fmt.Errorf("invalid spec %s: channel %q appears twice", path, ch.Name)
+		}
+		seen[ch.Name] = true
+		for _, b := range ch.Bookmarks {
+			if b.Title == "" || b.Link == "" {
+				return nil, fmt.Errorf("invalid spec %s: bookmarks in %q need a title and a link", path, ch.Name)
+			}
+		}
+	}
+	return &spec, nil
+}
+
+// planApply compares each spec with its channel's current state
+func planApply(c *client.Client, spec *applySpec) ([]channelPlan, error) {
+	existing, err := c.ListChannels("public_channel,private_channel", false, math.MaxInt32)
+	if err != nil {
+		return nil, client.WrapError("list channels", err)
+	}
+	byName := make(map[string]client.Channel, len(existing))
+	for _, ch := range existing {
+		byName[ch.Name] = ch
+	}
+
+	members, err := resolveSpecMembers(c, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]channelPlan, 0, len(spec.Channels))
+	for _, s := range spec.Channels {
+		plan := channelPlan{Name: s.Name, Private: s.Private, Changes: []change{}}
+		ch, ok := byName[s.Name]
+		if !ok {
+			plan.Changes = newChannelChanges(s, members[s.Name])
+			plans = append(plans, plan)
+			continue
+		}
+
+		switch {
+		case ch.IsArchived:
+			return nil, fmt.Errorf("channel #%s (%s) is archived: unarchive it first", s.Name, ch.ID)
+		case ch.IsPrivate != s.Private:
+			return nil, fmt.Errorf("channel #%s (%s) exists with private=%t, but the spec says private=%t", s.Name, ch.ID, ch.IsPrivate, s.Private)
+		}
+		plan.ID, plan.Exists = ch.ID, true
+		plan.Changes, err = existingChannelChanges(c, ch, s, members[s.Name])
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// slackLink matches a link as Slack formats it in message text:
+// <https://example.com> or <https://example.com|example.com>
+var slackLink = regexp.MustCompile(`<((?:https?|mailto):[^|>]*)(?:\|([^>]*))?>`)
+
+// slackEntities undoes the escaping Slack applies to message text
+var slackEntities = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// plainText undoes Slack's formatting of message text, topics and purposes,
+// so text read back from Slack compares equal to the text that was set. Links become their
+// label, or their URL if they have none.
+func plainText(s string) string {
+	s = slackLink.ReplaceAllStringFunc(s, func(link string) string {
+		m := slackLink.FindStringSubmatch(link)
+		if m[2] != "" {
+			return m[2]
+		}
+		return m[1]
+	})
+	return strings.TrimSpace(slackEntities.Replace(s))
+}
+
+// checkPlanNames returns an error if any channel the plan would create
+// breaks the naming policy. A nil policy allows every name.
+func checkPlanNames(plans []channelPlan, policy *naming.Policy) error {
+	if policy == nil {
+		return nil
+	}
+	var broken []string
+	for _, p := range plans {
+		if p.Exists {
+			continue
+		}
+		problems := policy.Check(p.Name)
+		if len(problems) == 0 {
+			continue
+		}
+		line := fmt.Sprintf("  #%s: %s", p.Name, strings.Join(problems, "; "))
+		if suggestion := policy.Suggest(p.Name); suggestion != "" {
+			line += fmt.Sprintf(" (suggested: %s)", suggestion)
+		}
+		broken = append(broken, line)
+	}
+	if len(broken) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d new channel(s) break the naming policy (%s):\n%s\nUse --ignore-policy to create them anyway",
+		len(broken), policy.Path, strings.Join(broken, "\n"))
+}
+
+// resolveSpecMembers resolves every channel's members, expanding user
+// groups, with one user lookup
+func resolveSpecMembers(c *client.Client, spec *applySpec) (map[string][]string, error) {
+	var refs []string
+	counts := make([]int, len(spec.Channels))
+	for i, s := range spec.Channels {
+		expanded, err := client.ExpandUsergroups(c, s.Members)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, expanded...)
+		counts[i] = len(expanded)
+	}
+	ids, err := client.ResolveUsers(c, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make(map[string][]string)
+	for i, s := range spec.Channels {
+		members[s.Name] = uniqueIDs(ids[:counts[i]])
+		ids = ids[counts[i]:]
+	}
+	return members, nil
+}
+
+func newChannelChanges(s channelSpec, members []string) []change {
+	changes := []change{{Action: actionCreate}}
+	if s.Topic != "" {
+		changes = append(changes, change{Action: actionSetTopic, Value: s.Topic})
+	}
+	if s.Purpose != "" {
+		changes = append(changes, change{Action: actionSetPurpose, Value: s.Purpose})
+	}
+	if len(members) > 0 {
+		changes = append(changes, change{Action: actionInvite, Users: members})
+	}
+	if s.Welcome != "" {
+		changes = append(changes, change{Action: actionPinWelcome, Value: s.Welcome})
+	}
+	for i := range s.Bookmarks {
+		changes = append(changes, change{Action: actionAddBookmark, Bookmark: &s.Bookmarks[i]})
+	}
+	return changes
+}
+
+func existingChannelChanges(c *client.Client, ch client.Channel, s channelSpec, members []string) ([]change, error) {
+	changes := []change{}
+	if !ch.IsMember {
+		changes = append(changes, change{Action: actionJoin})
+	}
+
+	info, err := c.GetChannelInfo(ch.ID)
+	if err != nil {
+		return nil, client.WrapError(fmt.Sprintf("get channel %s", ch.ID), err)
+	}
+	if s.Topic != "" && plainText(s.Topic) != plainText(info.Topic.Value) {
+		changes = append(changes, change{Action: actionSetTopic, Value: s.Topic})
+	}
+	if s.Purpose != "" && plainText(s.Purpose) != plainText(info.Purpose.Value) {
+		changes = append(changes, change{Action: actionSetPurpose, Value: s.Purpose})
+	}
+
+	if len(members) > 0 {
+		current, err := c.ListChannelMembers(ch.ID, math.MaxInt32)
+		if err != nil {
+			return nil, client.WrapError(fmt.Sprintf("list members of %s", ch.ID), err)
+		}
+		in := make(map[string]bool, len(current))
+		for _, id := range current {
+			in[id] = true
+		}
+		var missing []string
+		for _, id := range members {
+			if !in[id] {
+				missing = append(missing, id)
+				in[id] = true
+			}
+		}
+		if len(missing) > 0 {
+			changes = append(changes, change{Action: actionInvite, Users: missing})
+		}
+	}
+
+	if s.Welcome != "" {
+		pins, err := c.ListPins(ch.ID)
+		if err != nil {
+			return nil, client.WrapError(fmt.Sprintf("list pins in %s", ch.ID), err)
+		}
+		pinned := false
+		for _, p := range pins {
+			if p.Message != nil && plainText(p.Message.Text) == plainText(s.Welcome) {
+				pinned = true
+				break
+			}
+		}
+		if !pinned {
+			changes = append(changes, change{Action: actionPinWelcome, Value: s.Welcome})
+		}
+	}
+
+	if len(s.Bookmarks) > 0 {
+		bookmarks, err := c.ListBookmarks(ch.ID)
+		if err != nil {
+			return nil, client.WrapError(fmt.Sprintf("list bookmarks in %s", ch.ID), err)
+		}
+		byTitle := make(map[string]client.Bookmark, len(bookmarks))
+		for _, b := range bookmarks {
+			byTitle[b.Title] = b
+		}
+		for i := range s.Bookmarks {
+			want := &s.Bookmarks[i]
+			have, ok := byTitle[want.Title]
+			switch {
+			case !ok:
+				changes = append(changes, change{Action: actionAddBookmark, Bookmark: want})
+			case have.Link != want.Link || (want.Emoji != "" && have.Emoji != want.Emoji):
+				changes = append(changes, change{Action: actionUpdateBookmark, Bookmark: want, bookmarkID: have.ID})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// applyPlan makes a channel's changes in order and returns how many failed.
+// If the channel cannot be created, the rest of its changes are skipped.
+func applyPlan(c *client.Client, plan *channelPlan) int {
+	failed := 0
+	for i := range plan.Changes {
+		ch := &plan.Changes[i]
+		if err := applyChange(c, plan, ch); err != nil {
+			ch.Error = errorCode(err)
+			failed++
+			if ch.Action == actionCreate {
+				return failed + len(plan.Changes) - i - 1
+			}
+			continue
+		}
+		ch.Done = true
+	}
+	return failed
+}
+
+func applyChange(c *client.Client, plan *channelPlan, ch *change) error {
+	switch ch.Action {
+	case actionCreate:
+		created, err := c.CreateChannel(plan.Name, plan.Private)
+		if err != nil {
+			return err
+		}
+		plan.ID = created.ID
+		return nil
+	case actionJoin:
+		_, err := c.JoinChannel(plan.ID)
+		return err
+	case actionSetTopic:
+		return c.SetChannelTopic(plan.ID, ch.Value)
+	case actionSetPurpose:
+		return c.SetChannelPurpose(plan.ID, ch.Value)
+	case actionInvite:
+		results, err := inviteUsers(c, plan.ID, ch.Users)
+		if err != nil {
+			return err
+		}
+		var failed []string
+		for _, r := range results {
+			if !r.OK && r.Error != "already_in_channel" {
+				failed = append(failed, r.User+": "+r.Error)
+			}
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("%s", strings.Join(failed, ", "))
+		}
+		return nil
+	case actionPinWelcome:
+		msg, err := c.SendMessage(plan.ID, ch.Value, "", nil)
+		if err != nil {
+			return err
+		}
+		return c.AddPin(plan.ID, msg.TS)
+	case actionAddBookmark:
+		_, err := c.AddBookmark(plan.ID, ch.Bookmark.Title, ch.Bookmark.Link, ch.Bookmark.Emoji)
+		return err
+	case actionUpdateBookmark:
+		update := client.BookmarkUpdate{Link: &ch.Bookmark.Link}
+		if ch.Bookmark.Emoji != "" {
+			update.Emoji = &ch.Bookmark.Emoji
+		}
+		_, err := c.EditBookmark(plan.ID, ch.bookmarkID, update)
+		return err
+	}
+	return fmt.Errorf("unknown action %q", ch.Action)
+}
+
+// describe returns a one-line summary of a change for the text plan
+func (ch change) describe() string {
+	switch ch.Action {
+	case actionCreate:
+		return "create channel"
+	case actionJoin:
+		return "join channel"
+	case actionSetTopic:
+		return fmt.Sprintf("set topic to %q", ch.Value)
+	case actionSetPurpose:
+		return fmt.Sprintf("set purpose to %q", ch.Value)
+	case actionInvite:
+		return "invite " + strings.Join(ch.Users, ", ")
+	case actionPinWelcome:
+		return fmt.Sprintf("post and pin welcome message %q", truncate(strings.TrimSpace(ch.Value), 40))
+	case actionAddBookmark:
+		return fmt.Sprintf("add bookmark %q (%s)", ch.Bookmark.Title, ch.Bookmark.Link)
+	case actionUpdateBookmark:
+		return fmt.Sprintf("update bookmark %q (%s)", ch.Bookmark.Title, ch.Bookmark.Link)
+	}
+	return ch.Action
+}
+
+// truncate shortens a string to maxLen, replacing newlines with spaces
+func truncate(s string, maxLen int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
+
+func printPlan(plans []channelPlan) {
+	total, touched := 0, 0
+	for _, p := range plans {
+		label := fmt.Sprintf("#%s (%s)", p.Name, p.ID)
+		if !p.Exists {
+			visibility := "public"
+			if p.Private {
+				visibility = "private"
+			}
+			label = fmt.Sprintf("#%s (new, %s)", p.Name, visibility)
+		}
+		if len(p.Changes) == 0 {
+			output.Printf("%s: no changes\n", label)
+			continue
+		}
+		output.Println(label)
+		for _, ch := range p.Changes {
+			output.Printf("  + %s\n", ch.describe())
+		}
+		total += len(p.Changes)
+		touched++
+	}
+
+	if total == 0 {
+		output.Println("\nNo changes: channels match the spec")
+		return
+	}
+	output.Printf("\nPlan: %d change(s) to %d channel(s)\n", total, touched)
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 	"github.com/piekstra/slack-chat-api/internal/validate"
 )
 
@@ -23,9 +24,10 @@ func newArchiveCmd() *cobra.Command {
 	opts := &archiveOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "archive <channel-id>",
-		Short: "Archive a channel",
-		Args:  cobra.ExactArgs(1),
+		Use:         "archive <channel-id>",
+		Short:       "Archive a channel",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write"),
+		Args:        cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runArchive(args[0], opts, nil)
 		},
@@ -14,12 +14,21 @@ func NewCmd() *cobra.Command {
 
 	cmd.AddCommand(newListCmd())
 	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(newSearchCmd())
 	cmd.AddCommand(newCreateCmd())
+	cmd.AddCommand(newRenameCmd())
 	cmd.AddCommand(newArchiveCmd())
 	cmd.AddCommand(newUnarchiveCmd())
+	cmd.AddCommand(newStaleCmd())
+	cmd.AddCommand(newApplyCmd())
+	cmd.AddCommand(newLintCmd())
 	cmd.AddCommand(newSetTopicCmd())
 	cmd.AddCommand(newSetPurposeCmd())
 	cmd.AddCommand(newInviteCmd())
+	cmd.AddCommand(newKickCmd())
+	cmd.AddCommand(newMembersCmd())
+	cmd.AddCommand(newJoinCmd())
+	cmd.AddCommand(newLeaveCmd())
 
 	return cmd
 }
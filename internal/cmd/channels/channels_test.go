@@ -1,16 +1,23 @@
 package channels
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
 )
 
 func TestRunList_Success(t *testing.T) {
@@ -89,7 +96,11 @@ func TestRunList_APIError(t *testing.T) {
 
 func TestRunGet_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "/conversations.info", r.URL.Path)
+		// get also counts pins and bookmarks; those come back empty here
+		if r.URL.Path != "/conversations.info" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+			return
+		}
 		assert.Equal(t, "C123", r.URL.Query().Get("channel"))
 
 		_ = json.NewEncoder(w).Encode(map[string]interface{}{
@@ -382,3 +393,733 @@ func TestRunUnarchive_NotInChannel(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not_in_channel")
 }
+
+// Offline workflow against the fake Slack server
+
+func TestChannelsWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	require.NoError(t, runCreate("incidents", &createOptions{}, c))
+	channels, err := c.ListChannels("public_channel", true, 10)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	id := channels[0].ID
+
+	require.NoError(t, runSetTopic(id, "Active incidents", &setTopicOptions{}, c))
+	require.NoError(t, runSetPurpose(id, "Coordinate incident response", &setPurposeOptions{}, c))
+	require.NoError(t, runInvite(id, []string{slacktest.UserID}, &inviteOptions{}, c))
+	require.NoError(t, runGet(id, &getOptions{}, c))
+	require.NoError(t, runArchive(id, &archiveOptions{force: true}, c))
+
+	ch, ok := srv.Channel(id)
+	require.True(t, ok)
+	assert.Equal(t, "Active incidents", ch.Topic)
+	assert.Equal(t, "Coordinate incident response", ch.Purpose)
+	assert.ElementsMatch(t, []string{slacktest.BotUserID, slacktest.UserID}, ch.Members)
+	assert.True(t, ch.IsArchived)
+
+	err = runArchive(id, &archiveOptions{force: true}, c)
+	assert.ErrorContains(t, err, "already_archived")
+}
+
+func TestMembershipWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	alice := slack.User{ID: "U0000ALICE", Name: "alice", RealName: "Alice Smith"}
+	alice.Profile.Email = "alice@example.com"
+	srv.AddUser(alice)
+	id := srv.AddChannel(slacktest.Channel{Name: "ops", IsPrivate: true, Members: []string{slacktest.BotUserID, slacktest.UserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	// tester is already a member: alice is still invited, tester is reported
+	err := runInvite(id, []string{"@tester,alice@example.com"}, &inviteOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 user(s) failed")
+	assert.Contains(t, buf.String(), "failed: already_in_channel")
+	assert.Contains(t, buf.String(), "Invited 1 of 2 user(s) to channel "+id)
+
+	buf.Reset()
+	require.NoError(t, runMembers(id, &membersOptions{limit: 100, names: true}, c))
+	assert.Contains(t, buf.String(), "Alice Smith")
+	assert.Contains(t, buf.String(), "alice@example.com")
+
+	buf.Reset()
+	require.NoError(t, runKick(id, []string{"@alice"}, &kickOptions{stdin: strings.NewReader("n\n")}, c))
+	assert.Contains(t, buf.String(), "Cancelled.")
+	require.NoError(t, runKick(id, []string{"@alice"}, &kickOptions{force: true}, c))
+	ch, _ := srv.Channel(id)
+	assert.Equal(t, []string{slacktest.BotUserID, slacktest.UserID}, ch.Members)
+
+	require.NoError(t, runLeave(id, &leaveOptions{}, c))
+	ch, _ = srv.Channel(id)
+	assert.Equal(t, []string{slacktest.UserID}, ch.Members)
+}
+
+func TestRunKick_Confirmation(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	id := srv.AddChannel(slacktest.Channel{Name: "ops", Members: []string{slacktest.BotUserID, slacktest.UserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	// End of input is no
+	require.NoError(t, runKick(id, []string{slacktest.UserID}, &kickOptions{stdin: strings.NewReader("")}, c))
+	assert.Contains(t, buf.String(), "Cancelled.")
+	assert.Equal(t, 0, srv.Calls("conversations.kick"))
+
+	origFormat := output.OutputFormat
+	output.OutputFormat = output.FormatJSON
+	defer func() { output.OutputFormat = origFormat }()
+
+	err := runKick(id, []string{slacktest.UserID}, &kickOptions{stdin: strings.NewReader("y\n")}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "needs --force")
+	assert.Equal(t, 0, srv.Calls("conversations.kick"))
+
+	require.NoError(t, runKick(id, []string{slacktest.UserID}, &kickOptions{force: true}, c))
+	ch, _ := srv.Channel(id)
+	assert.Equal(t, []string{slacktest.BotUserID}, ch.Members)
+}
+
+func TestRunJoin_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	id := srv.AddChannel(slacktest.Channel{Name: "random"})
+	private := srv.AddChannel(slacktest.Channel{Name: "secret", IsPrivate: true, Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runJoin(id, &joinOptions{}, c))
+	assert.Contains(t, buf.String(), "Joined channel: #random")
+	ch, _ := srv.Channel(id)
+	assert.Equal(t, []string{slacktest.BotUserID}, ch.Members)
+
+	assert.Error(t, runJoin(private, &joinOptions{}, c))
+}
+
+func TestRunMembers_JSON(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	id := srv.AddChannel(slacktest.Channel{Name: "ops", Members: []string{slacktest.BotUserID, slacktest.UserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	origWriter, origFormat := output.Writer, output.OutputFormat
+	output.Writer, output.OutputFormat = buf, output.FormatJSON
+	defer func() { output.Writer, output.OutputFormat = origWriter, origFormat }()
+
+	require.NoError(t, runMembers(id, &membersOptions{limit: 100}, c))
+	var members []string
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &members))
+	assert.Equal(t, []string{slacktest.BotUserID, slacktest.UserID}, members)
+}
+
+func TestRunGet_Details_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	id := srv.AddChannel(slacktest.Channel{
+		Name:        "partners",
+		IsExtShared: true,
+		Creator:     slacktest.UserID,
+		Created:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC).Unix(),
+		Members:     []string{slacktest.BotUserID, slacktest.UserID},
+	})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+	ts := srv.AddMessage(id, slacktest.Message{User: slacktest.UserID, Text: "welcome"})
+	require.NoError(t, c.AddPin(id, ts))
+	_, err := c.AddBookmark(id, "Runbook", "https://wiki.example.com", "")
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	origWriter, origFormat := output.Writer, output.OutputFormat
+	output.Writer, output.OutputFormat = buf, output.FormatJSON
+	defer func() { output.Writer, output.OutputFormat = origWriter, origFormat }()
+
+	require.NoError(t, runGet(id, &getOptions{}, c))
+	var got map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "tester", got["creator_name"])
+	assert.Equal(t, float64(1), got["pinned_count"])
+	assert.Equal(t, float64(1), got["bookmark_count"])
+	assert.Equal(t, true, got["is_member"])
+	assert.Equal(t, true, got["is_shared"])
+	assert.Equal(t, true, got["is_ext_shared"])
+	assert.Equal(t, ts, got["last_read"])
+
+	buf.Reset()
+	output.OutputFormat = output.FormatText
+	require.NoError(t, runGet(id, &getOptions{}, c))
+	assert.Contains(t, buf.String(), "tester ("+slacktest.UserID+")")
+	assert.Contains(t, buf.String(), "yes, with external organizations")
+}
+
+func TestRunRename_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	id := srv.AddChannel(slacktest.Channel{Name: "tmp-outage", Members: []string{slacktest.BotUserID}})
+	srv.AddChannel(slacktest.Channel{Name: "inc-db"})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runRename(id, "inc-outage", &renameOptions{}, c))
+	assert.Contains(t, buf.String(), "Renamed channel "+id+" to #inc-outage")
+	ch, _ := srv.Channel(id)
+	assert.Equal(t, "inc-outage", ch.Name)
+
+	err := runRename(id, "inc-db", &renameOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name_taken")
+
+	assert.Error(t, runRename(id, "Inc Outage", &renameOptions{}, nil))
+	assert.Error(t, runCreate("Inc Outage", &createOptions{}, nil))
+}
+
+func TestRunList_FiltersAndSort_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	day := int64(24 * 60 * 60)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	bot, user := slacktest.BotUserID, slacktest.UserID
+	srv.AddChannel(slacktest.Channel{ID: "C1", Name: "inc-db", Created: base + 10*day, Members: []string{bot, user}})
+	srv.AddChannel(slacktest.Channel{ID: "C2", Name: "inc-api", Created: base + 20*day, Members: []string{user}})
+	srv.AddChannel(slacktest.Channel{ID: "C3", Name: "general", Created: base - 100*day, Members: []string{bot, user, "U3"}})
+	srv.AddChannel(slacktest.Channel{ID: "C4", Name: "partners", Created: base + 30*day, IsExtShared: true})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	list := func(opts *listOptions) []string {
+		t.Helper()
+		buf := &bytes.Buffer{}
+		origWriter, origFormat := output.Writer, output.OutputFormat
+		output.Writer, output.OutputFormat = buf, output.FormatJSON
+		defer func() { output.Writer, output.OutputFormat = origWriter, origFormat }()
+
+		if opts.limit == 0 {
+			opts.limit = 100
+		}
+		require.NoError(t, runList(opts, c))
+		var channels []client.Channel
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &channels))
+		ids := make([]string, len(channels))
+		for i, ch := range channels {
+			ids[i] = ch.ID
+		}
+		return ids
+	}
+
+	one := 1
+	assert.Equal(t, []string{"C1", "C2"}, list(&listOptions{nameRegex: "^inc-"}))
+	assert.Equal(t, []string{"C1", "C3"}, list(&listOptions{member: true}))
+	assert.Equal(t, []string{"C3", "C1"}, list(&listOptions{minMembers: 2, sort: "members"}))
+	assert.Equal(t, []string{"C2", "C4"}, list(&listOptions{maxMembers: &one}))
+	assert.Equal(t, []string{"C4"}, list(&listOptions{shared: true}))
+	assert.Equal(t, []string{"C2", "C4"}, list(&listOptions{createdAfter: "2026-01-15"}))
+	assert.Equal(t, []string{"C4", "C2", "C1", "C3"}, list(&listOptions{sort: "created"}))
+	assert.Equal(t, []string{"C3", "C2", "C1", "C4"}, list(&listOptions{sort: "name"}))
+	assert.Equal(t, []string{"C4", "C1"}, list(&listOptions{sort: "name", reverse: true, limit: 2}))
+
+	assert.Error(t, runList(&listOptions{sort: "size"}, c))
+	assert.Error(t, runList(&listOptions{nameRegex: "("}, c))
+}
+
+func TestRunSearch_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	srv.AddChannel(slacktest.Channel{ID: "C1", Name: "inc-db", Topic: "Database outage"})
+	srv.AddChannel(slacktest.Channel{ID: "C2", Name: "ops", Purpose: "Incident follow-ups"})
+	srv.AddChannel(slacktest.Channel{ID: "C3", Name: "random"})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runSearch("INC", &searchOptions{limit: 100, field: "all"}, c))
+	assert.Contains(t, buf.String(), "Found 2 channels")
+	assert.Contains(t, buf.String(), "inc-db")
+	assert.Contains(t, buf.String(), "ops")
+
+	buf.Reset()
+	require.NoError(t, runSearch("outage", &searchOptions{limit: 100, field: "purpose"}, c))
+	assert.Contains(t, buf.String(), "No channels found")
+
+	assert.Error(t, runSearch("x", &searchOptions{limit: 100, field: "creator"}, c))
+}
+
+func TestRunStale_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	bot := slacktest.BotUserID
+	old := int64(1600000000)
+	srv.AddChannel(slacktest.Channel{ID: "C1", Name: "old-project", Created: old, Members: []string{bot, "U1", "U2"}})
+	srv.AddMessage("C1", slacktest.Message{User: "U1", Text: "last word", TS: "1600000100.000100"})
+	srv.AddChannel(slacktest.Channel{ID: "C2", Name: "active", Created: old, Members: []string{bot}})
+	srv.AddMessage("C2", slacktest.Message{User: "U1", Text: "hi"})
+	srv.AddChannel(slacktest.Channel{ID: "C3", Name: "empty", Created: old - 1000, Members: []string{bot}})
+	srv.AddChannel(slacktest.Channel{ID: "C4", Name: "general", Created: old, IsGeneral: true, Members: []string{bot}})
+	srv.AddChannel(slacktest.Channel{ID: "C5", Name: "elsewhere", Created: old})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runStale(&staleOptions{inactive: "90d", concurrency: 2}, c))
+	out := buf.String()
+	assert.Contains(t, out, "2 channel(s) inactive for 90d")
+	assert.Less(t, strings.Index(out, "empty"), strings.Index(out, "old-project"))
+	assert.NotContains(t, out, "C2")
+	assert.NotContains(t, out, "general")
+	assert.Contains(t, out, "#elsewhere (C5): bot is not a member")
+
+	// Dry run archives nothing
+	buf.Reset()
+	require.NoError(t, runStale(&staleOptions{inactive: "90d", archive: true, dryRun: true}, c))
+	assert.Contains(t, buf.String(), "Dry run: would archive 2 channel(s)")
+	ch, _ := srv.Channel("C1")
+	assert.False(t, ch.IsArchived)
+
+	// Declining the confirmation archives nothing
+	buf.Reset()
+	require.NoError(t, runStale(&staleOptions{inactive: "90d", archive: true, stdin: strings.NewReader("n\n")}, c))
+	assert.Contains(t, buf.String(), "Cancelled.")
+	ch, _ = srv.Channel("C1")
+	assert.False(t, ch.IsArchived)
+
+	buf.Reset()
+	require.NoError(t, runStale(&staleOptions{inactive: "90d", archive: true, stdin: strings.NewReader("y\n")}, c))
+	assert.Contains(t, buf.String(), "Archived 2 of 2 channel(s)")
+	for _, id := range []string{"C1", "C3"} {
+		ch, _ := srv.Channel(id)
+		assert.True(t, ch.IsArchived, id)
+	}
+	ch, _ = srv.Channel("C2")
+	assert.False(t, ch.IsArchived)
+
+	assert.Error(t, runStale(&staleOptions{inactive: "soon"}, c))
+	assert.Error(t, runStale(&staleOptions{inactive: "90d", dryRun: true}, c))
+}
+
+func TestRunStale_IgnoresChannelEvents(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	bot := slacktest.BotUserID
+	old := int64(1600000000)
+
+	// Joined on the help text's advice: the join is the newest message
+	srv.AddChannel(slacktest.Channel{ID: "C1", Name: "just-joined", Created: old, Members: []string{bot, "U1"}})
+	srv.AddMessage("C1", slacktest.Message{User: "U1", Text: "last word", TS: "1600000100.000100"})
+	srv.AddMessage("C1", slacktest.Message{User: bot, Subtype: "channel_join", Text: "<@" + bot + "> has joined the channel"})
+
+	// More events than fit in one page of history
+	srv.AddChannel(slacktest.Channel{ID: "C2", Name: "busy-doorway", Created: old, Members: []string{bot}})
+	srv.AddMessage("C2", slacktest.Message{User: "U1", Text: "anyone here?", TS: "1600000200.000100"})
+	for i := 0; i < activityPageSize+5; i++ {
+		srv.AddMessage("C2", slacktest.Message{User: "U1", Subtype: "channel_leave"})
+	}
+
+	// A real message after the join keeps a channel active
+	srv.AddChannel(slacktest.Channel{ID: "C3", Name: "alive", Created: old, Members: []string{bot}})
+	srv.AddMessage("C3", slacktest.Message{User: bot, Subtype: "channel_join"})
+	srv.AddMessage("C3", slacktest.Message{User: "U1", Text: "welcome"})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	origFormat := output.OutputFormat
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = orig
+		output.OutputFormat = origFormat
+	}()
+
+	require.NoError(t, runStale(&staleOptions{inactive: "90d"}, c))
+	var report staleReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Len(t, report.Stale, 2)
+	assert.Equal(t, "C1", report.Stale[0].ID)
+	assert.Equal(t, int64(1600000100), report.Stale[0].LastActivity)
+	assert.Equal(t, "C2", report.Stale[1].ID)
+	assert.Equal(t, int64(1600000200), report.Stale[1].LastActivity)
+}
+
+func TestRunStale_JSON(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	srv.AddChannel(slacktest.Channel{ID: "C1", Name: "old-project", Created: 1600000000, Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	origFormat := output.OutputFormat
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = orig
+		output.OutputFormat = origFormat
+	}()
+
+	// Without --force there is no way to confirm
+	err := runStale(&staleOptions{inactive: "30d", archive: true, stdin: strings.NewReader("y\n")}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+	assert.Empty(t, buf.String())
+	ch, _ := srv.Channel("C1")
+	assert.False(t, ch.IsArchived)
+
+	require.NoError(t, runStale(&staleOptions{inactive: "30d", archive: true, force: true}, c))
+	var report staleReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Len(t, report.Stale, 1)
+	assert.Equal(t, "C1", report.Stale[0].ID)
+	assert.Equal(t, int64(1600000000), report.Stale[0].LastActivity)
+	assert.True(t, report.Stale[0].Archived)
+}
+
+const applyTestSpec = `channels:
+  - name: inc-db
+    private: true
+    topic: Database outage
+    purpose: Coordinate the response
+    members: ["@tester", alice@example.com]
+    welcome: Welcome! The runbook is bookmarked above.
+    bookmarks:
+      - title: Runbook
+        link: https://wiki.example.com/runbooks/db
+  - name: ops
+    topic: Operations
+    members: [U0000ALICE]
+`
+
+func TestRunApply_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	alice := slack.User{ID: "U0000ALICE", Name: "alice"}
+	alice.Profile.Email = "alice@example.com"
+	srv.AddUser(alice)
+	ops := srv.AddChannel(slacktest.Channel{Name: "ops", Topic: "Ops", Members: []string{slacktest.BotUserID, "U0000ALICE"}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(applyTestSpec), 0o600))
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runApply(&applyOptions{file: path, dryRun: true}, c))
+	out := buf.String()
+	assert.Contains(t, out, "#inc-db (new, private)")
+	assert.Contains(t, out, `set topic to "Operations"`)
+	assert.Contains(t, out, "Plan: 7 change(s) to 2 channel(s)")
+	assert.Contains(t, out, "Dry run: no changes made")
+	assert.Equal(t, 0, srv.Calls("conversations.create"))
+
+	buf.Reset()
+	require.NoError(t, runApply(&applyOptions{file: path}, c))
+	assert.Contains(t, buf.String(), "Applied 7 of 7 change(s)")
+
+	channels, err := c.ListChannels("private_channel", true, 10)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	ch, _ := srv.Channel(channels[0].ID)
+	assert.True(t, ch.IsPrivate)
+	assert.Equal(t, "Database outage", ch.Topic)
+	assert.Equal(t, "Coordinate the response", ch.Purpose)
+	assert.Equal(t, []string{slacktest.BotUserID, slacktest.UserID, "U0000ALICE"}, ch.Members)
+	assert.Len(t, srv.Pins(ch.ID), 1)
+	require.Len(t, srv.Bookmarks(ch.ID), 1)
+	assert.Equal(t, "Runbook", srv.Bookmarks(ch.ID)[0].Title)
+	opsCh, _ := srv.Channel(ops)
+	assert.Equal(t, "Operations", opsCh.Topic)
+
+	// Applying again changes nothing
+	buf.Reset()
+	require.NoError(t, runApply(&applyOptions{file: path}, c))
+	assert.Contains(t, buf.String(), "No changes: channels match the spec")
+	assert.Equal(t, 1, srv.Calls("chat.postMessage"))
+
+	// Drift is planned as an update
+	bookmark := srv.Bookmarks(ch.ID)[0]
+	oldLink := "https://old.example.com"
+	_, err = c.EditBookmark(ch.ID, bookmark.ID, client.BookmarkUpdate{Link: &oldLink})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, runApply(&applyOptions{file: path, dryRun: true}, c))
+	assert.Contains(t, buf.String(), `update bookmark "Runbook"`)
+	assert.Contains(t, buf.String(), "Plan: 1 change(s) to 1 channel(s)")
+}
+
+func TestRunApply_JSONPlan(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	srv.AddChannel(slacktest.Channel{Name: "ops", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("channels:\n  - name: ops\n    purpose: Operations\n"), 0o600))
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	origFormat := output.OutputFormat
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = orig
+		output.OutputFormat = origFormat
+	}()
+
+	require.NoError(t, runApply(&applyOptions{file: path, dryRun: true}, c))
+	var plans []channelPlan
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &plans))
+	require.Len(t, plans, 1)
+	assert.True(t, plans[0].Exists)
+	require.Len(t, plans[0].Changes, 1)
+	assert.Equal(t, actionSetPurpose, plans[0].Changes[0].Action)
+	assert.Equal(t, "Operations", plans[0].Changes[0].Value)
+}
+
+func TestRunApply_WelcomeWithLink(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	ops := srv.AddChannel(slacktest.Channel{Name: "ops", Members: []string{slacktest.BotUserID}})
+	// The welcome as Slack returns it: entities escaped, links wrapped
+	ts := srv.AddMessage(ops, slacktest.Message{
+		User: slacktest.BotUserID,
+		Text: "Runbook &amp; status: <https://wiki.example.com/ops?a=1&amp;b=2> and <http://status.example.com|status.example.com>\n",
+	})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+	require.NoError(t, c.AddPin(ops, ts))
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	spec := "channels:\n  - name: ops\n    welcome: |\n      Runbook & status: https://wiki.example.com/ops?a=1&b=2 and status.example.com\n"
+	require.NoError(t, os.WriteFile(path, []byte(spec), 0o600))
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runApply(&applyOptions{file: path}, c))
+	assert.Contains(t, buf.String(), "No changes: channels match the spec")
+	assert.Equal(t, 0, srv.Calls("chat.postMessage"))
+}
+
+func TestRunApply_TopicWithLink(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	// The topic and purpose as Slack returns them: entities escaped, links wrapped
+	srv.AddChannel(slacktest.Channel{
+		Name:    "ops",
+		Members: []string{slacktest.BotUserID},
+		Topic:   "Status: <https://status.example.com/ops?a=1&amp;b=2>",
+		Purpose: "Deploys &amp; incidents",
+	})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	spec := "channels:\n  - name: ops\n    topic: \"Status: https://status.example.com/ops?a=1&b=2\"\n    purpose: Deploys & incidents\n"
+	require.NoError(t, os.WriteFile(path, []byte(spec), 0o600))
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runApply(&applyOptions{file: path}, c))
+	assert.Contains(t, buf.String(), "No changes: channels match the spec")
+	assert.Equal(t, 0, srv.Calls("conversations.setTopic"))
+	assert.Equal(t, 0, srv.Calls("conversations.setPurpose"))
+}
+
+func TestPlainText(t *testing.T) {
+	tests := map[string]string{
+		"plain":                                     "plain",
+		"a &amp; b &lt;c&gt;":                       "a & b <c>",
+		"see <https://example.com/x>":               "see https://example.com/x",
+		"see <http://example.com|example.com>":      "see example.com",
+		"mail <mailto:a@example.com|a@example.com>": "mail a@example.com",
+		"<@U123> and <#C123|ops> stay":              "<@U123> and <#C123|ops> stay",
+		"  trailing\n":                              "trailing",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, plainText(in), in)
+	}
+}
+
+func TestLoadApplySpec_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, spec := range map[string]string{
+		"empty":     "channels: []\n",
+		"bad name":  "channels:\n  - name: Bad Name\n",
+		"duplicate": "channels:\n  - name: ops\n  - name: ops\n",
+		"bookmark":  "channels:\n  - name: ops\n    bookmarks:\n      - title: Runbook\n",
+		"unknown":   "channels:\n  - name: ops\n    colour: blue\n",
+	} {
+		path := filepath.Join(dir, "spec.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(spec), 0o600))
+		_, err := loadApplySpec(path)
+		assert.Error(t, err, name)
+	}
+
+	_, err := loadApplySpec(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func writeNamingPolicy(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "channel-policy.yaml")
+	policy := "prefixes: [team-, proj-, inc-]\nforbidden: [tmp]\nexempt: [general]\n"
+	require.NoError(t, os.WriteFile(path, []byte(policy), 0o600))
+	return path
+}
+
+func TestRunCreate_NamingPolicy(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+	policy := writeNamingPolicy(t)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	err := runCreate("apollo", &createOptions{policy: policy}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must start with team-, proj-, inc-")
+	assert.Contains(t, err.Error(), "Suggested name: team-apollo")
+	assert.Contains(t, err.Error(), "--ignore-policy")
+	assert.Equal(t, 0, srv.Calls("conversations.create"))
+
+	require.NoError(t, runCreate("proj-apollo", &createOptions{policy: policy}, c))
+	require.NoError(t, runCreate("apollo", &createOptions{policy: policy, ignorePolicy: true}, c))
+	assert.Equal(t, 2, srv.Calls("conversations.create"))
+}
+
+func TestRunApply_NamingPolicy(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	// Existing channels are not checked
+	srv.AddChannel(slacktest.Channel{Name: "ops", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+	policy := writeNamingPolicy(t)
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	spec := "channels:\n  - name: ops\n    topic: Operations\n  - name: apollo\n  - name: proj-tmp-x\n  - name: inc-db\n"
+	require.NoError(t, os.WriteFile(path, []byte(spec), 0o600))
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	for _, dryRun := range []bool{true, false} {
+		err := runApply(&applyOptions{file: path, dryRun: dryRun, policy: policy}, c)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2 new channel(s) break the naming policy")
+		assert.Contains(t, err.Error(), "#apollo: must start with team-, proj-, inc- (suggested: team-apollo)")
+		assert.Contains(t, err.Error(), `#proj-tmp-x: contains "tmp"`)
+		assert.NotContains(t, err.Error(), "#ops")
+		assert.NotContains(t, err.Error(), "#inc-db")
+		assert.Contains(t, err.Error(), "--ignore-policy")
+	}
+	assert.Equal(t, 0, srv.Calls("conversations.create"))
+
+	require.NoError(t, runApply(&applyOptions{file: path, policy: policy, ignorePolicy: true}, c))
+	assert.Equal(t, 3, srv.Calls("conversations.create"))
+}
+
+func TestRunLint_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	srv.AddChannel(slacktest.Channel{ID: "C1", Name: "general", IsGeneral: true})
+	srv.AddChannel(slacktest.Channel{ID: "C2", Name: "team-platform"})
+	srv.AddChannel(slacktest.Channel{ID: "C3", Name: "proj-tmp-apollo"})
+	srv.AddChannel(slacktest.Channel{ID: "C4", Name: "apollo"})
+	srv.AddChannel(slacktest.Channel{ID: "C5", Name: "platform"})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+	policy := writeNamingPolicy(t)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	origFormat := output.OutputFormat
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = orig
+		output.OutputFormat = origFormat
+	}()
+
+	err := runLint(&lintOptions{policy: policy, excludeArchived: true}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "3 of 5 channel(s)")
+
+	var results []lintResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	require.Len(t, results, 3)
+	assert.Equal(t, lintResult{ID: "C4", Name: "apollo", Problems: []string{"must start with team-, proj-, inc-"}, Suggestion: "team-apollo"}, results[0])
+	assert.Equal(t, "C5", results[1].ID)
+	// team-platform is taken, so no rename is suggested
+	assert.Empty(t, results[1].Suggestion)
+	assert.Equal(t, "proj-apollo", results[2].Suggestion)
+
+	output.OutputFormat = origFormat
+	buf.Reset()
+	err = runLint(&lintOptions{policy: policy, excludeArchived: true}, c)
+	require.Error(t, err)
+	assert.Contains(t, buf.String(), "SUGGESTED")
+	assert.Contains(t, buf.String(), "team-apollo")
+}
+
+func TestRunLint_NoPolicy(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("SLACK_CHANNEL_POLICY", "")
+
+	err := runLint(&lintOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no naming policy found")
+}
+
+func TestRunInvite_Usergroup(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	srv.AddUser(slack.User{ID: "U0000ALICE", Name: "alice"})
+	srv.AddUser(slack.User{ID: "U0000BOB", Name: "bob"})
+	srv.AddUsergroup(slack.Usergroup{Name: "On-call", Handle: "oncall", Users: []string{"U0000ALICE", "U0000BOB"}})
+	id := srv.AddChannel(slacktest.Channel{Name: "ops", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	// alice is named twice but invited once
+	require.NoError(t, runInvite(id, []string{"@oncall", "@alice"}, &inviteOptions{}, c))
+	assert.Contains(t, buf.String(), "Invited 2 user(s) to channel "+id)
+	ch, _ := srv.Channel(id)
+	assert.Equal(t, []string{slacktest.BotUserID, "U0000ALICE", "U0000BOB"}, ch.Members)
+}
@@ -1,34 +1,63 @@
 package channels
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/naming"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
 )
 
 type createOptions struct {
-	private bool
+	private      bool
+	policy       string
+	ignorePolicy bool
 }
 
 func newCreateCmd() *cobra.Command {
 	opts := &createOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "create <name>",
-		Short: "Create a new channel",
-		Args:  cobra.ExactArgs(1),
+		Use:         "create <name>",
+		Short:       "Create a new channel",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write"),
+		Long: `Create a new channel.
+
+If a channel naming policy is configured (see 'slack-chat-api channels lint
+--help'), the name must comply with it. Use --ignore-policy to create the
+channel anyway.
+
+Examples:
+  slack-chat-api channels create proj-apollo
+  slack-chat-api channels create team-security --private`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCreate(args[0], opts, nil)
 		},
 	}
 
 	cmd.Flags().BoolVar(&opts.private, "private", false, "Create as private channel")
+	cmd.Flags().StringVar(&opts.policy, "policy", "", "Naming policy file (default $SLACK_CHANNEL_POLICY or the config directory's "+naming.PolicyFile+")")
+	cmd.Flags().BoolVar(&opts.ignorePolicy, "ignore-policy", false, "Create the channel even if its name breaks the naming policy")
 
 	return cmd
 }
 
 func runCreate(name string, opts *createOptions, c *client.Client) error {
+	if err := validate.ChannelName(name); err != nil {
+		return err
+	}
+	if !opts.ignorePolicy {
+		if err := checkNamingPolicy(name, opts.policy); err != nil {
+			return err
+		}
+	}
+
 	if c == nil {
 		var err error
 		c, err = client.New()
@@ -49,3 +78,21 @@ func runCreate(name string, opts *createOptions, c *client.Client) error {
 	output.Printf("Created channel: %s (%s)\n", channel.Name, channel.ID)
 	return nil
 }
+
+// checkNamingPolicy returns an error if name breaks the naming policy
+func checkNamingPolicy(name, path string) error {
+	policy, err := naming.Load(path)
+	if err != nil || policy == nil {
+		return err
+	}
+	problems := policy.Check(name)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	msg := fmt.Sprintf("channel name %q breaks the naming policy (%s): %s", name, policy.Path, strings.Join(problems, "; "))
+	if suggestion := policy.Suggest(name); suggestion != "" {
+		msg += fmt.Sprintf("\nSuggested name: %s", suggestion)
+	}
+	return fmt.Errorf("%s\nUse --ignore-policy to create it anyway", msg)
+}
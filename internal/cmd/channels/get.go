@@ -1,10 +1,13 @@
 package channels
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type getOptions struct{}
@@ -13,15 +16,29 @@ func newGetCmd() *cobra.Command {
 	opts := &getOptions{}
 
 	return &cobra.Command{
-		Use:   "get <channel-id>",
-		Short: "Get channel information",
-		Args:  cobra.ExactArgs(1),
+		Use:         "get <channel-id>",
+		Short:       "Get channel information",
+		Annotations: scopes.BotScopes("channels:read", "users:read", "pins:read", "bookmarks:read"),
+		Long: `Get a channel's details: creator, creation date, membership, sharing,
+topic and purpose, and how many pinned items and bookmarks it has.
+
+The creator's name and the pin and bookmark counts need users:read,
+pins:read and bookmarks:read; they are left out if the token lacks them.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runGet(args[0], opts, nil)
 		},
 	}
 }
 
+// channelDetails is a channel with the extra details get looks up
+type channelDetails struct {
+	client.Channel
+	CreatorName   string `json:"creator_name,omitempty"`
+	PinnedCount   *int   `json:"pinned_count,omitempty"`
+	BookmarkCount *int   `json:"bookmark_count,omitempty"`
+}
+
 func runGet(channelID string, opts *getOptions, c *client.Client) error {
 	if c == nil {
 		var err error
@@ -36,15 +53,52 @@ func runGet(channelID string, opts *getOptions, c *client.Client) error {
 		return err
 	}
 
+	// The extras are best effort: a missing scope should not hide the rest
+	details := channelDetails{Channel: *channel}
+	if channel.Creator != "" {
+		if u, err := c.GetUserInfo(channel.Creator); err == nil {
+			details.CreatorName = u.Name
+		}
+	}
+	if pins, err := c.ListPins(channel.ID); err == nil {
+		n := len(pins)
+		details.PinnedCount = &n
+	}
+	if bookmarks, err := c.ListBookmarks(channel.ID); err == nil {
+		n := len(bookmarks)
+		details.BookmarkCount = &n
+	}
+
 	if output.IsJSON() {
-		return output.PrintJSON(channel)
+		return output.PrintJSON(details)
 	}
 
 	output.KeyValue("ID", channel.ID)
 	output.KeyValue("Name", channel.Name)
 	output.KeyValue("Private", channel.IsPrivate)
 	output.KeyValue("Archived", channel.IsArchived)
+	if channel.IsGeneral {
+		output.KeyValue("General", true)
+	}
+	output.KeyValue("Shared", sharing(channel))
+	if channel.Created != 0 {
+		output.KeyValue("Created", time.Unix(channel.Created, 0).Format("2006-01-02 15:04"))
+	}
+	if channel.Creator != "" {
+		creator := channel.Creator
+		if details.CreatorName != "" {
+			creator = details.CreatorName + " (" + channel.Creator + ")"
+		}
+		output.KeyValue("Creator", creator)
+	}
 	output.KeyValue("Members", channel.NumMembers)
+	output.KeyValue("Is Member", channel.IsMember)
+	if details.PinnedCount != nil {
+		output.KeyValue("Pinned", *details.PinnedCount)
+	}
+	if details.BookmarkCount != nil {
+		output.KeyValue("Bookmarks", *details.BookmarkCount)
+	}
 	if channel.Topic.Value != "" {
 		output.KeyValue("Topic", channel.Topic.Value)
 	}
@@ -54,3 +108,15 @@ func runGet(channelID string, opts *getOptions, c *client.Client) error {
 
 	return nil
 }
+
+// sharing describes who else a channel is shared with
+func sharing(ch *client.Channel) string {
+	switch {
+	case ch.IsExtShared:
+		return "yes, with external organizations"
+	case ch.IsShared:
+		return "yes, with other workspaces"
+	default:
+		return "no"
+	}
+}
@@ -1,10 +1,16 @@
 package channels
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
 )
 
 type inviteOptions struct{}
@@ -13,16 +19,44 @@ func newInviteCmd() *cobra.Command {
 	opts := &inviteOptions{}
 
 	return &cobra.Command{
-		Use:   "invite <channel-id> <user-id>...",
-		Short: "Invite users to a channel",
-		Args:  cobra.MinimumNArgs(2),
+		Use:         "invite <channel-id> <user>...",
+		Short:       "Invite users to a channel",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write", "users:read", "users:read.email", "usergroups:read"),
+		Long: `Invite users to a channel. Users can be user IDs, @handles or emails,
+given as separate arguments or comma-separated. A user group, given as its
+@handle or ID, invites all of its members.
+
+Users who cannot be invited (already in the channel, not found, ...) are
+reported one by one; the others are still invited.
+
+Examples:
+  slack-chat-api channels invite C1234567890 U1234567890 @alice bob@example.com
+  slack-chat-api channels invite C1234567890 U1234567890,U2345678901
+  slack-chat-api channels invite C1234567890 @oncall`,
+		Args: cobra.MinimumNArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInvite(args[0], args[1:], opts, nil)
 		},
 	}
 }
 
-func runInvite(channelID string, userIDs []string, opts *inviteOptions, c *client.Client) error {
+// perUserInviteErrors are conversations.invite errors caused by one of the
+// users rather than the channel or token
+var perUserInviteErrors = map[string]bool{
+	"already_in_channel":       true,
+	"cant_invite":              true,
+	"cant_invite_self":         true,
+	"user_not_found":           true,
+	"user_is_restricted":       true,
+	"user_is_ultra_restricted": true,
+	"ura_max_channels":         true,
+}
+
+func runInvite(channelID string, users []string, opts *inviteOptions, c *client.Client) error {
+	if err := validate.ChannelID(channelID); err != nil {
+		return err
+	}
+
 	if c == nil {
 		var err error
 		c, err = client.New()
@@ -31,10 +65,146 @@ func runInvite(channelID string, userIDs []string, opts *inviteOptions, c *clien
 		}
 	}
 
-	if err := c.InviteToChannel(channelID, userIDs); err != nil {
+	userIDs, err := resolveUserArgs(c, users, true)
+	if err != nil {
 		return err
 	}
 
-	output.Printf("Invited %d user(s) to channel %s\n", len(userIDs), channelID)
+	results, err := inviteUsers(c, channelID, userIDs)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("invite to channel %s", channelID), err)
+	}
+
+	return printMemberResults(results, "Invited", "to", channelID)
+}
+
+// inviteUsers invites users to a channel and reports the outcome for each.
+// It returns an error only if the invite failed for a reason other than one
+// of the users.
+func inviteUsers(c *client.Client, channelID string, userIDs []string) ([]memberResult, error) {
+	// One call invites everyone. If a user spoils it, invite one at a time
+	// to find out who.
+	results := make([]memberResult, len(userIDs))
+	err := c.InviteToChannel(channelID, userIDs)
+	switch {
+	case err == nil:
+		for i, id := range userIDs {
+			results[i] = memberResult{User: id, OK: true}
+		}
+	case !perUserInviteErrors[errorCode(err)]:
+		return nil, err
+	case len(userIDs) == 1:
+		results[0] = newMemberResult(userIDs[0], err)
+	default:
+		for i, id := range userIDs {
+			results[i] = newMemberResult(id, c.InviteToChannel(channelID, []string{id}))
+		}
+	}
+	return results, nil
+}
+
+// memberResult is the outcome of adding or removing one user
+type memberResult struct {
+	User  string `json:"user"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+func newMemberResult(user string, err error) memberResult {
+	if err != nil {
+		return memberResult{User: user, Error: errorCode(err)}
+	}
+	return memberResult{User: user, OK: true}
+}
+
+// errorCode returns the Slack error code of err, or its message
+func errorCode(err error) string {
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return err.Error()
+}
+
+// resolveUserArgs resolves user arguments, which may be comma-separated,
+// to user IDs, without duplicates. With groups, user groups given by @handle
+// or ID stand for their members.
+func resolveUserArgs(c *client.Client, args []string, groups bool) ([]string, error) {
+	var refs []string
+	for _, arg := range args {
+		for _, ref := range strings.Split(arg, ",") {
+			if ref = strings.TrimSpace(ref); ref != "" {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("no users given")
+	}
+
+	if groups {
+		var err error
+		refs, err = client.ExpandUsergroups(c, refs)
+		if err != nil {
+			return nil, err
+		}
+		if len(refs) == 0 {
+			return nil, fmt.Errorf("the user groups given have no members")
+		}
+	}
+
+	ids, err := client.ResolveUsers(c, refs)
+	if err != nil {
+		return nil, err
+	}
+	return uniqueIDs(ids), nil
+}
+
+// uniqueIDs drops repeated IDs, keeping the first of each
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := ids[:0]
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// printMemberResults reports a bulk membership change, such as "Invited 2
+// user(s) to channel C123", listing each user when any failed. It returns
+// an error if any did.
+func printMemberResults(results []memberResult, verb, preposition, channelID string) error {
+	failed := 0
+	for _, r := range results {
+		if !r.OK {
+			failed++
+		}
+	}
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(results); err != nil {
+			return err
+		}
+	} else if failed == 0 {
+		output.Printf("%s %d user(s) %s channel %s\n", verb, len(results), preposition, channelID)
+	} else {
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			result := "ok"
+			if !r.OK {
+				result = "failed: " + r.Error
+			}
+			rows = append(rows, []string{r.User, result})
+		}
+		output.Table([]string{"USER", "RESULT"}, rows)
+		output.Printf("%s %d of %d user(s) %s channel %s\n", verb, len(results)-failed, len(results), preposition, channelID)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d user(s) failed", failed, len(results))
+	}
 	return nil
 }
@@ -0,0 +1,52 @@
+package channels
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type joinOptions struct{}
+
+func newJoinCmd() *cobra.Command {
+	opts := &joinOptions{}
+
+	return &cobra.Command{
+		Use:         "join <channel-id>",
+		Short:       "Join a public channel",
+		Annotations: scopes.BotScopes("channels:join"),
+		Long: `Join a public channel. Private channels can only be joined by being
+invited.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJoin(args[0], opts, nil)
+		},
+	}
+}
+
+func runJoin(channelID string, opts *joinOptions, c *client.Client) error {
+	if err := validate.ChannelID(channelID); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channel, err := c.JoinChannel(channelID)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("join channel %s", channelID), err)
+	}
+
+	output.Printf("Joined channel: #%s (%s)\n", channel.Name, channel.ID)
+	return nil
+}
@@ -0,0 +1,101 @@
+package channels
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type kickOptions struct {
+	force bool
+	stdin io.Reader // For testing
+}
+
+func newKickCmd() *cobra.Command {
+	opts := &kickOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "kick <channel-id> <user>...",
+		Aliases:     []string{"remove-member"},
+		Short:       "Remove users from a channel",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write", "users:read", "users:read.email"),
+		Long: `Remove users from a channel. Users can be user IDs, @handles or emails,
+given as separate arguments or comma-separated. Each user is removed
+separately and failures are reported per user.
+
+Examples:
+  slack-chat-api channels kick C1234567890 @alice
+  slack-chat-api channels kick C1234567890 alice@example.com,bob@example.com --force`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKick(args[0], args[1:], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runKick(channelID string, users []string, opts *kickOptions, c *client.Client) error {
+	if err := validate.ChannelID(channelID); err != nil {
+		return err
+	}
+	// A prompt would corrupt the JSON
+	if !opts.force && output.IsJSON() {
+		return fmt.Errorf("kick with -o json needs --force")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	userIDs, err := resolveUserArgs(c, users, false)
+	if err != nil {
+		return err
+	}
+
+	if !opts.force && !confirmKick(opts.stdin, channelID, userIDs) {
+		output.Println("Cancelled.")
+		return nil
+	}
+
+	results := make([]memberResult, len(userIDs))
+	for i, id := range userIDs {
+		results[i] = newMemberResult(id, c.KickFromChannel(channelID, id))
+	}
+
+	return printMemberResults(results, "Removed", "from", channelID)
+}
+
+// confirmKick asks before removing users. Anything but yes, including end
+// of input, is no.
+func confirmKick(stdin io.Reader, channelID string, userIDs []string) bool {
+	reader := stdin
+	if reader == nil {
+		reader = os.Stdin
+	}
+
+	output.Printf("About to remove %d user(s) from channel %s: %s\n", len(userIDs), channelID, strings.Join(userIDs, ", "))
+	output.Printf("Are you sure? [y/N]: ")
+
+	scanner := bufio.NewScanner(reader)
+	if scanner.Scan() {
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		return confirm == "y" || confirm == "yes"
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package channels
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type leaveOptions struct{}
+
+func newLeaveCmd() *cobra.Command {
+	opts := &leaveOptions{}
+
+	return &cobra.Command{
+		Use:         "leave <channel-id>",
+		Short:       "Leave a channel",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLeave(args[0], opts, nil)
+		},
+	}
+}
+
+func runLeave(channelID string, opts *leaveOptions, c *client.Client) error {
+	if err := validate.ChannelID(channelID); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.LeaveChannel(channelID); err != nil {
+		return client.WrapError(fmt.Sprintf("leave channel %s", channelID), err)
+	}
+
+	output.Printf("Left channel: %s\n", channelID)
+	return nil
+}
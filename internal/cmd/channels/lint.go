@@ -0,0 +1,134 @@
+package channels
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/naming"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type lintOptions struct {
+	policy          string
+	types           string
+	excludeArchived bool
+}
+
+func newLintCmd() *cobra.Command {
+	opts := &lintOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "lint",
+		Short:       "Report channels that break the naming policy",
+		Annotations: scopes.BotScopes("channels:read", "groups:read"),
+		Long: `Report channels whose names break the channel naming policy, with a
+suggested rename where one can be found. Exits with an error if any do.
+
+The policy is a YAML file, read from --policy, $SLACK_CHANNEL_POLICY, or
+` + naming.PolicyFile + ` in the config directory (~/.config/slack-chat-api):
+
+  prefixes: [team-, proj-, inc-]   # names must start with one of these
+  forbidden: [tmp, test]           # words that may not appear in a name
+  pattern: '^[a-z0-9-]+$'          # a regular expression names must match
+  exempt: [general, random]        # names the policy does not apply to
+
+'channels create' refuses names that break the policy unless given
+--ignore-policy.
+
+Examples:
+  slack-chat-api channels lint
+  slack-chat-api channels lint --policy ./channel-policy.yaml -o json
+  slack-chat-api channels lint --types public_channel,private_channel`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLint(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.policy, "policy", "", "Naming policy file (default $SLACK_CHANNEL_POLICY or the config directory's "+naming.PolicyFile+")")
+	cmd.Flags().StringVar(&opts.types, "types", "", "Channel types (public_channel,private_channel)")
+	cmd.Flags().BoolVar(&opts.excludeArchived, "exclude-archived", true, "Exclude archived channels")
+
+	return cmd
+}
+
+// lintResult is a channel that breaks the naming policy
+type lintResult struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Problems   []string `json:"problems"`
+	Suggestion string   `json:"suggestion,omitempty"`
+}
+
+func runLint(opts *lintOptions, c *client.Client) error {
+	policy, err := naming.Load(opts.policy)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return fmt.Errorf("no naming policy found at %s: create one or pass --policy (see 'slack-chat-api channels lint --help')", naming.DefaultPath())
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channels, err := c.ListChannels(opts.types, opts.excludeArchived, math.MaxInt32)
+	if err != nil {
+		return client.WrapError("list channels", err)
+	}
+
+	taken := make(map[string]bool, len(channels))
+	for _, ch := range channels {
+		taken[ch.Name] = true
+	}
+
+	results := []lintResult{}
+	for _, ch := range channels {
+		problems := policy.Check(ch.Name)
+		if len(problems) == 0 {
+			continue
+		}
+		r := lintResult{ID: ch.ID, Name: ch.Name, Problems: problems}
+		// A suggestion is only useful if the name is free
+		if suggestion := policy.Suggest(ch.Name); suggestion != "" && !taken[suggestion] {
+			r.Suggestion = suggestion
+			taken[suggestion] = true
+		}
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(results); err != nil {
+			return err
+		}
+	} else if len(results) == 0 {
+		output.Printf("All %d channel(s) follow the naming policy\n", len(channels))
+	} else {
+		rows := make([][]string, 0, len(results))
+		for _, r := range results {
+			suggestion := r.Suggestion
+			if suggestion == "" {
+				suggestion = "-"
+			}
+			rows = append(rows, []string{r.ID, r.Name, strings.Join(r.Problems, "; "), suggestion})
+		}
+		output.Table([]string{"ID", "NAME", "PROBLEMS", "SUGGESTED"}, rows)
+		output.Printf("\nRename with: slack-chat-api channels rename <id> <new-name>\n")
+	}
+
+	if len(results) > 0 {
+		return fmt.Errorf("%d of %d channel(s) break the naming policy", len(results), len(channels))
+	}
+	return nil
+}
@@ -2,26 +2,60 @@ package channels
 
 import (
 	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/timeparse"
 )
 
+// ValidSorts contains the allowed --sort values
+var ValidSorts = []string{"name", "members", "created"}
+
 type listOptions struct {
 	types           string
 	excludeArchived bool
 	limit           int
+	member          bool
+	nameRegex       string
+	minMembers      int
+	maxMembers      *int // nil means no maximum
+	createdAfter    string
+	shared          bool
+	sort            string
+	reverse         bool
+	now             func() time.Time // For testing
 }
 
 func newListCmd() *cobra.Command {
 	opts := &listOptions{}
+	var maxMembers int
 
 	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List all channels",
+		Use:         "list",
+		Short:       "List all channels",
+		Annotations: scopes.BotScopes("channels:read", "groups:read", "im:read", "mpim:read"),
+		Long: `List channels.
+
+Filters are applied locally, so when any filter or --sort is given every
+channel is fetched and --limit applies to the result.
+
+Examples:
+  slack-chat-api channels list --member --sort members --reverse
+  slack-chat-api channels list --name-regex '^inc-' --created-after 2026-01-01
+  slack-chat-api channels list --types public_channel,private_channel --max-members 1
+  slack-chat-api channels list --shared`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("max-members") {
+				opts.maxMembers = &maxMembers
+			}
 			return runList(opts, nil)
 		},
 	}
@@ -29,24 +63,151 @@ func newListCmd() *cobra.Command {
 	cmd.Flags().StringVar(&opts.types, "types", "", "Channel types (public_channel,private_channel,mpim,im)")
 	cmd.Flags().BoolVar(&opts.excludeArchived, "exclude-archived", true, "Exclude archived channels")
 	cmd.Flags().IntVar(&opts.limit, "limit", 100, "Maximum channels to return")
+	cmd.Flags().BoolVar(&opts.member, "member", false, "Only channels the bot is a member of")
+	cmd.Flags().StringVar(&opts.nameRegex, "name-regex", "", "Only channels whose name matches this regular expression")
+	cmd.Flags().IntVar(&opts.minMembers, "min-members", 0, "Only channels with at least this many members")
+	cmd.Flags().IntVar(&maxMembers, "max-members", 0, "Only channels with at most this many members")
+	cmd.Flags().StringVar(&opts.createdAfter, "created-after", "", "Only channels created after this time (e.g., 2026-01-01, \"30 days ago\")")
+	cmd.Flags().BoolVar(&opts.shared, "shared", false, "Only channels shared with other workspaces or organizations")
+	cmd.Flags().StringVar(&opts.sort, "sort", "", "Sort by: name, members, created")
+	cmd.Flags().BoolVar(&opts.reverse, "reverse", false, "Reverse the sort order")
 
 	return cmd
 }
 
+// filtering reports whether the options narrow or reorder the list, which
+// needs every channel
+func (o *listOptions) filtering() bool {
+	return o.nameRegex != "" || o.minMembers > 0 || o.maxMembers != nil ||
+		o.createdAfter != "" || o.shared || o.sort != ""
+}
+
+// channelFilter is a compiled set of list filters
+type channelFilter struct {
+	name         *regexp.Regexp
+	minMembers   int
+	maxMembers   *int
+	createdAfter time.Time
+	shared       bool
+}
+
+func (o *listOptions) filter() (*channelFilter, error) {
+	f := &channelFilter{minMembers: o.minMembers, maxMembers: o.maxMembers, shared: o.shared}
+	if o.nameRegex != "" {
+		re, err := regexp.Compile(o.nameRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --name-regex: %w", err)
+		}
+		f.name = re
+	}
+	if o.createdAfter != "" {
+		now := time.Now()
+		if o.now != nil {
+			now = o.now()
+		}
+		t, err := timeparse.Parse(o.createdAfter, now, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		f.createdAfter = t
+	}
+	return f, nil
+}
+
+func (f *channelFilter) match(ch client.Channel) bool {
+	switch {
+	case f.name != nil && !f.name.MatchString(ch.Name):
+		return false
+	case ch.NumMembers < f.minMembers:
+		return false
+	case f.maxMembers != nil && ch.NumMembers > *f.maxMembers:
+		return false
+	case !f.createdAfter.IsZero() && !time.Unix(ch.Created, 0).After(f.createdAfter):
+		return false
+	case f.shared && !ch.IsShared && !ch.IsExtShared:
+		return false
+	}
+	return true
+}
+
+// sortChannels orders channels by name, member count (largest first) or
+// creation time (newest first)
+func sortChannels(channels []client.Channel, by string, reverse bool) {
+	var less func(a, b client.Channel) bool
+	switch by {
+	case "name":
+		less = func(a, b client.Channel) bool { return a.Name < b.Name }
+	case "members":
+		less = func(a, b client.Channel) bool { return a.NumMembers > b.NumMembers }
+	case "created":
+		less = func(a, b client.Channel) bool { return a.Created > b.Created }
+	default:
+		return
+	}
+	sort.SliceStable(channels, func(i, j int) bool {
+		if reverse {
+			return less(channels[j], channels[i])
+		}
+		return less(channels[i], channels[j])
+	})
+}
+
+func validateSort(by string) error {
+	if by == "" {
+		return nil
+	}
+	for _, s := range ValidSorts {
+		if by == s {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid sort: %q (must be one of: name, members, created)", by)
+}
+
 func runList(opts *listOptions, c *client.Client) error {
+	if err := validateSort(opts.sort); err != nil {
+		return err
+	}
+	filter, err := opts.filter()
+	if err != nil {
+		return err
+	}
+
 	if c == nil {
-		var err error
 		c, err = client.New()
 		if err != nil {
 			return err
 		}
 	}
 
-	channels, err := c.ListChannels(opts.types, opts.excludeArchived, opts.limit)
+	fetch := opts.limit
+	if opts.filtering() {
+		fetch = math.MaxInt32
+	}
+	var channels []client.Channel
+	if opts.member {
+		channels, err = c.ListMemberChannels(opts.types, opts.excludeArchived, fetch)
+	} else {
+		channels, err = c.ListChannels(opts.types, opts.excludeArchived, fetch)
+	}
 	if err != nil {
 		return err
 	}
 
+	if opts.filtering() {
+		matched := make([]client.Channel, 0, len(channels))
+		for _, ch := range channels {
+			if filter.match(ch) {
+				matched = append(matched, ch)
+			}
+		}
+		sortChannels(matched, opts.sort, opts.reverse)
+		if len(matched) > opts.limit {
+			matched = matched[:opts.limit]
+		}
+		channels = matched
+	}
+
 	if output.IsJSON() {
 		return output.PrintJSON(channels)
 	}
@@ -56,16 +217,23 @@ func runList(opts *listOptions, c *client.Client) error {
 		return nil
 	}
 
-	headers := []string{"ID", "NAME", "MEMBERS"}
+	printChannels(channels)
+	return nil
+}
+
+func printChannels(channels []client.Channel) {
+	headers := []string{"ID", "NAME", "MEMBERS", "CREATED"}
 	rows := make([][]string, 0, len(channels))
 	for _, ch := range channels {
 		members := fmt.Sprintf("%d", ch.NumMembers)
 		if ch.IsPrivate {
 			members += " (private)"
 		}
-		rows = append(rows, []string{ch.ID, ch.Name, members})
+		created := ""
+		if ch.Created != 0 {
+			created = time.Unix(ch.Created, 0).Format("2006-01-02")
+		}
+		rows = append(rows, []string{ch.ID, ch.Name, members, created})
 	}
 	output.Table(headers, rows)
-
-	return nil
 }
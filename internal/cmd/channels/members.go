@@ -0,0 +1,115 @@
+package channels
+
+import (
+	"math"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type membersOptions struct {
+	limit int
+	names bool
+}
+
+func newMembersCmd() *cobra.Command {
+	opts := &membersOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "members <channel-id>",
+		Short:       "List a channel's members",
+		Annotations: scopes.BotScopes("channels:read", "groups:read", "users:read", "users:read.email"),
+		Long: `List the members of a channel. With --names, each member's username, real
+name and email are looked up too.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMembers(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.limit, "limit", 1000, "Maximum members to return")
+	cmd.Flags().BoolVar(&opts.names, "names", false, "Look up member names and emails")
+
+	return cmd
+}
+
+func runMembers(channelID string, opts *membersOptions, c *client.Client) error {
+	if err := validate.ChannelID(channelID); err != nil {
+		return err
+	}
+	if err := validate.Limit(opts.limit); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	members, err := c.ListChannelMembers(channelID, opts.limit)
+	if err != nil {
+		return client.WrapError("list members of "+channelID, err)
+	}
+
+	if !opts.names {
+		if output.IsJSON() {
+			return output.PrintJSON(members)
+		}
+		if len(members) == 0 {
+			output.Println("No members found")
+			return nil
+		}
+		rows := make([][]string, len(members))
+		for i, id := range members {
+			rows[i] = []string{id}
+		}
+		output.Table([]string{"ID"}, rows)
+		return nil
+	}
+
+	// One directory listing is cheaper than a users.info call per member
+	all, err := c.ListUsers(math.MaxInt32)
+	if err != nil {
+		return client.WrapError("list users", err)
+	}
+	byID := make(map[string]client.User, len(all))
+	for _, u := range all {
+		byID[u.ID] = u
+	}
+	users := make([]client.User, len(members))
+	for i, id := range members {
+		u, ok := byID[id]
+		if !ok {
+			u = client.User{ID: id}
+		}
+		users[i] = u
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(users)
+	}
+
+	if len(users) == 0 {
+		output.Println("No members found")
+		return nil
+	}
+
+	headers := []string{"ID", "USERNAME", "REAL NAME", "EMAIL", "BOT"}
+	rows := make([][]string, len(users))
+	for i, u := range users {
+		bot := ""
+		if u.IsBot {
+			bot = "yes"
+		}
+		rows[i] = []string{u.ID, u.Name, u.RealName, u.Profile.Email, bot}
+	}
+	output.Table(headers, rows)
+	return nil
+}
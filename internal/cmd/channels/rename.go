@@ -0,0 +1,59 @@
+package channels
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type renameOptions struct{}
+
+func newRenameCmd() *cobra.Command {
+	opts := &renameOptions{}
+
+	return &cobra.Command{
+		Use:         "rename <channel-id> <new-name>",
+		Short:       "Rename a channel",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write"),
+		Long: `Rename a channel. Names are at most 80 lowercase letters, numbers, hyphens
+and underscores.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRename(args[0], args[1], opts, nil)
+		},
+	}
+}
+
+func runRename(channelID, name string, opts *renameOptions, c *client.Client) error {
+	if err := validate.ChannelID(channelID); err != nil {
+		return err
+	}
+	if err := validate.ChannelName(name); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channel, err := c.RenameChannel(channelID, name)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("rename channel %s", channelID), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(channel)
+	}
+
+	output.Printf("Renamed channel %s to #%s\n", channel.ID, channel.Name)
+	return nil
+}
@@ -0,0 +1,125 @@
+package channels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+// ValidSearchFields contains the allowed --field values for search
+var ValidSearchFields = []string{"all", "name", "topic", "purpose"}
+
+type searchOptions struct {
+	types           string
+	excludeArchived bool
+	limit           int
+	field           string
+}
+
+func newSearchCmd() *cobra.Command {
+	opts := &searchOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "search <query>",
+		Short:       "Search channels by name, topic, or purpose",
+		Annotations: scopes.BotScopes("channels:read", "groups:read", "im:read", "mpim:read"),
+		Long: `Search channels by name, topic, or purpose.
+
+Uses the conversations.list API with local, case-insensitive filtering.
+
+By default, searches across all fields. Use --field to limit search to one.
+
+Examples:
+  slack-chat-api channels search incident
+  slack-chat-api channels search "on-call" --field topic
+  slack-chat-api channels search apollo --types public_channel,private_channel`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSearch(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.types, "types", "", "Channel types (public_channel,private_channel,mpim,im)")
+	cmd.Flags().BoolVar(&opts.excludeArchived, "exclude-archived", true, "Exclude archived channels")
+	cmd.Flags().IntVar(&opts.limit, "limit", 1000, "Maximum channels to search through")
+	cmd.Flags().StringVar(&opts.field, "field", "all", "Search field: all, name, topic, purpose")
+
+	return cmd
+}
+
+func validateSearchField(field string) error {
+	for _, f := range ValidSearchFields {
+		if field == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid field: %q (must be one of: %s)", field, strings.Join(ValidSearchFields, ", "))
+}
+
+func runSearch(query string, opts *searchOptions, c *client.Client) error {
+	if err := validateSearchField(opts.field); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channels, err := c.ListChannels(opts.types, opts.excludeArchived, opts.limit)
+	if err != nil {
+		return err
+	}
+
+	queryLower := strings.ToLower(query)
+	var matches []client.Channel
+	for _, ch := range channels {
+		if channelMatchesQuery(ch, queryLower, opts.field) {
+			matches = append(matches, ch)
+		}
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(matches)
+	}
+
+	if len(matches) == 0 {
+		output.Printf("No channels found matching \"%s\"\n", query)
+		return nil
+	}
+
+	output.Printf("Found %d channels matching \"%s\"\n\n", len(matches), query)
+
+	headers := []string{"ID", "NAME", "MEMBERS", "TOPIC"}
+	rows := make([][]string, 0, len(matches))
+	for _, ch := range matches {
+		rows = append(rows, []string{ch.ID, ch.Name, fmt.Sprintf("%d", ch.NumMembers), ch.Topic.Value})
+	}
+	output.Table(headers, rows)
+
+	return nil
+}
+
+func channelMatchesQuery(ch client.Channel, queryLower, field string) bool {
+	name := strings.Contains(strings.ToLower(ch.Name), queryLower)
+	topic := strings.Contains(strings.ToLower(ch.Topic.Value), queryLower)
+	purpose := strings.Contains(strings.ToLower(ch.Purpose.Value), queryLower)
+	switch field {
+	case "name":
+		return name
+	case "topic":
+		return topic
+	case "purpose":
+		return purpose
+	default: // "all"
+		return name || topic || purpose
+	}
+}
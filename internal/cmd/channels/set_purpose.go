@@ -5,6 +5,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type setPurposeOptions struct{}
@@ -13,9 +14,10 @@ func newSetPurposeCmd() *cobra.Command {
 	opts := &setPurposeOptions{}
 
 	return &cobra.Command{
-		Use:   "set-purpose <channel-id> <purpose>",
-		Short: "Set channel purpose",
-		Args:  cobra.ExactArgs(2),
+		Use:         "set-purpose <channel-id> <purpose>",
+		Short:       "Set channel purpose",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write"),
+		Args:        cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSetPurpose(args[0], args[1], opts, nil)
 		},
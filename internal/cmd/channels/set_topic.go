@@ -5,6 +5,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type setTopicOptions struct{}
@@ -13,9 +14,10 @@ func newSetTopicCmd() *cobra.Command {
 	opts := &setTopicOptions{}
 
 	return &cobra.Command{
-		Use:   "set-topic <channel-id> <topic>",
-		Short: "Set channel topic",
-		Args:  cobra.ExactArgs(2),
+		Use:         "set-topic <channel-id> <topic>",
+		Short:       "Set channel topic",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write"),
+		Args:        cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runSetTopic(args[0], args[1], opts, nil)
 		},
@@ -0,0 +1,338 @@
+package channels
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/timeparse"
+)
+
+// defaultStaleConcurrency is how many channels' history is checked at once.
+// conversations.history allows about 50 calls a minute; the client waits
+// out any rate limiting.
+const defaultStaleConcurrency = 4
+
+type staleOptions struct {
+	inactive    string
+	types       string
+	concurrency int
+	archive     bool
+	dryRun      bool
+	force       bool
+	stdin       io.Reader // For testing
+}
+
+func newStaleCmd() *cobra.Command {
+	opts := &staleOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "stale",
+		Short:       "Find inactive channels, and optionally archive them",
+		Annotations: scopes.BotScopes("channels:read", "groups:read", "channels:history", "groups:history", "channels:manage", "groups:write"),
+		Long: `Find channels with no messages for a while, based on each channel's latest
+message. Joins, leaves, and topic, purpose and name changes don't count. A
+channel with no messages counts as active from its creation.
+
+Only channels the bot is a member of can be checked; others are listed as
+unchecked (use 'slack-chat-api channels join' first). The general channel is
+never reported.
+
+With --archive, the stale channels are archived after a single confirmation.
+Use --dry-run to see what would be archived without archiving anything. With
+-o json there is no prompt, so --archive needs --force or --dry-run.
+
+Examples:
+  slack-chat-api channels stale --inactive 90d
+  slack-chat-api channels stale --inactive 180d --types public_channel,private_channel -o json
+  slack-chat-api channels stale --inactive 90d --archive --dry-run
+  slack-chat-api channels stale --inactive 90d --archive`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStale(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.inactive, "inactive", "90d", "How long without messages makes a channel stale (e.g., 90d, 12w)")
+	cmd.Flags().StringVar(&opts.types, "types", "", "Channel types (public_channel,private_channel)")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "j", defaultStaleConcurrency, "Channels to check at once")
+	cmd.Flags().BoolVar(&opts.archive, "archive", false, "Archive the stale channels")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "With --archive, show what would be archived without archiving")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// staleChannel is a channel in the stale report
+type staleChannel struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	NumMembers   int    `json:"num_members"`
+	LastActivity int64  `json:"last_activity"`
+	InactiveDays int    `json:"inactive_days"`
+	Archived     bool   `json:"archived,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// uncheckedChannel is a channel whose activity could not be read
+type uncheckedChannel struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+type staleReport struct {
+	Inactive  string             `json:"inactive"`
+	Stale     []staleChannel     `json:"stale"`
+	Unchecked []uncheckedChannel `json:"unchecked,omitempty"`
+}
+
+func runStale(opts *staleOptions, c *client.Client) error {
+	inactive, err := timeparse.ParseDuration(opts.inactive)
+	if err != nil {
+		return err
+	}
+	if inactive <= 0 {
+		return fmt.Errorf("--inactive must be positive")
+	}
+	if opts.dryRun && !opts.archive {
+		return fmt.Errorf("--dry-run only applies with --archive")
+	}
+	// A prompt would corrupt the JSON, and the list it asks about is not shown
+	if opts.archive && !opts.dryRun && !opts.force && output.IsJSON() {
+		return fmt.Errorf("--archive with -o json needs --force or --dry-run")
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channels, err := c.ListChannels(opts.types, true, math.MaxInt32)
+	if err != nil {
+		return client.WrapError("list channels", err)
+	}
+
+	now := time.Now()
+	report := findStale(c, channels, now.Add(-inactive), now, opts.concurrency)
+	report.Inactive = opts.inactive
+
+	if opts.archive && len(report.Stale) > 0 {
+		if output.IsJSON() && opts.dryRun {
+			return output.PrintJSON(report)
+		}
+		if !output.IsJSON() {
+			printStale(report)
+		}
+		if opts.dryRun {
+			output.Printf("\nDry run: would archive %d channel(s)\n", len(report.Stale))
+			return nil
+		}
+		if !opts.force && !confirmArchive(opts.stdin, len(report.Stale)) {
+			output.Println("Cancelled.")
+			return nil
+		}
+		return archiveStale(c, report)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(report)
+	}
+	printStale(report)
+	return nil
+}
+
+// findStale reads the latest message of each channel, a few channels at a
+// time, and returns those last active before cutoff, oldest first
+func findStale(c *client.Client, channels []client.Channel, cutoff, now time.Time, concurrency int) *staleReport {
+	report := &staleReport{Stale: []staleChannel{}}
+	var checks []client.Channel
+	for _, ch := range channels {
+		switch {
+		case ch.IsGeneral || ch.IsArchived:
+		case !ch.IsMember:
+			report.Unchecked = append(report.Unchecked, uncheckedChannel{ID: ch.ID, Name: ch.Name, Reason: "bot is not a member"})
+		default:
+			checks = append(checks, ch)
+		}
+	}
+
+	if concurrency < 1 {
+		concurrency = defaultStaleConcurrency
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	work := make(chan client.Channel)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ch := range work {
+				last, err := lastActivity(c, ch)
+				mu.Lock()
+				switch {
+				case err != nil:
+					report.Unchecked = append(report.Unchecked, uncheckedChannel{ID: ch.ID, Name: ch.Name, Reason: errorCode(err)})
+				case last.Before(cutoff):
+					report.Stale = append(report.Stale, staleChannel{
+						ID:           ch.ID,
+						Name:         ch.Name,
+						NumMembers:   ch.NumMembers,
+						LastActivity: last.Unix(),
+						InactiveDays: int(now.Sub(last).Hours() / 24),
+					})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, ch := range checks {
+		work <- ch
+	}
+	close(work)
+	wg.Wait()
+
+	sort.Slice(report.Stale, func(i, j int) bool {
+		a, b := report.Stale[i], report.Stale[j]
+		if a.LastActivity != b.LastActivity {
+			return a.LastActivity < b.LastActivity
+		}
+		return a.Name < b.Name
+	})
+	sort.Slice(report.Unchecked, func(i, j int) bool { return report.Unchecked[i].Name < report.Unchecked[j].Name })
+	return report
+}
+
+const (
+	// activityPageSize is how many messages lastActivity reads at a time
+	// while looking past joins, leaves and topic changes
+	activityPageSize = 20
+	// activityMaxPages bounds how far back lastActivity looks
+	activityMaxPages = 5
+)
+
+// lastActivity returns when a channel's latest message was posted, or when
+// the channel was created if it has none. Joins, leaves, and topic, purpose
+// and name changes don't count, so joining a channel to check it doesn't
+// make it look active.
+func lastActivity(c *client.Client, ch client.Channel) (time.Time, error) {
+	created := time.Unix(ch.Created, 0)
+	latest := ""
+	for page := 0; page < activityMaxPages; page++ {
+		messages, err := c.GetChannelHistory(ch.ID, activityPageSize, "", latest)
+		if err != nil {
+			return time.Time{}, err
+		}
+		for _, m := range messages {
+			if isChannelEvent(m) {
+				continue
+			}
+			sec, err := strconv.ParseFloat(m.TS, 64)
+			if err == nil && int64(sec) > ch.Created {
+				return time.Unix(int64(sec), 0), nil
+			}
+			return created, nil
+		}
+		if len(messages) < activityPageSize {
+			break
+		}
+		latest = messages[len(messages)-1].TS
+	}
+	return created, nil
+}
+
+// isChannelEvent reports whether a message is a system message about the
+// channel itself (channel_join, channel_topic, group_leave and the like)
+func isChannelEvent(m client.Message) bool {
+	return strings.HasPrefix(m.Subtype, "channel_") || strings.HasPrefix(m.Subtype, "group_")
+}
+
+func confirmArchive(stdin io.Reader, n int) bool {
+	reader := stdin
+	if reader == nil {
+		reader = os.Stdin
+	}
+
+	output.Printf("\nAbout to archive %d channel(s)\n", n)
+	output.Printf("Are you sure? [y/N]: ")
+
+	scanner := bufio.NewScanner(reader)
+	if scanner.Scan() {
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		return confirm == "y" || confirm == "yes"
+	}
+	return false
+}
+
+// archiveStale archives every stale channel and reports the results
+func archiveStale(c *client.Client, report *staleReport) error {
+	failed := 0
+	for i := range report.Stale {
+		ch := &report.Stale[i]
+		if err := c.ArchiveChannel(ch.ID); err != nil {
+			ch.Error = errorCode(err)
+			failed++
+			continue
+		}
+		ch.Archived = true
+	}
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(report); err != nil {
+			return err
+		}
+	} else {
+		for _, ch := range report.Stale {
+			if ch.Error != "" {
+				output.Printf("Failed to archive #%s (%s): %s\n", ch.Name, ch.ID, ch.Error)
+			}
+		}
+		output.Printf("Archived %d of %d channel(s)\n", len(report.Stale)-failed, len(report.Stale))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d channel(s) could not be archived", failed, len(report.Stale))
+	}
+	return nil
+}
+
+func printStale(report *staleReport) {
+	if len(report.Stale) == 0 {
+		output.Printf("No channels inactive for %s\n", report.Inactive)
+	} else {
+		output.Printf("%d channel(s) inactive for %s\n\n", len(report.Stale), report.Inactive)
+		headers := []string{"ID", "NAME", "MEMBERS", "LAST ACTIVITY", "INACTIVE DAYS"}
+		rows := make([][]string, 0, len(report.Stale))
+		for _, ch := range report.Stale {
+			rows = append(rows, []string{
+				ch.ID,
+				ch.Name,
+				fmt.Sprintf("%d", ch.NumMembers),
+				time.Unix(ch.LastActivity, 0).Format("2006-01-02"),
+				fmt.Sprintf("%d", ch.InactiveDays),
+			})
+		}
+		output.Table(headers, rows)
+	}
+
+	if len(report.Unchecked) > 0 {
+		output.Printf("\nCould not check %d channel(s):\n", len(report.Unchecked))
+		for _, ch := range report.Unchecked {
+			output.Printf("  #%s (%s): %s\n", ch.Name, ch.ID, ch.Reason)
+		}
+	}
+}
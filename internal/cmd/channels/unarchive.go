@@ -7,6 +7,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type unarchiveOptions struct{}
@@ -15,9 +16,10 @@ func newUnarchiveCmd() *cobra.Command {
 	opts := &unarchiveOptions{}
 
 	return &cobra.Command{
-		Use:   "unarchive <channel-id>",
-		Short: "Unarchive a channel",
-		Args:  cobra.ExactArgs(1),
+		Use:         "unarchive <channel-id>",
+		Short:       "Unarchive a channel",
+		Annotations: scopes.BotScopes("channels:manage", "groups:write"),
+		Args:        cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runUnarchive(args[0], opts, nil)
 		},
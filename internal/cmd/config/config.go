@@ -11,10 +11,12 @@ func NewCmd() *cobra.Command {
 		Short: "Configure slack-chat-api",
 	}
 
+	cmd.AddCommand(newLoginCmd())
 	cmd.AddCommand(newSetTokenCmd())
 	cmd.AddCommand(newDeleteTokenCmd())
 	cmd.AddCommand(newShowCmd())
 	cmd.AddCommand(newTestCmd())
+	cmd.AddCommand(newDoctorCmd())
 
 	return cmd
 }
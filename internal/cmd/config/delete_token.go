@@ -53,8 +53,21 @@ func runDeleteToken(opts *deleteTokenOptions) error {
 	hasUserToken := keychain.HasStoredUserToken()
 
 	// Determine what we're deleting
-	deleteBot := (opts.tokenType == "bot" || opts.tokenType == "all") && hasBotToken
-	deleteUser := (opts.tokenType == "user" || opts.tokenType == "all") && hasUserToken
+	wantBot := opts.tokenType == "bot" || opts.tokenType == "all"
+	wantUser := opts.tokenType == "user" || opts.tokenType == "all"
+	deleteBot := wantBot && hasBotToken
+	deleteUser := wantUser && hasUserToken
+
+	// Scopes and rotation data left behind without a stored token would
+	// otherwise be shown against a token set through the environment
+	if wantBot && !hasBotToken {
+		keychain.DeleteAPITokenScopes()
+		keychain.DeleteAPITokenRotation()
+	}
+	if wantUser && !hasUserToken {
+		keychain.DeleteUserTokenScopes()
+		keychain.DeleteUserTokenRotation()
+	}
 
 	if !deleteBot && !deleteUser {
 		switch opts.tokenType {
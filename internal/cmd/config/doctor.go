@@ -0,0 +1,213 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/keychain"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+const (
+	statusOK      = "ok"
+	statusMissing = "missing scopes"
+	statusNoToken = "no token"
+	statusUnknown = "unknown"
+)
+
+type doctorOptions struct{}
+
+type tokenReport struct {
+	Token      scopes.Token `json:"token"`
+	Configured bool         `json:"configured"`
+	Error      string       `json:"error,omitempty"`
+	Workspace  string       `json:"workspace,omitempty"`
+	User       string       `json:"user,omitempty"`
+	Scopes     []string     `json:"scopes"`
+}
+
+type commandCheck struct {
+	Command string       `json:"command"`
+	Token   scopes.Token `json:"token"`
+	Status  string       `json:"status"`
+	Missing []string     `json:"missing,omitempty"`
+}
+
+type doctorReport struct {
+	Tokens        []tokenReport             `json:"tokens"`
+	Commands      []commandCheck            `json:"commands"`
+	MissingScopes map[scopes.Token][]string `json:"missing_scopes"`
+}
+
+func newDoctorCmd() *cobra.Command {
+	opts := &doctorOptions{}
+
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check token scopes against what each command needs",
+		Long: `Check the scopes granted to the bot and user tokens against the scopes each
+command requires.
+
+Lists the commands that will fail with missing_scope and the scopes to add to
+the app manifest (slack-app-manifest.yaml) before reinstalling the app.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(opts, scopes.Collect(cmd.Root()), nil, nil)
+		},
+	}
+}
+
+func runDoctor(opts *doctorOptions, reqs []scopes.Requirement, botClient *client.Client, userClient *client.Client) error {
+	if botClient == nil {
+		botClient, _ = client.New()
+	}
+	if userClient == nil {
+		userClient, _ = client.NewUserClient()
+	}
+
+	bot := checkToken(scopes.Bot, botClient, keychain.GetAPITokenScopes())
+	user := checkToken(scopes.User, userClient, keychain.GetUserTokenScopes())
+	report := buildDoctorReport(reqs, bot, user)
+
+	if output.IsJSON() {
+		return output.PrintJSON(report)
+	}
+
+	for _, t := range report.Tokens {
+		printTokenReport(t)
+	}
+	output.Println()
+
+	headers := []string{"COMMAND", "TOKEN", "STATUS", "MISSING"}
+	rows := make([][]string, 0, len(report.Commands))
+	failing := 0
+	for _, c := range report.Commands {
+		if c.Status != statusOK {
+			failing++
+		}
+		rows = append(rows, []string{c.Command, string(c.Token), c.Status, strings.Join(c.Missing, ", ")})
+	}
+	output.Table(headers, rows)
+	output.Println()
+
+	if failing == 0 {
+		output.Println("All commands have the scopes they need.")
+		return nil
+	}
+
+	output.Printf("%d command(s) will fail.\n", failing)
+	if len(report.MissingScopes) > 0 {
+		output.Println()
+		output.Println("Add these scopes to your app manifest (slack-app-manifest.yaml), then reinstall the app:")
+		output.Println()
+		output.Println("oauth_config:")
+		output.Println("  scopes:")
+		for _, token := range []scopes.Token{scopes.Bot, scopes.User} {
+			if missing := report.MissingScopes[token]; len(missing) > 0 {
+				output.Printf("    %s:\n", token)
+				for _, s := range missing {
+					output.Printf("      - %s\n", s)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkToken authenticates c and collects the scopes it has been granted.
+// stored is the scope list recorded by 'config login', used when Slack
+// doesn't return an X-OAuth-Scopes header.
+func checkToken(token scopes.Token, c *client.Client, stored string) tokenReport {
+	report := tokenReport{Token: token}
+	if c == nil {
+		return report
+	}
+	report.Configured = true
+
+	info, err := c.AuthTest()
+	if err != nil {
+		report.Error = err.Error()
+		return report
+	}
+	report.Workspace = info.Team
+	report.User = info.User
+	report.Scopes = info.Scopes
+	if len(report.Scopes) == 0 && stored != "" {
+		report.Scopes = strings.Split(stored, ",")
+	}
+
+	return report
+}
+
+func buildDoctorReport(reqs []scopes.Requirement, tokens ...tokenReport) *doctorReport {
+	report := &doctorReport{
+		Tokens:        tokens,
+		MissingScopes: make(map[scopes.Token][]string),
+	}
+
+	byToken := make(map[scopes.Token]tokenReport)
+	for _, t := range tokens {
+		byToken[t.Token] = t
+	}
+
+	for _, req := range reqs {
+		check := commandCheck{Command: req.Command, Token: req.Token}
+		t := byToken[req.Token]
+		switch {
+		case !t.Configured || t.Error != "":
+			check.Status = statusNoToken
+		case len(t.Scopes) == 0:
+			check.Status = statusUnknown
+		default:
+			check.Missing = scopes.Missing(t.Scopes, req.Scopes)
+			check.Status = statusOK
+			if len(check.Missing) > 0 {
+				check.Status = statusMissing
+				report.MissingScopes[req.Token] = appendUnique(report.MissingScopes[req.Token], check.Missing...)
+			}
+		}
+		report.Commands = append(report.Commands, check)
+	}
+
+	return report
+}
+
+func printTokenReport(t tokenReport) {
+	name := "Bot Token"
+	if t.Token == scopes.User {
+		name = "User Token"
+	}
+
+	switch {
+	case !t.Configured:
+		output.Printf("%s: Not configured\n", name)
+	case t.Error != "":
+		output.Printf("%s: Authentication failed: %s\n", name, t.Error)
+	default:
+		output.Printf("%s: %s in %s\n", name, t.User, t.Workspace)
+		if len(t.Scopes) == 0 {
+			output.Println("  Granted scopes: unknown (Slack did not report them)")
+		} else {
+			output.Printf("  Granted scopes: %s\n", strings.Join(t.Scopes, ", "))
+		}
+	}
+}
+
+func appendUnique(list []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, existing := range list {
+			if existing == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, item)
+		}
+	}
+	return list
+}
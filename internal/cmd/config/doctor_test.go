@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+func newAuthTestServer(t *testing.T, grantedScopes string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/auth.test", r.URL.Path)
+		w.Header().Set("X-OAuth-Scopes", grantedScopes)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":   true,
+			"team": "Test Workspace",
+			"user": "test-bot",
+		})
+	}))
+}
+
+func TestCheckToken_ReadsScopeHeader(t *testing.T) {
+	server := newAuthTestServer(t, "chat:write, users:read")
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "xoxb-test", nil)
+	report := checkToken(scopes.Bot, c, "")
+
+	assert.True(t, report.Configured)
+	assert.Empty(t, report.Error)
+	assert.Equal(t, []string{"chat:write", "users:read"}, report.Scopes)
+}
+
+func TestCheckToken_FallsBackToStoredScopes(t *testing.T) {
+	server := newAuthTestServer(t, "")
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "xoxb-test", nil)
+	report := checkToken(scopes.Bot, c, "chat:write,team:read")
+
+	assert.Equal(t, []string{"chat:write", "team:read"}, report.Scopes)
+}
+
+// testRequirements stands in for the requirements collected from the command tree
+var testRequirements = []scopes.Requirement{
+	{Command: "messages send", Token: scopes.Bot, Scopes: []string{"chat:write"}},
+	{Command: "messages history", Token: scopes.Bot, Scopes: []string{"channels:history", "groups:history"}},
+	{Command: "messages react", Token: scopes.Bot, Scopes: []string{"reactions:write"}},
+	{Command: "workspace info", Token: scopes.Bot, Scopes: []string{"team:read"}},
+	{Command: "search messages", Token: scopes.User, Scopes: []string{"search:read"}},
+}
+
+func TestBuildDoctorReport(t *testing.T) {
+	bot := tokenReport{Token: scopes.Bot, Configured: true, Scopes: []string{"chat:write", "team:read"}}
+	user := tokenReport{Token: scopes.User}
+
+	report := buildDoctorReport(testRequirements, bot, user)
+
+	statuses := make(map[string]commandCheck)
+	for _, c := range report.Commands {
+		statuses[c.Command] = c
+	}
+
+	assert.Equal(t, statusOK, statuses["messages send"].Status)
+	assert.Equal(t, statusOK, statuses["workspace info"].Status)
+	assert.Equal(t, statusMissing, statuses["messages react"].Status)
+	assert.Equal(t, []string{"reactions:write"}, statuses["messages react"].Missing)
+	assert.Equal(t, statusNoToken, statuses["search messages"].Status)
+
+	assert.Contains(t, report.MissingScopes[scopes.Bot], "reactions:write")
+	assert.Contains(t, report.MissingScopes[scopes.Bot], "channels:history")
+	assert.NotContains(t, report.MissingScopes[scopes.Bot], "chat:write")
+	assert.Empty(t, report.MissingScopes[scopes.User])
+}
+
+func TestBuildDoctorReport_UnknownScopes(t *testing.T) {
+	bot := tokenReport{Token: scopes.Bot, Configured: true}
+	report := buildDoctorReport(testRequirements, bot, tokenReport{Token: scopes.User})
+
+	for _, c := range report.Commands {
+		if c.Token == scopes.Bot {
+			assert.Equal(t, statusUnknown, c.Status, c.Command)
+		}
+	}
+}
+
+func TestRunDoctor(t *testing.T) {
+	bot := newAuthTestServer(t, "chat:write")
+	defer bot.Close()
+	user := newAuthTestServer(t, "search:read")
+	defer user.Close()
+
+	err := runDoctor(&doctorOptions{}, testRequirements,
+		client.NewWithConfig(bot.URL, "xoxb-test", nil),
+		client.NewWithConfig(user.URL, "xoxp-test", nil))
+	require.NoError(t, err)
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/keychain"
+	"github.com/piekstra/slack-chat-api/internal/oauth"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type loginOptions struct {
+	clientID     string
+	clientSecret string
+	botScopes    []string
+	userScopes   []string
+	port         int
+	noBrowser    bool
+
+	// For testing
+	authorizeURL string
+	apiBaseURL   string
+	openBrowser  func(string) error
+}
+
+func newLoginCmd() *cobra.Command {
+	opts := &loginOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Install the Slack app via OAuth and store its tokens",
+		Long: `Install the Slack app into a workspace using the OAuth v2 flow and store
+the resulting bot and user tokens.
+
+A temporary listener is started on localhost to receive the redirect, and your
+browser is opened at Slack's authorization page. The redirect URL
+http://localhost:<port>/callback must be listed under OAuth & Permissions ->
+Redirect URLs in your app's settings.
+
+The client ID and secret are shown under Basic Information -> App Credentials.
+They can also be provided via SLACK_CLIENT_ID and SLACK_CLIENT_SECRET.
+
+Scopes default to everything the CLI's commands need (the same scopes as
+'slack-chat-api app manifest').
+
+Examples:
+  slack-chat-api config login --client-id 123.456 --client-secret abc123
+  slack-chat-api config login --user-scopes search:read --bot-scopes chat:write
+  slack-chat-api config login --no-browser`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqs := scopes.Collect(cmd.Root())
+			if !cmd.Flags().Changed("bot-scopes") {
+				opts.botScopes = scopes.Union(scopes.ForToken(reqs, scopes.Bot))
+			}
+			if !cmd.Flags().Changed("user-scopes") {
+				opts.userScopes = scopes.Union(scopes.ForToken(reqs, scopes.User))
+			}
+			return runLogin(cmd.Context(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.clientID, "client-id", "", "Slack app client ID (default $SLACK_CLIENT_ID)")
+	cmd.Flags().StringVar(&opts.clientSecret, "client-secret", "", "Slack app client secret (default $SLACK_CLIENT_SECRET)")
+	cmd.Flags().StringSliceVar(&opts.botScopes, "bot-scopes", nil, "Bot token scopes to request (default: all scopes commands need)")
+	cmd.Flags().StringSliceVar(&opts.userScopes, "user-scopes", nil, "User token scopes to request (default: all scopes commands need)")
+	cmd.Flags().IntVar(&opts.port, "port", oauth.DefaultPort, "Local port for the OAuth redirect")
+	cmd.Flags().BoolVar(&opts.noBrowser, "no-browser", false, "Print the authorization URL instead of opening a browser")
+
+	return cmd
+}
+
+func runLogin(ctx context.Context, opts *loginOptions) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	clientID := opts.clientID
+	if clientID == "" {
+		clientID = os.Getenv("SLACK_CLIENT_ID")
+	}
+	clientSecret := opts.clientSecret
+	if clientSecret == "" {
+		clientSecret = os.Getenv("SLACK_CLIENT_SECRET")
+	}
+	if clientID == "" || clientSecret == "" {
+		return fmt.Errorf("client ID and secret are required: use --client-id/--client-secret or set SLACK_CLIENT_ID/SLACK_CLIENT_SECRET")
+	}
+
+	openBrowser := opts.openBrowser
+	if openBrowser == nil {
+		openBrowser = func(authURL string) error {
+			output.Println("Open this URL in your browser to authorize slack-chat-api:")
+			output.Println()
+			output.Printf("  %s\n\n", authURL)
+			if opts.noBrowser {
+				return nil
+			}
+			if err := oauth.OpenBrowser(authURL); err != nil {
+				output.Printf("Could not open a browser (%v); open the URL manually.\n", err)
+			}
+			return nil
+		}
+	}
+
+	cfg := oauth.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		BotScopes:    opts.botScopes,
+		UserScopes:   opts.userScopes,
+		AuthorizeURL: opts.authorizeURL,
+		APIBaseURL:   opts.apiBaseURL,
+		Port:         opts.port,
+		OpenBrowser:  openBrowser,
+	}
+
+	output.Println("Waiting for authorization...")
+	resp, err := oauth.Login(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if resp.AccessToken != "" {
+		if err := keychain.SetAPIToken(resp.AccessToken); err != nil {
+			return fmt.Errorf("failed to store bot token: %w", err)
+		}
+		if err := keychain.SetAPITokenScopes(resp.Scope); err != nil {
+			return fmt.Errorf("failed to store bot token scopes: %w", err)
+		}
+		keychain.DeleteAPITokenRotation()
+		if resp.RefreshToken != "" {
+			rotation := keychain.TokenRotation{RefreshToken: resp.RefreshToken, ExpiresAt: expiresAt(resp.ExpiresIn)}
+			if err := keychain.SetAPITokenRotation(rotation); err != nil {
+				return fmt.Errorf("failed to store bot refresh token: %w", err)
+			}
+		}
+	}
+	if resp.AuthedUser.AccessToken != "" {
+		if err := keychain.SetUserToken(resp.AuthedUser.AccessToken); err != nil {
+			return fmt.Errorf("failed to store user token: %w", err)
+		}
+		if err := keychain.SetUserTokenScopes(resp.AuthedUser.Scope); err != nil {
+			return fmt.Errorf("failed to store user token scopes: %w", err)
+		}
+		keychain.DeleteUserTokenRotation()
+		if resp.AuthedUser.RefreshToken != "" {
+			rotation := keychain.TokenRotation{RefreshToken: resp.AuthedUser.RefreshToken, ExpiresAt: expiresAt(resp.AuthedUser.ExpiresIn)}
+			if err := keychain.SetUserTokenRotation(rotation); err != nil {
+				return fmt.Errorf("failed to store user refresh token: %w", err)
+			}
+		}
+	}
+
+	// Refreshing rotated tokens requires the client credentials
+	if resp.RefreshToken != "" || resp.AuthedUser.RefreshToken != "" {
+		if err := keychain.SetOAuthClient(clientID, clientSecret); err != nil {
+			return fmt.Errorf("failed to store client credentials: %w", err)
+		}
+	}
+
+	output.Printf("Installed to workspace %s (%s)\n", resp.Team.Name, resp.Team.ID)
+	storage := "config file"
+	if keychain.IsSecureStorage() {
+		storage = "Keychain"
+	}
+	if resp.AccessToken != "" {
+		output.Printf("Bot token stored in %s\n", storage)
+		output.Printf("  Scopes: %s\n", formatScopes(resp.Scope))
+		if resp.RefreshToken != "" {
+			output.Println("  Token rotation enabled; the token is refreshed automatically")
+		}
+	}
+	if resp.AuthedUser.AccessToken != "" {
+		output.Printf("User token stored in %s\n", storage)
+		output.Printf("  Scopes: %s\n", formatScopes(resp.AuthedUser.Scope))
+		if resp.AuthedUser.RefreshToken != "" {
+			output.Println("  Token rotation enabled; the token is refreshed automatically")
+		}
+	}
+
+	return nil
+}
+
+// expiresAt converts an expires_in value (seconds) into an absolute time
+func expiresAt(expiresIn int) time.Time {
+	return time.Now().Add(time.Duration(expiresIn) * time.Second)
+}
+
+// formatScopes renders a comma-separated scope list for display
+func formatScopes(scopes string) string {
+	if scopes == "" {
+		return "(none)"
+	}
+	return strings.ReplaceAll(scopes, ",", ", ")
+}
@@ -72,6 +72,10 @@ func runSetToken(token string, opts *setTokenOptions) error {
 		if err := keychain.SetAPIToken(token); err != nil {
 			return fmt.Errorf("failed to store bot token: %w", err)
 		}
+		// A manually set token doesn't rotate, and its scopes are unknown; drop
+		// the refresh data and scopes recorded by login
+		keychain.DeleteAPITokenRotation()
+		keychain.DeleteAPITokenScopes()
 		if keychain.IsSecureStorage() {
 			output.Println("Bot token stored securely in Keychain")
 		} else {
@@ -81,6 +85,8 @@ func runSetToken(token string, opts *setTokenOptions) error {
 		if err := keychain.SetUserToken(token); err != nil {
 			return fmt.Errorf("failed to store user token: %w", err)
 		}
+		keychain.DeleteUserTokenRotation()
+		keychain.DeleteUserTokenScopes()
 		if keychain.IsSecureStorage() {
 			output.Println("User token stored securely in Keychain")
 		} else {
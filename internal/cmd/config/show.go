@@ -1,6 +1,7 @@
 package config
 
 import (
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -39,6 +40,12 @@ func runShow(opts *showOptions) error {
 		hasAnyToken = true
 		source := keychain.GetTokenSource()
 		output.Printf("Bot Token: %s (from %s)\n", maskToken(botToken), source)
+		if scopes := keychain.GetAPITokenScopes(); scopes != "" {
+			output.Printf("  Scopes: %s\n", formatScopes(scopes))
+		}
+		if rotation := keychain.GetAPITokenRotation(); rotation != nil && source != "environment variable" {
+			output.Printf("  Rotating token, expires %s\n", rotation.ExpiresAt.Local().Format("2006-01-02 15:04"))
+		}
 	} else {
 		output.Println("Bot Token: Not configured")
 	}
@@ -49,8 +56,15 @@ func runShow(opts *showOptions) error {
 		hasAnyToken = true
 		source := keychain.GetUserTokenSource()
 		output.Printf("User Token: %s (from %s)\n", maskToken(userToken), source)
+		// SLACK_USER_TOKEN takes precedence, so stored scopes may not apply to it
+		if scopes := keychain.GetUserTokenScopes(); scopes != "" && os.Getenv("SLACK_USER_TOKEN") == "" {
+			output.Printf("  Scopes: %s\n", formatScopes(scopes))
+		}
+		if rotation := keychain.GetUserTokenRotation(); rotation != nil && os.Getenv("SLACK_USER_TOKEN") == "" {
+			output.Printf("  Rotating token, expires %s\n", rotation.ExpiresAt.Local().Format("2006-01-02 15:04"))
+		}
 	} else {
-		output.Println("User Token: Not configured (required for search)")
+		output.Println("User Token: Not configured (required for search and reminders)")
 	}
 
 	if !hasAnyToken {
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"strings"
+
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
@@ -17,7 +19,9 @@ func newTestCmd() *cobra.Command {
 		Short: "Test Slack authentication",
 		Long: `Verify that the configured API tokens authenticate successfully with Slack.
 
-Tests both bot token (for most commands) and user token (for search commands).`,
+Tests both bot token (for most commands) and user token (for search commands),
+and lists the scopes granted to each. Run 'slack-chat-api config doctor' to
+check those scopes against what each command needs.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runTest(opts, nil, nil)
 		},
@@ -51,6 +55,7 @@ func runTest(opts *testOptions, botClient *client.Client, userClient *client.Cli
 			if info.BotID != "" {
 				output.Printf("    Bot ID: %s\n", info.BotID)
 			}
+			printScopes(info.Scopes)
 		}
 	}
 
@@ -74,6 +79,7 @@ func runTest(opts *testOptions, botClient *client.Client, userClient *client.Cli
 			output.Println("  Authentication successful")
 			output.Printf("    Workspace: %s\n", info.Team)
 			output.Printf("    User: %s\n", info.User)
+			printScopes(info.Scopes)
 		}
 	}
 
@@ -84,3 +90,9 @@ func runTest(opts *testOptions, botClient *client.Client, userClient *client.Cli
 
 	return nil
 }
+
+func printScopes(granted []string) {
+	if len(granted) > 0 {
+		output.Printf("    Scopes: %s\n", strings.Join(granted, ", "))
+	}
+}
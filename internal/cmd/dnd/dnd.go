@@ -0,0 +1,29 @@
+package dnd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates the dnd command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dnd",
+		Short: "Snooze notifications with Do Not Disturb (requires user token)",
+		Long: `Snooze notifications, end a snooze early, or see Do Not Disturb status.
+
+These commands use the user token (xoxp-*) with the dnd:read and dnd:write
+scopes.`,
+	}
+
+	cmd.AddCommand(newSnoozeCmd())
+	cmd.AddCommand(newEndCmd())
+	cmd.AddCommand(newInfoCmd())
+
+	return cmd
+}
+
+func formatTime(unix int64) string {
+	return time.Unix(unix, 0).Format("Mon 2006-01-02 15:04 MST")
+}
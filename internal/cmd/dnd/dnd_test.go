@@ -0,0 +1,58 @@
+package dnd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func TestDNDWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.UserToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runSnooze("90m", &snoozeOptions{}, c))
+	assert.WithinDuration(t, time.Now().Add(90*time.Minute), srv.SnoozeEnd(slacktest.UserID), 5*time.Second)
+
+	buf.Reset()
+	require.NoError(t, runInfo("", &infoOptions{}, c))
+	assert.Contains(t, buf.String(), "until")
+
+	require.NoError(t, runEnd(&endOptions{}, c))
+	assert.True(t, srv.SnoozeEnd(slacktest.UserID).IsZero())
+
+	err := runEnd(&endOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "snooze_not_active")
+}
+
+func TestRunSnooze_Until(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.UserToken, nil)
+	orig := output.Writer
+	output.Writer = &bytes.Buffer{}
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runSnooze("", &snoozeOptions{until: "in 2h"}, c))
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), srv.SnoozeEnd(slacktest.UserID), time.Minute)
+}
+
+func TestRunSnooze_Validation(t *testing.T) {
+	assert.Error(t, runSnooze("", &snoozeOptions{}, nil))
+	assert.Error(t, runSnooze("1h", &snoozeOptions{until: "17:00"}, nil))
+	assert.Error(t, runSnooze("soon", &snoozeOptions{}, nil))
+	assert.Error(t, runSnooze("", &snoozeOptions{until: "1 hour ago"}, nil))
+}
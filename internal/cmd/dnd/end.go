@@ -0,0 +1,42 @@
+package dnd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type endOptions struct{}
+
+func newEndCmd() *cobra.Command {
+	opts := &endOptions{}
+
+	return &cobra.Command{
+		Use:         "end",
+		Short:       "End a snooze early",
+		Annotations: scopes.UserScopes("dnd:write"),
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnd(opts, nil)
+		},
+	}
+}
+
+func runEnd(opts *endOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.EndSnooze(); err != nil {
+		return client.WrapError("end snooze", err)
+	}
+
+	output.Println("Snooze ended")
+	return nil
+}
@@ -0,0 +1,73 @@
+package dnd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type infoOptions struct{}
+
+func newInfoCmd() *cobra.Command {
+	opts := &infoOptions{}
+
+	return &cobra.Command{
+		Use:         "info [user]",
+		Short:       "Show Do Not Disturb status (yours by default)",
+		Annotations: scopes.UserScopes("dnd:read", "users:read", "users:read.email"),
+		Long: `Show whether Do Not Disturb is on, the next scheduled DND window and any
+snooze. The user can be a user ID, @handle or email.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user := ""
+			if len(args) > 0 {
+				user = args[0]
+			}
+			return runInfo(user, opts, nil)
+		},
+	}
+}
+
+func runInfo(user string, opts *infoOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if user != "" {
+		ids, err := client.ResolveUsers(c, []string{user})
+		if err != nil {
+			return err
+		}
+		user = ids[0]
+	}
+
+	st, err := c.GetDNDInfo(user)
+	if err != nil {
+		return client.WrapError("get dnd info", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(st)
+	}
+
+	output.KeyValue("DND Enabled", st.DNDEnabled)
+	if st.NextDNDStartTS != 0 {
+		output.KeyValue("Next DND", formatTime(st.NextDNDStartTS)+" to "+formatTime(st.NextDNDEndTS))
+	}
+	if st.SnoozeEnabled {
+		snoozed := "yes"
+		if st.SnoozeEndTime != 0 {
+			snoozed = "until " + formatTime(st.SnoozeEndTime)
+		}
+		output.KeyValue("Snoozed", snoozed)
+	} else {
+		output.KeyValue("Snoozed", "no")
+	}
+	return nil
+}
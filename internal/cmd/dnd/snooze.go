@@ -0,0 +1,103 @@
+package dnd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/timeparse"
+)
+
+type snoozeOptions struct {
+	until string
+	tz    string
+	now   func() time.Time // For testing
+}
+
+func newSnoozeCmd() *cobra.Command {
+	opts := &snoozeOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "snooze [duration]",
+		Short:       "Pause notifications",
+		Annotations: scopes.UserScopes("dnd:write"),
+		Long: `Pause notifications for a duration such as 30m, 2h or 1d, or until the
+time --until names ("17:00", "tomorrow 9am"). Snoozing again replaces the
+current snooze.
+
+Examples:
+  slack-chat-api dnd snooze 2h
+  slack-chat-api dnd snooze --until 17:00`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			duration := ""
+			if len(args) > 0 {
+				duration = args[0]
+			}
+			return runSnooze(duration, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.until, "until", "", "Snooze until this time instead of for a duration")
+	cmd.Flags().StringVar(&opts.tz, "tz", "", "Time zone for --until (IANA name, e.g., America/New_York; default local)")
+
+	return cmd
+}
+
+func runSnooze(duration string, opts *snoozeOptions, c *client.Client) error {
+	if (duration == "") == (opts.until == "") {
+		return fmt.Errorf("give either a duration or --until")
+	}
+
+	var d time.Duration
+	if duration != "" {
+		var err error
+		if d, err = timeparse.ParseDuration(duration); err != nil {
+			return err
+		}
+	} else {
+		loc, err := timeparse.LoadLocation(opts.tz)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if opts.now != nil {
+			now = opts.now()
+		}
+		until, err := timeparse.Parse(opts.until, now, loc)
+		if err != nil {
+			return err
+		}
+		d = until.Sub(now)
+	}
+
+	// Slack snoozes in whole minutes
+	minutes := int((d + time.Minute - 1) / time.Minute)
+	if minutes < 1 {
+		return fmt.Errorf("snooze must end in the future")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	st, err := c.SetSnooze(minutes)
+	if err != nil {
+		return client.WrapError("snooze notifications", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(st)
+	}
+
+	output.Printf("Notifications snoozed until %s\n", formatTime(st.SnoozeEndTime))
+	return nil
+}
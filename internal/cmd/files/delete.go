@@ -0,0 +1,80 @@
+package files
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type deleteOptions struct {
+	force bool
+	stdin io.Reader // For testing
+}
+
+func newDeleteCmd() *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "delete <file-id>",
+		Short:       "Delete a file",
+		Annotations: scopes.BotScopes("files:write"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(fileID string, opts *deleteOptions, c *client.Client) error {
+	if err := validate.FileID(fileID); err != nil {
+		return err
+	}
+
+	// Prompt for confirmation unless --force
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		output.Printf("About to delete file %s\n", fileID)
+		output.Printf("Are you sure? [y/N]: ")
+
+		scanner := bufio.NewScanner(reader)
+		if scanner.Scan() {
+			confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+			if confirm != "y" && confirm != "yes" {
+				output.Println("Cancelled.")
+				return nil
+			}
+		}
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.DeleteFile(fileID); err != nil {
+		return client.WrapError(fmt.Sprintf("delete file %s", fileID), err)
+	}
+
+	output.Println("File deleted")
+	return nil
+}
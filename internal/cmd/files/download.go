@@ -0,0 +1,180 @@
+package files
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/download"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type downloadOptions struct {
+	dir         string
+	dest        string
+	force       bool
+	concurrency int
+	progress    io.Writer
+}
+
+func newDownloadCmd() *cobra.Command {
+	opts := &downloadOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "download <file-id|permalink>...",
+		Short:       "Download files",
+		Annotations: scopes.BotScopes("files:read"),
+		Long: `Download files by ID or permalink.
+
+Files are saved under their Slack names in --dir (the current directory by
+default), several at a time. Files already downloaded are skipped, partial
+downloads are resumed, and a file whose name is taken by a different local
+file is saved with its ID added: "report (F1234567890).pdf". A manifest
+(.slack-downloads.json) in the directory records what was downloaded.
+
+Use --dest to save a single file to a specific path, or "--dest -" to
+write it to stdout.
+
+Examples:
+  slack-chat-api files download F1234567890
+  slack-chat-api files download F1234567890 F0987654321 --dir ./downloads
+  slack-chat-api files download https://acme.slack.com/files/U0123/F1234567890/report.pdf
+  slack-chat-api files download F1234567890 --dest - | head`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.progress = cmd.ErrOrStderr()
+			return runDownload(args, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.dir, "dir", ".", "Directory to save files in")
+	cmd.Flags().StringVar(&opts.dest, "dest", "", "Path to save a single file to (\"-\" for stdout)")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Download files again even if already present")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "j", download.DefaultConcurrency, "Files to download at once")
+
+	return cmd
+}
+
+func runDownload(refs []string, opts *downloadOptions, c *client.Client) error {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		id, err := parseFileRef(ref)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if opts.dest != "" && len(ids) > 1 {
+		return fmt.Errorf("--dest can only be used when downloading a single file")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	items := make([]download.Item, 0, len(ids))
+	for _, id := range ids {
+		file, err := c.GetFileInfo(id)
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("get file %s", id), err)
+		}
+		items = append(items, download.FileItem(file))
+	}
+
+	if opts.dest != "" {
+		return downloadOne(c, items[0], opts)
+	}
+
+	results, err := download.Run(c, items, download.Options{
+		Dir:         opts.dir,
+		Concurrency: opts.concurrency,
+		Force:       opts.force,
+		Progress:    opts.progress,
+	})
+	if err != nil {
+		return err
+	}
+	return download.PrintResults(results)
+}
+
+// downloadOne saves a single file to --dest
+func downloadOne(c *client.Client, item download.Item, opts *downloadOptions) error {
+	if item.URL == "" {
+		return fmt.Errorf("file %s has no downloadable content", item.ID)
+	}
+	if opts.dest == "-" {
+		_, err := c.DownloadFile(item.URL, output.Writer)
+		return err
+	}
+	if !opts.force {
+		if _, err := os.Stat(opts.dest); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", opts.dest)
+		}
+	}
+
+	n, err := downloadTo(c, item.URL, opts.dest)
+	if err != nil {
+		return err
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(download.Result{ID: item.ID, Name: item.Name, Path: opts.dest, Size: n, Status: download.Downloaded})
+	}
+	output.Printf("Downloaded %s to %s (%s)\n", item.Name, opts.dest, output.FormatSize(n))
+	return nil
+}
+
+// downloadTo writes a file's content to path, removing partial downloads
+// on failure
+func downloadTo(c *client.Client, fileURL, path string) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	n, err := c.DownloadFile(fileURL, tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// parseFileRef accepts a file ID or a Slack file URL (permalink,
+// url_private or url_private_download) and returns the file ID
+func parseFileRef(ref string) (string, error) {
+	if validate.FileID(ref) == nil {
+		return ref, nil
+	}
+	u, err := url.Parse(ref)
+	if err == nil && u.Host != "" {
+		// /files/<user>/<file id>/<name> or /files-pri/<team>-<file id>/...
+		for _, seg := range strings.Split(u.Path, "/") {
+			if i := strings.LastIndex(seg, "-"); i >= 0 && strings.HasPrefix(seg, "T") {
+				seg = seg[i+1:]
+			}
+			if validate.FileID(seg) == nil {
+				return seg, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("invalid file %q: expected a file ID (e.g., F01234ABCDE) or a Slack file permalink", ref)
+}
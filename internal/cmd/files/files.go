@@ -0,0 +1,32 @@
+package files
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates the files command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "files",
+		Aliases: []string{"file", "f"},
+		Short:   "Upload, download and manage files",
+	}
+
+	cmd.AddCommand(newUploadCmd())
+	cmd.AddCommand(newDownloadCmd())
+	cmd.AddCommand(newInfoCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newDeleteCmd())
+
+	return cmd
+}
+
+// formatCreated converts a Unix time to a human-readable format
+func formatCreated(created int64) string {
+	if created == 0 {
+		return ""
+	}
+	return time.Unix(created, 0).Format("2006-01-02 15:04")
+}
@@ -0,0 +1,225 @@
+package files
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	t.Cleanup(func() { output.Writer = orig })
+	return buf
+}
+
+func newServer(t *testing.T) (*slacktest.Server, string, *client.Client) {
+	t.Helper()
+	srv := slacktest.NewServer()
+	t.Cleanup(srv.Close)
+	channel := srv.AddChannel(slacktest.Channel{Name: "general", Members: []string{slacktest.BotUserID}})
+	return srv, channel, client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+}
+
+func TestRunUpload_FilesAndStdin(t *testing.T) {
+	srv, channel, c := newServer(t)
+	captureOutput(t)
+
+	path := filepath.Join(t.TempDir(), "report.csv")
+	require.NoError(t, os.WriteFile(path, []byte("a,b\n"), 0600))
+
+	opts := &uploadOptions{
+		channel:  channel,
+		comment:  "Nightly numbers",
+		filename: "build.log",
+		stdin:    strings.NewReader("build ok\n"),
+	}
+	require.NoError(t, runUpload([]string{path, "-"}, opts, c))
+
+	files := srv.Files()
+	require.Len(t, files, 2)
+	assert.Equal(t, "report.csv", files[0].Name)
+	assert.Equal(t, "build.log", files[1].Name)
+	assert.Equal(t, "build ok\n", string(files[1].Content))
+
+	msgs := srv.Messages(channel)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "Nightly numbers", msgs[0].Text)
+	assert.Len(t, msgs[0].Files, 2)
+}
+
+func TestRunUpload_InThread(t *testing.T) {
+	srv, channel, c := newServer(t)
+	captureOutput(t)
+	parent, err := c.SendMessage(channel, "deploy", "", nil)
+	require.NoError(t, err)
+
+	opts := &uploadOptions{channel: channel, threadTS: parent.TS, filename: "out.txt", stdin: strings.NewReader("x")}
+	require.NoError(t, runUpload([]string{"-"}, opts, c))
+
+	msgs := srv.Messages(channel)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, parent.TS, msgs[1].ThreadTS)
+}
+
+func TestRunUpload_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		opts    *uploadOptions
+		wantErr string
+	}{
+		{"stdin without filename", []string{"-"}, &uploadOptions{}, "--filename is required"},
+		{"thread without channel", []string{"a.txt"}, &uploadOptions{threadTS: "1234567890.123456"}, "--thread requires --channel"},
+		{"comment without channel", []string{"a.txt"}, &uploadOptions{comment: "hi"}, "--comment requires --channel"},
+		{"title with several files", []string{"a.txt", "b.txt"}, &uploadOptions{title: "A"}, "single file"},
+		{"invalid channel", []string{"a.txt"}, &uploadOptions{channel: "general"}, "invalid channel ID"},
+		{"missing file", []string{filepath.Join(os.TempDir(), "does-not-exist.txt")}, &uploadOptions{}, "does-not-exist.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runUpload(tt.paths, tt.opts, client.NewWithConfig("http://127.0.0.1:0", "test-token", nil))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestRunDownload(t *testing.T) {
+	srv, _, c := newServer(t)
+	buf := captureOutput(t)
+	srv.AddFile(slacktest.File{ID: "F0000LOG", Name: "deploy.log", User: slacktest.UserID, Content: []byte("all good\n")})
+	srv.AddFile(slacktest.File{ID: "F0000CSV", Name: "report.csv", User: slacktest.UserID, Content: []byte("a,b\n")})
+
+	dir := t.TempDir()
+	refs := []string{"F0000LOG", srv.URL + "/files/" + slacktest.UserID + "/F0000CSV/report.csv"}
+	require.NoError(t, runDownload(refs, &downloadOptions{dir: dir, concurrency: 2}, c))
+	data, err := os.ReadFile(filepath.Join(dir, "deploy.log"))
+	require.NoError(t, err)
+	assert.Equal(t, "all good\n", string(data))
+	assert.FileExists(t, filepath.Join(dir, "report.csv"))
+	assert.Contains(t, buf.String(), "2 downloaded")
+
+	buf.Reset()
+	require.NoError(t, runDownload(refs, &downloadOptions{dir: dir}, c))
+	assert.Contains(t, buf.String(), "2 skipped")
+
+	buf.Reset()
+	require.NoError(t, runDownload([]string{"F0000LOG"}, &downloadOptions{dest: "-"}, c))
+	assert.Equal(t, "all good\n", buf.String())
+
+	out := filepath.Join(dir, "copy.log")
+	require.NoError(t, runDownload([]string{"F0000LOG"}, &downloadOptions{dest: out}, c))
+	err = runDownload([]string{"F0000LOG"}, &downloadOptions{dest: out}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already exists")
+
+	err = runDownload(refs, &downloadOptions{dest: out}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "single file")
+}
+
+func TestDownloadCmd_KeepsGlobalOutputFlag(t *testing.T) {
+	cmd := newDownloadCmd()
+	assert.Nil(t, cmd.Flags().Lookup("output"), "--output is the global format flag")
+	assert.Nil(t, cmd.Flags().ShorthandLookup("o"))
+}
+
+func TestParseFileRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr bool
+	}{
+		{"F0123ABCD", "F0123ABCD", false},
+		{"https://acme.slack.com/files/U0123/F0123ABCD/report.pdf", "F0123ABCD", false},
+		{"https://files.slack.com/files-pri/T0123-F0123ABCD/download/report.pdf", "F0123ABCD", false},
+		{"https://acme.slack.com/archives/C0123/p1234567890123456", "", true},
+		{"report.pdf", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseFileRef(tt.ref)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunDownload_SignInPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/files.info" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"file": map[string]interface{}{"id": "F123", "name": "a.txt", "url_private_download": "http://" + r.Host + "/files-pri/a.txt"},
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html>sign in</html>"))
+	}))
+	defer server.Close()
+
+	captureOutput(t)
+	out := filepath.Join(t.TempDir(), "a.txt")
+	err := runDownload([]string{"F123"}, &downloadOptions{dest: out}, client.NewWithConfig(server.URL, "test-token", nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "files:read")
+
+	entries, err := os.ReadDir(filepath.Dir(out))
+	require.NoError(t, err)
+	assert.Empty(t, entries, "partial downloads are removed")
+}
+
+func TestRunInfoAndList(t *testing.T) {
+	srv, channel, c := newServer(t)
+	buf := captureOutput(t)
+	srv.AddFile(slacktest.File{ID: "F0000CSV", Name: "report.csv", User: slacktest.UserID, Channels: []string{channel}, Content: []byte("a,b\n")})
+	srv.AddFile(slacktest.File{ID: "F0000PNG", Name: "chart.png", User: slacktest.BotUserID, Content: []byte("png")})
+
+	require.NoError(t, runInfo("F0000CSV", &infoOptions{}, c))
+	assert.Contains(t, buf.String(), "report.csv")
+	assert.Contains(t, buf.String(), channel)
+
+	buf.Reset()
+	require.NoError(t, runList(&listOptions{channel: channel, limit: 100}, c))
+	assert.Contains(t, buf.String(), "F0000CSV")
+	assert.NotContains(t, buf.String(), "F0000PNG")
+
+	err := runInfo("F0000NOPE", &infoOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "file_not_found")
+}
+
+func TestRunDelete(t *testing.T) {
+	srv, _, c := newServer(t)
+	buf := captureOutput(t)
+	srv.AddFile(slacktest.File{ID: "F0000LOG", Name: "deploy.log", User: slacktest.BotUserID})
+
+	require.NoError(t, runDelete("F0000LOG", &deleteOptions{stdin: strings.NewReader("n\n")}, c))
+	assert.Contains(t, buf.String(), "Cancelled.")
+	assert.Len(t, srv.Files(), 1)
+
+	require.NoError(t, runDelete("F0000LOG", &deleteOptions{stdin: strings.NewReader("y\n")}, c))
+	assert.Empty(t, srv.Files())
+}
@@ -0,0 +1,79 @@
+package files
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type infoOptions struct{}
+
+func newInfoCmd() *cobra.Command {
+	opts := &infoOptions{}
+
+	return &cobra.Command{
+		Use:         "info <file-id>",
+		Short:       "Get file information",
+		Annotations: scopes.BotScopes("files:read"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInfo(args[0], opts, nil)
+		},
+	}
+}
+
+func runInfo(fileID string, opts *infoOptions, c *client.Client) error {
+	if err := validate.FileID(fileID); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	file, err := c.GetFileInfo(fileID)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("get file %s", fileID), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(file)
+	}
+
+	output.KeyValue("ID", file.ID)
+	output.KeyValue("Name", file.Name)
+	if file.Title != "" && file.Title != file.Name {
+		output.KeyValue("Title", file.Title)
+	}
+	output.KeyValue("Type", file.Filetype)
+	output.KeyValue("Size", output.FormatSize(file.Size))
+	output.KeyValue("User", file.User)
+	output.KeyValue("Created", formatCreated(file.Created))
+	if shared := sharedIn(file); len(shared) > 0 {
+		output.KeyValue("Shared in", strings.Join(shared, ", "))
+	}
+	if file.Permalink != "" {
+		output.KeyValue("Permalink", file.Permalink)
+	}
+
+	return nil
+}
+
+// sharedIn lists every conversation a file is shared in
+func sharedIn(f *client.File) []string {
+	var ids []string
+	ids = append(ids, f.Channels...)
+	ids = append(ids, f.Groups...)
+	ids = append(ids, f.IMs...)
+	return ids
+}
@@ -0,0 +1,89 @@
+package files
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type listOptions struct {
+	channel string
+	user    string
+	types   string
+	limit   int
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Short:       "List files, newest first",
+		Annotations: scopes.BotScopes("files:read"),
+		Long: `List files visible to the token, newest first.
+
+Examples:
+  slack-chat-api files list --channel C1234567890
+  slack-chat-api files list --user U1234567890 --types images,pdfs`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.channel, "channel", "c", "", "Only files shared in this channel")
+	cmd.Flags().StringVar(&opts.user, "user", "", "Only files uploaded by this user")
+	cmd.Flags().StringVar(&opts.types, "types", "", "File types (all,spaces,snippets,images,gdocs,zips,pdfs)")
+	cmd.Flags().IntVar(&opts.limit, "limit", 100, "Maximum files to return")
+
+	return cmd
+}
+
+func runList(opts *listOptions, c *client.Client) error {
+	if opts.channel != "" {
+		if err := validate.ChannelID(opts.channel); err != nil {
+			return err
+		}
+	}
+	if opts.user != "" {
+		if err := validate.UserID(opts.user); err != nil {
+			return err
+		}
+	}
+	if err := validate.Limit(opts.limit); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := c.ListFiles(opts.channel, opts.user, opts.types, opts.limit)
+	if err != nil {
+		return client.WrapError("list files", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(files)
+	}
+
+	if len(files) == 0 {
+		output.Println("No files found")
+		return nil
+	}
+
+	headers := []string{"ID", "NAME", "TYPE", "SIZE", "USER", "CREATED"}
+	rows := make([][]string, 0, len(files))
+	for _, f := range files {
+		rows = append(rows, []string{f.ID, f.Name, f.Filetype, output.FormatSize(f.Size), f.User, formatCreated(f.Created)})
+	}
+	output.Table(headers, rows)
+	return nil
+}
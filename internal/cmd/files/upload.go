@@ -0,0 +1,112 @@
+package files
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type uploadOptions struct {
+	channel  string
+	title    string
+	comment  string
+	threadTS string
+	filename string
+	stdin    io.Reader // For testing
+}
+
+func newUploadCmd() *cobra.Command {
+	opts := &uploadOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "upload <file>...",
+		Short:       "Upload files",
+		Annotations: scopes.BotScopes("files:write"),
+		Long: `Upload one or more files, optionally sharing them in a channel.
+
+Files uploaded together are shared in a single message. Without --channel
+the files are uploaded privately and can be shared later.
+
+Use "-" to upload from stdin; --filename names the file in Slack.
+
+Examples:
+  slack-chat-api files upload report.pdf --channel C1234567890
+  slack-chat-api files upload a.png b.png -c C1234567890 --comment "Before and after"
+  slack-chat-api files upload build.log -c C1234567890 --thread 1234567890.123456
+  kubectl logs deploy/api | slack-chat-api files upload - --filename api.log -c C1234567890`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpload(args, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.channel, "channel", "c", "", "Channel to share the files in")
+	cmd.Flags().StringVar(&opts.title, "title", "", "File title (single file only; defaults to the file name)")
+	cmd.Flags().StringVar(&opts.comment, "comment", "", "Message to post with the files")
+	cmd.Flags().StringVar(&opts.threadTS, "thread", "", "Thread timestamp to share the files in")
+	cmd.Flags().StringVar(&opts.filename, "filename", "", "File name when uploading from stdin")
+
+	return cmd
+}
+
+func runUpload(paths []string, opts *uploadOptions, c *client.Client) error {
+	if opts.channel != "" {
+		if err := validate.ChannelID(opts.channel); err != nil {
+			return err
+		}
+	}
+	if opts.threadTS != "" {
+		if opts.channel == "" {
+			return fmt.Errorf("--thread requires --channel")
+		}
+		if err := validate.Timestamp(opts.threadTS); err != nil {
+			return err
+		}
+	}
+	if opts.comment != "" && opts.channel == "" {
+		return fmt.Errorf("--comment requires --channel")
+	}
+	if opts.title != "" && len(paths) > 1 {
+		return fmt.Errorf("--title can only be used when uploading a single file")
+	}
+
+	uploads, closeFiles, err := client.OpenUploads(paths, opts.stdin, opts.filename)
+	if err != nil {
+		return err
+	}
+	defer closeFiles()
+	if opts.title != "" {
+		uploads[0].Title = opts.title
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := c.UploadFiles(uploads, client.UploadOptions{
+		Channel:        opts.channel,
+		InitialComment: opts.comment,
+		ThreadTS:       opts.threadTS,
+	})
+	if err != nil {
+		return client.WrapError("upload files", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(files)
+	}
+
+	for _, f := range files {
+		output.Printf("Uploaded %s (%s)\n", f.Title, f.ID)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package messages
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// messageInput is the text and Block Kit sources given to a command that
+// posts a message (send, schedule)
+type messageInput struct {
+	text        string
+	blocksJSON  string
+	blocksFile  string
+	blocksStdin bool
+	simple      bool
+	stdin       io.Reader // For testing
+
+	// allowEmpty accepts a message with neither text nor blocks
+	allowEmpty bool
+}
+
+// resolve reads the text and blocks from their sources. Unless --simple is
+// set, text without blocks is wrapped in a default mrkdwn section block.
+func (in messageInput) resolve() (string, []interface{}, error) {
+	// Validate mutually exclusive blocks options
+	blocksOptionsCount := 0
+	if in.blocksJSON != "" {
+		blocksOptionsCount++
+	}
+	if in.blocksFile != "" {
+		blocksOptionsCount++
+	}
+	if in.blocksStdin {
+		blocksOptionsCount++
+	}
+	if blocksOptionsCount > 1 {
+		return "", nil, fmt.Errorf("only one of --blocks, --blocks-file, or --blocks-stdin can be specified")
+	}
+
+	text := in.text
+	// Read from stdin if text is "-"
+	if text == "-" {
+		if in.blocksStdin {
+			return "", nil, fmt.Errorf("cannot use '-' for text and --blocks-stdin together; stdin can only be used for one")
+		}
+		lines, err := readLines(in.stdin)
+		if err != nil {
+			return "", nil, fmt.Errorf("reading stdin: %w", err)
+		}
+		text = lines
+	}
+
+	// Unescape shell-escaped characters (e.g., \! from zsh)
+	text = unescapeShellChars(text)
+
+	// Determine blocks source
+	var blocksSource string
+	if in.blocksJSON != "" {
+		blocksSource = in.blocksJSON
+	} else if in.blocksFile != "" {
+		data, err := os.ReadFile(in.blocksFile)
+		if err != nil {
+			return "", nil, fmt.Errorf("reading blocks file: %w", err)
+		}
+		blocksSource = string(data)
+	} else if in.blocksStdin {
+		lines, err := readLines(in.stdin)
+		if err != nil {
+			return "", nil, fmt.Errorf("reading blocks from stdin: %w", err)
+		}
+		blocksSource = lines
+	}
+
+	// Validate: must have text or blocks (or both)
+	if text == "" && blocksSource == "" && !in.allowEmpty {
+		return "", nil, fmt.Errorf("message text cannot be empty (or provide blocks via --blocks, --blocks-file, or --blocks-stdin)")
+	}
+
+	var blocks []interface{}
+	if blocksSource != "" {
+		if err := json.Unmarshal([]byte(blocksSource), &blocks); err != nil {
+			return "", nil, fmt.Errorf("invalid blocks JSON: %w", err)
+		}
+	} else if !in.simple && text != "" {
+		// Default to block style for a more refined appearance
+		blocks = buildDefaultBlocks(text)
+	}
+
+	return text, blocks, nil
+}
+
+// readLines reads all of r (stdin if nil), joining lines with newlines
+func readLines(r io.Reader) (string, error) {
+	if r == nil {
+		r = os.Stdin
+	}
+	scanner := bufio.NewScanner(r)
+	var lines []byte
+	for scanner.Scan() {
+		if len(lines) > 0 {
+			lines = append(lines, '\n')
+		}
+		lines = append(lines, scanner.Bytes()...)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return string(lines), nil
+}
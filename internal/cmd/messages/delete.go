@@ -10,7 +10,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 	"github.com/piekstra/slack-chat-api/internal/validate"
 )
 
@@ -23,11 +25,17 @@ func newDeleteCmd() *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <channel> <timestamp>",
-		Short: "Delete a message",
-		Args:  cobra.ExactArgs(2),
+		Use:         "delete <channel> <timestamp>",
+		Short:       "Delete a message",
+		Annotations: scopes.BotScopes("chat:write"),
+		Long:        "Delete a message.\n\n" + msgref.Help,
+		Args:        msgref.Args(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(args[0], args[1], opts, nil)
+			ref, _, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			return runDelete(ref.Channel, ref.TS, opts, nil)
 		},
 	}
 
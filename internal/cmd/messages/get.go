@@ -0,0 +1,120 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type getOptions struct {
+	threadTS string
+}
+
+func newGetCmd() *cobra.Command {
+	opts := &getOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "get <channel> <timestamp>",
+		Short:       "Get a single message",
+		Annotations: scopes.BotScopes("channels:history", "groups:history"),
+		Long: `Get a single message with its blocks, files, reactions, edit and reply
+details. Use -o json for the full message.
+
+Thread replies are not part of channel history, so getting a reply needs its
+thread: pass --thread, or use the reply's permalink, which includes it.
+
+` + msgref.Help,
+		Args: msgref.Args(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, _, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			if opts.threadTS == "" {
+				opts.threadTS = ref.ThreadTS
+			}
+			return runGet(ref.Channel, ref.TS, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.threadTS, "thread", "", "Thread timestamp, when the message is a reply")
+
+	return cmd
+}
+
+func runGet(channel, timestamp string, opts *getOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+	if opts.threadTS != "" {
+		if err := validate.Timestamp(opts.threadTS); err != nil {
+			return err
+		}
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	msg, err := c.GetMessage(channel, timestamp, opts.threadTS)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("get message %s", timestamp), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(msg)
+	}
+
+	output.KeyValue("Channel", channel)
+	output.KeyValue("Timestamp", fmt.Sprintf("%s (%s)", msg.TS, formatTimestamp(msg.TS)))
+	if msg.IsBot() {
+		bot := msg.Sender()
+		if msg.BotID != "" && bot != msg.BotID {
+			bot += " (" + msg.BotID + ")"
+		}
+		output.KeyValue("Bot", bot)
+	} else {
+		output.KeyValue("User", msg.User)
+	}
+	if msg.Subtype != "" {
+		output.KeyValue("Subtype", msg.Subtype)
+	}
+	if msg.ThreadTS != "" && msg.ThreadTS != msg.TS {
+		output.KeyValue("Thread", msg.ThreadTS)
+	}
+	if msg.ReplyCount > 0 {
+		output.KeyValue("Replies", fmt.Sprintf("%d (latest %s)", msg.ReplyCount, formatTimestamp(msg.LatestReply)))
+	}
+	if msg.Edited != nil {
+		output.KeyValue("Edited", fmt.Sprintf("%s by %s", formatTimestamp(msg.Edited.TS), msg.Edited.User))
+	}
+	if len(msg.Reactions) > 0 {
+		output.KeyValue("Reactions", formatReactions(msg.Reactions))
+	}
+	for _, f := range msg.Files {
+		output.KeyValue("File", fmt.Sprintf("%s %s (%s)", f.ID, f.Name, output.FormatSize(f.Size)))
+	}
+	if len(msg.Blocks) > 0 {
+		output.KeyValue("Blocks", "yes (use -o json to see them)")
+	}
+	if len(msg.Attachments) > 0 {
+		output.KeyValue("Attachments", "yes (use -o json to see them)")
+	}
+	output.Println()
+	output.Println(msg.Text)
+
+	return nil
+}
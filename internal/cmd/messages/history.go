@@ -5,6 +5,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type historyOptions struct {
@@ -17,9 +18,10 @@ func newHistoryCmd() *cobra.Command {
 	opts := &historyOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "history <channel>",
-		Short: "Get channel message history",
-		Args:  cobra.ExactArgs(1),
+		Use:         "history <channel>",
+		Short:       "Get channel message history",
+		Annotations: scopes.BotScopes("channels:history", "groups:history"),
+		Args:        cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runHistory(args[0], opts, nil)
 		},
@@ -56,9 +58,7 @@ func runHistory(channel string, opts *historyOptions, c *client.Client) error {
 	}
 
 	for _, m := range messages {
-		ts := formatTimestamp(m.TS)
-		text := truncate(m.Text, 80)
-		output.Printf("[%s] %s: %s\n", ts, m.User, text)
+		output.Println(formatMessage(m))
 	}
 
 	return nil
@@ -1,11 +1,14 @@
 package messages
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
 )
 
 // NewCmd creates the messages command with all subcommands
@@ -17,8 +20,12 @@ func NewCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newSendCmd())
+	cmd.AddCommand(newScheduleCmd())
+	cmd.AddCommand(newScheduledCmd())
 	cmd.AddCommand(newUpdateCmd())
 	cmd.AddCommand(newDeleteCmd())
+	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(newPermalinkCmd())
 	cmd.AddCommand(newHistoryCmd())
 	cmd.AddCommand(newThreadCmd())
 	cmd.AddCommand(newReactCmd())
@@ -43,6 +50,53 @@ func formatTimestamp(ts string) string {
 	return t.Format("2006-01-02 15:04")
 }
 
+// formatMessage renders a message as a single line for history and thread
+// listings, marking bot senders, edits, replies, files and reactions
+func formatMessage(m client.Message) string {
+	sender := m.Sender()
+	if m.IsBot() {
+		sender += " [bot]"
+	}
+	text := truncate(m.Text, 80)
+
+	var line string
+	if m.Subtype == "me_message" {
+		line = fmt.Sprintf("[%s] * %s %s", formatTimestamp(m.TS), sender, text)
+	} else {
+		line = fmt.Sprintf("[%s] %s: %s", formatTimestamp(m.TS), sender, text)
+	}
+
+	if m.Edited != nil {
+		line += " (edited)"
+	}
+	if m.ReplyCount > 0 {
+		line += fmt.Sprintf(" (%d %s)", m.ReplyCount, plural(m.ReplyCount, "reply", "replies"))
+	}
+	if len(m.Files) > 0 {
+		line += fmt.Sprintf(" [%d %s]", len(m.Files), plural(len(m.Files), "file", "files"))
+	}
+	if reactions := formatReactions(m.Reactions); reactions != "" {
+		line += "  " + reactions
+	}
+	return line
+}
+
+// formatReactions renders reactions as ":tada: 2  :eyes: 1"
+func formatReactions(reactions []client.Reaction) string {
+	parts := make([]string, 0, len(reactions))
+	for _, r := range reactions {
+		parts = append(parts, fmt.Sprintf(":%s: %d", r.Name, r.Count))
+	}
+	return strings.Join(parts, "  ")
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
 // truncate shortens a string to maxLen, replacing newlines with spaces
 func truncate(s string, maxLen int) string {
 	// Replace newlines with spaces
@@ -1,17 +1,22 @@
 package messages
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
 )
 
 func TestFormatTimestamp(t *testing.T) {
@@ -1008,3 +1013,205 @@ func TestRunSend_EmptyTextNoBlocks(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "message text cannot be empty")
 }
+
+// Offline workflow against the fake Slack server
+
+func TestMessagesWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	channel := srv.AddChannel(slacktest.Channel{Name: "general", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	require.NoError(t, runSend(channel, "Deploy started", &sendOptions{simple: true}, c))
+	parent := srv.Messages(channel)[0]
+
+	require.NoError(t, runSend(channel, "Step 1 done", &sendOptions{simple: true, threadTS: parent.TS}, c))
+	require.NoError(t, runReact(channel, parent.TS, ":rocket:", &reactOptions{}, c))
+	require.NoError(t, runUpdate(channel, parent.TS, "Deploy finished", &updateOptions{simple: true}, c))
+	require.NoError(t, runHistory(channel, &historyOptions{limit: 20}, c))
+	require.NoError(t, runThread(channel, parent.TS, &threadOptions{limit: 100}, c))
+
+	msgs := srv.Messages(channel)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, "Deploy finished", msgs[0].Text)
+	assert.Equal(t, []string{slacktest.BotUserID}, msgs[0].Reactions["rocket"])
+	assert.Equal(t, parent.TS, msgs[1].ThreadTS)
+
+	require.NoError(t, runUnreact(channel, parent.TS, "rocket", &unreactOptions{}, c))
+	require.NoError(t, runDelete(channel, parent.TS, &deleteOptions{force: true}, c))
+	assert.Len(t, srv.Messages(channel), 1)
+}
+
+func TestRunSend_WithFiles(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	channel := srv.AddChannel(slacktest.Channel{Name: "general", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	path := filepath.Join(t.TempDir(), "results.csv")
+	require.NoError(t, os.WriteFile(path, []byte("pass,12\n"), 0600))
+
+	opts := &sendOptions{files: []string{path, "-"}, filename: "notes.txt", stdin: strings.NewReader("flaky: 1")}
+	require.NoError(t, runSend(channel, "Nightly results", opts, c))
+
+	msgs := srv.Messages(channel)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "Nightly results", msgs[0].Text)
+	assert.Len(t, msgs[0].Files, 2)
+	assert.Equal(t, "flaky: 1", string(srv.Files()[1].Content))
+}
+
+func TestRunSend_FilesWithBlocks(t *testing.T) {
+	opts := &sendOptions{files: []string{"a.txt"}, blocksJSON: `[]`}
+	err := runSend("C123", "hi", opts, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--file cannot be combined")
+
+	opts = &sendOptions{files: []string{"-"}, stdin: strings.NewReader("x")}
+	err = runSend("C123", "-", opts, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stdin can only be used for one")
+}
+
+func TestScheduledMessagesWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	channel := srv.AddChannel(slacktest.Channel{Name: "general", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	opts := &scheduleOptions{at: "tomorrow 09:00", tz: "UTC"}
+	require.NoError(t, runSchedule(channel, "Standup in 5 minutes", opts, c))
+
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1)
+	scheduled := srv.ScheduledMessages()
+	require.Len(t, scheduled, 1)
+	assert.Equal(t, time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, time.UTC).Unix(), scheduled[0].PostAt)
+	assert.Equal(t, "Standup in 5 minutes", scheduled[0].Text)
+	assert.Contains(t, string(scheduled[0].Blocks), "mrkdwn")
+
+	require.NoError(t, runScheduledList(&scheduledListOptions{channel: channel, limit: 100}, c))
+	require.NoError(t, runScheduledDelete(channel, scheduled[0].ID, &scheduledDeleteOptions{force: true}, c))
+	assert.Empty(t, srv.ScheduledMessages())
+}
+
+func TestRunSchedule_Validation(t *testing.T) {
+	now := func() time.Time { return time.Date(2030, 3, 14, 16, 0, 0, 0, time.UTC) }
+
+	err := runSchedule("C123456789", "hi", &scheduleOptions{at: "yesterday 9am", tz: "UTC", now: now}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "in the past")
+
+	err = runSchedule("C123456789", "hi", &scheduleOptions{at: "someday", now: now}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid time")
+
+	err = runSchedule("C123456789", "hi", &scheduleOptions{at: "9am", tz: "Mars/Olympus", now: now}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid time zone")
+}
+
+func TestRunSend_EphemeralAndMe(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	channel := srv.AddChannel(slacktest.Channel{Name: "general", Members: []string{slacktest.BotUserID, slacktest.UserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	require.NoError(t, runSend(channel, "Your deploy is queued", &sendOptions{ephemeral: true, user: "@tester"}, c))
+	eph := srv.EphemeralMessages()
+	require.Len(t, eph, 1)
+	assert.Equal(t, slacktest.UserID, eph[0].To)
+	assert.Contains(t, string(eph[0].Blocks), "mrkdwn", "ephemeral messages get default blocks like any other")
+
+	require.NoError(t, runSend(channel, "is deploying", &sendOptions{me: true}, c))
+	msgs := srv.Messages(channel)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "me_message", msgs[0].Subtype)
+	assert.Empty(t, msgs[0].Blocks)
+}
+
+func TestRunSend_EphemeralAndMeValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *sendOptions
+		want string
+	}{
+		{"ephemeral without user", &sendOptions{ephemeral: true}, "--ephemeral requires --user"},
+		{"user without ephemeral", &sendOptions{user: "U123"}, "--user can only be used with --ephemeral"},
+		{"both modes", &sendOptions{ephemeral: true, me: true, user: "U123"}, "cannot be used together"},
+		{"me with blocks", &sendOptions{me: true, blocksJSON: `[]`}, "text only"},
+		{"me in thread", &sendOptions{me: true, threadTS: "1234567890.123456"}, "--me cannot be combined"},
+		{"ephemeral with file", &sendOptions{ephemeral: true, user: "U123", files: []string{"a.txt"}}, "--file cannot be used with --ephemeral"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSend("C123456789", "hi", tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestGetAndPermalink_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	channel := srv.AddChannel(slacktest.Channel{Name: "general", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	parent := srv.AddMessage(channel, slacktest.Message{User: slacktest.BotUserID, Text: "Deploy started"})
+	reply := srv.AddMessage(channel, slacktest.Message{User: slacktest.BotUserID, Text: "Step 1 done", ThreadTS: parent})
+
+	require.NoError(t, runPermalink(channel, reply, &permalinkOptions{}, c))
+	require.NoError(t, runGet(channel, parent, &getOptions{}, c))
+	require.NoError(t, runGet(channel, reply, &getOptions{threadTS: parent}, c))
+
+	err := runGet(channel, reply, &getOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "message_not_found")
+}
+
+func TestFormatMessage(t *testing.T) {
+	plain := client.Message{User: "U001", Text: "Hello", TS: "1704067200.000100"}
+	assert.Equal(t, "["+formatTimestamp(plain.TS)+"] U001: Hello", formatMessage(plain))
+
+	bot := client.Message{
+		User:       "U002",
+		BotID:      "B001",
+		BotProfile: &client.BotProfile{Name: "deploybot"},
+		Text:       "Deployed",
+		TS:         "1704067200.000200",
+		Edited:     &client.Edited{User: "U002", TS: "1704067300.000000"},
+		ReplyCount: 2,
+		Reactions:  []client.Reaction{{Name: "tada", Count: 3}, {Name: "eyes", Count: 1}},
+	}
+	line := formatMessage(bot)
+	assert.Contains(t, line, "deploybot [bot]: Deployed (edited) (2 replies)")
+	assert.True(t, strings.HasSuffix(line, ":tada: 3  :eyes: 1"), line)
+
+	me := client.Message{User: "U001", Subtype: "me_message", Text: "is deploying", TS: "1704067200.000300"}
+	assert.Contains(t, formatMessage(me), "* U001 is deploying")
+}
+
+func TestRunHistory_JSONKeepsFullMessages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[{"type":"message","user":"U001","text":"hi","ts":"1.1",` +
+			`"blocks":[{"type":"divider"}],"attachments":[{"color":"good"}],"client_msg_id":"abc"}]}`))
+	}))
+	defer server.Close()
+	c := client.NewWithConfig(server.URL, "xoxb-test", nil)
+
+	var buf bytes.Buffer
+	output.Writer = &buf
+	output.OutputFormat = output.FormatJSON
+	defer func() {
+		output.Writer = os.Stdout
+		output.OutputFormat = output.FormatText
+	}()
+
+	require.NoError(t, runHistory("C123", &historyOptions{limit: 10}, c))
+	var got []map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	require.Len(t, got, 1)
+	assert.Contains(t, got[0], "blocks")
+	assert.Contains(t, got[0], "attachments")
+	assert.Equal(t, "abc", got[0]["client_msg_id"])
+}
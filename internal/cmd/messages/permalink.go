@@ -0,0 +1,67 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type permalinkOptions struct{}
+
+func newPermalinkCmd() *cobra.Command {
+	opts := &permalinkOptions{}
+
+	return &cobra.Command{
+		Use:         "permalink <channel> <timestamp>",
+		Short:       "Get a link to a message",
+		Annotations: scopes.BotScopes(),
+		Long: `Get a permanent link to a message, to share it or open it in Slack.
+
+Examples:
+  slack-chat-api messages permalink C1234567890 1234567890.123456
+  open "$(slack-chat-api messages permalink C1234567890 1234567890.123456)"`,
+		Args: msgref.Args(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, _, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			return runPermalink(ref.Channel, ref.TS, opts, nil)
+		},
+	}
+}
+
+func runPermalink(channel, timestamp string, opts *permalinkOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	link, err := c.GetPermalink(channel, timestamp)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("get permalink for %s", timestamp), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(map[string]string{"channel": channel, "ts": timestamp, "permalink": link})
+	}
+
+	output.Println(link)
+	return nil
+}
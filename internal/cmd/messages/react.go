@@ -6,7 +6,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 	"github.com/piekstra/slack-chat-api/internal/validate"
 )
 
@@ -16,11 +18,17 @@ func newReactCmd() *cobra.Command {
 	opts := &reactOptions{}
 
 	return &cobra.Command{
-		Use:   "react <channel> <timestamp> <emoji>",
-		Short: "Add a reaction to a message",
-		Args:  cobra.ExactArgs(3),
+		Use:         "react <channel> <timestamp> <emoji>",
+		Short:       "Add a reaction to a message",
+		Annotations: scopes.BotScopes("reactions:write"),
+		Long:        "Add a reaction to a message.\n\n" + msgref.Help,
+		Args:        msgref.Args(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runReact(args[0], args[1], args[2], opts, nil)
+			ref, rest, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			return runReact(ref.Channel, ref.TS, rest[0], opts, nil)
 		},
 	}
 }
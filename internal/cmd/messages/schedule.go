@@ -0,0 +1,133 @@
+package messages
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/timeparse"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type scheduleOptions struct {
+	at          string
+	tz          string
+	threadTS    string
+	blocksJSON  string
+	blocksFile  string
+	blocksStdin bool
+	simple      bool
+	now         func() time.Time // For testing
+	stdin       io.Reader        // For testing
+}
+
+func newScheduleCmd() *cobra.Command {
+	opts := &scheduleOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "schedule <channel> [text]",
+		Short:       "Schedule a message to be sent later",
+		Annotations: scopes.BotScopes("chat:write"),
+		Long: `Schedule a message to be posted by Slack at a later time.
+
+Slack posts the message itself, so nothing needs to stay running. Messages
+can be scheduled up to 120 days ahead. Text, blocks and threads work as in
+'messages send'.
+
+--at accepts:
+  09:00, 9am, 5:30pm, noon     the next time the clock shows that time
+  tomorrow 9am, friday 14:00   a day (today, tomorrow, a weekday, "next monday")
+  2026-03-14 09:30             a date and time
+  in 2h, +30m, in 3 days       an offset from now
+  2026-03-14T09:30:00Z         RFC 3339, or a Unix timestamp
+
+Times are in the local time zone unless --tz names another.
+
+Examples:
+  slack-chat-api messages schedule C1234567890 --at "tomorrow 09:00" "Standup in 5 minutes"
+  slack-chat-api messages schedule C1234567890 --at "friday 5pm" --tz Europe/London "Weekend!"
+  slack-chat-api messages schedule C1234567890 --at "in 2h" --blocks-file ./reminder.json`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			text := ""
+			if len(args) > 1 {
+				text = args[1]
+			}
+			return runSchedule(args[0], text, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.at, "at", "", "When to post the message (e.g., \"tomorrow 09:00\", \"in 2h\")")
+	cmd.Flags().StringVar(&opts.tz, "tz", "", "Time zone for --at (IANA name, e.g., America/New_York; default local)")
+	cmd.Flags().StringVar(&opts.threadTS, "thread", "", "Thread timestamp for reply")
+	cmd.Flags().StringVar(&opts.blocksJSON, "blocks", "", "Inline Block Kit JSON array (for simple blocks)")
+	cmd.Flags().StringVar(&opts.blocksFile, "blocks-file", "", "Read blocks from JSON file (recommended for complex payloads)")
+	cmd.Flags().BoolVar(&opts.blocksStdin, "blocks-stdin", false, "Read blocks from stdin (for piping from other tools)")
+	cmd.Flags().BoolVar(&opts.simple, "simple", false, "Send as plain text without block formatting")
+	_ = cmd.MarkFlagRequired("at")
+
+	return cmd
+}
+
+func runSchedule(channel, text string, opts *scheduleOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+	if opts.threadTS != "" {
+		if err := validate.Timestamp(opts.threadTS); err != nil {
+			return err
+		}
+	}
+
+	loc, err := timeparse.LoadLocation(opts.tz)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if opts.now != nil {
+		now = opts.now()
+	}
+	postAt, err := timeparse.Parse(opts.at, now, loc)
+	if err != nil {
+		return err
+	}
+	if !postAt.After(now) {
+		return fmt.Errorf("scheduled time %s is in the past", postAt.Format("2006-01-02 15:04 MST"))
+	}
+
+	text, blocks, err := messageInput{
+		text:        text,
+		blocksJSON:  opts.blocksJSON,
+		blocksFile:  opts.blocksFile,
+		blocksStdin: opts.blocksStdin,
+		simple:      opts.simple,
+		stdin:       opts.stdin,
+	}.resolve()
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	msg, err := c.ScheduleMessage(channel, text, postAt, opts.threadTS, blocks)
+	if err != nil {
+		return client.WrapError("schedule message", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(msg)
+	}
+
+	output.Printf("Message scheduled for %s (id: %s)\n", postAt.Format("Mon 2006-01-02 15:04 MST"), msg.ID)
+	return nil
+}
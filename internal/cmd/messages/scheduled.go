@@ -0,0 +1,164 @@
+package messages
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/timeparse"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+func newScheduledCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scheduled",
+		Short: "Manage scheduled messages",
+	}
+
+	cmd.AddCommand(newScheduledListCmd())
+	cmd.AddCommand(newScheduledDeleteCmd())
+
+	return cmd
+}
+
+type scheduledListOptions struct {
+	channel string
+	limit   int
+	tz      string
+}
+
+func newScheduledListCmd() *cobra.Command {
+	opts := &scheduledListOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Short:       "List messages waiting to be posted",
+		Annotations: scopes.BotScopes("chat:write"),
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScheduledList(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.channel, "channel", "", "Only messages scheduled in this channel")
+	cmd.Flags().IntVar(&opts.limit, "limit", 100, "Maximum messages to return")
+	cmd.Flags().StringVar(&opts.tz, "tz", "", "Time zone to show times in (IANA name; default local)")
+
+	return cmd
+}
+
+func runScheduledList(opts *scheduledListOptions, c *client.Client) error {
+	if opts.channel != "" {
+		if err := validate.ChannelID(opts.channel); err != nil {
+			return err
+		}
+	}
+	loc, err := timeparse.LoadLocation(opts.tz)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	messages, err := c.ListScheduledMessages(opts.channel, opts.limit)
+	if err != nil {
+		return client.WrapError("list scheduled messages", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(messages)
+	}
+
+	if len(messages) == 0 {
+		output.Println("No scheduled messages")
+		return nil
+	}
+
+	headers := []string{"ID", "CHANNEL", "POST AT", "TEXT"}
+	rows := make([][]string, 0, len(messages))
+	for _, m := range messages {
+		postAt := time.Unix(m.PostAt, 0).In(loc).Format("2006-01-02 15:04 MST")
+		rows = append(rows, []string{m.ID, m.ChannelID, postAt, truncate(m.Text, 60)})
+	}
+	output.Table(headers, rows)
+
+	return nil
+}
+
+type scheduledDeleteOptions struct {
+	force bool
+	stdin io.Reader // For testing
+}
+
+func newScheduledDeleteCmd() *cobra.Command {
+	opts := &scheduledDeleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "delete <channel> <scheduled-message-id>",
+		Aliases:     []string{"cancel"},
+		Short:       "Cancel a scheduled message",
+		Annotations: scopes.BotScopes("chat:write"),
+		Args:        cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runScheduledDelete(args[0], args[1], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runScheduledDelete(channel, id string, opts *scheduledDeleteOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+
+	// Prompt for confirmation unless --force
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		output.Printf("About to cancel scheduled message %s in channel %s\n", id, channel)
+		output.Printf("Are you sure? [y/N]: ")
+
+		scanner := bufio.NewScanner(reader)
+		if scanner.Scan() {
+			confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+			if confirm != "y" && confirm != "yes" {
+				output.Println("Cancelled.")
+				return nil
+			}
+		}
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.DeleteScheduledMessage(channel, id); err != nil {
+		return client.WrapError(fmt.Sprintf("delete scheduled message %s", id), err)
+	}
+
+	output.Println("Scheduled message deleted")
+	return nil
+}
@@ -1,16 +1,14 @@
 package messages
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 	"github.com/piekstra/slack-chat-api/internal/validate"
 )
 
@@ -20,6 +18,11 @@ type sendOptions struct {
 	blocksFile  string
 	blocksStdin bool
 	simple      bool
+	files       []string
+	filename    string
+	ephemeral   bool
+	user        string
+	me          bool
 	stdin       io.Reader // For testing
 }
 
@@ -27,8 +30,9 @@ func newSendCmd() *cobra.Command {
 	opts := &sendOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "send <channel> [text]",
-		Short: "Send a message to a channel",
+		Use:         "send <channel> [text]",
+		Short:       "Send a message to a channel",
+		Annotations: scopes.BotScopes("chat:write", "files:write", "users:read", "users:read.email"),
 		Long: `Send a message to a channel.
 
 By default, messages are sent using Slack Block Kit formatting for a more
@@ -54,7 +58,29 @@ Text is optional when providing blocks via any of these methods:
 Examples:
   slack-chat-api messages send C1234567890 --blocks '[{"type":"section",...}]'
   slack-chat-api messages send C1234567890 --blocks-file ./report.json
-  generate-report | slack-chat-api messages send C1234567890 --blocks-stdin`,
+  generate-report | slack-chat-api messages send C1234567890 --blocks-stdin
+
+FILE ATTACHMENTS
+
+--file attaches a file to the message and can be repeated; the text becomes
+the comment posted with the files. Attaching files requires the files:write
+scope and cannot be combined with blocks.
+
+  slack-chat-api messages send C1234567890 "Nightly results" --file results.csv
+  slack-chat-api messages send C1234567890 "Screenshots" --file a.png --file b.png
+
+EPHEMERAL AND /ME MESSAGES
+
+--ephemeral shows the message only to --user, who must be in the channel.
+Ephemeral messages are not stored and cannot be updated or deleted. --user
+takes a user ID, @handle or email address; resolving a @handle or email
+needs the users:read scope.
+
+--me posts an action in italics, like /me in Slack. Slack accepts only text
+for these, so they cannot have blocks, files or a thread.
+
+  slack-chat-api messages send C1234567890 "Your deploy is queued" --ephemeral --user @alice
+  slack-chat-api messages send C1234567890 "is deploying to production" --me`,
 		Args: cobra.RangeArgs(1, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			text := ""
@@ -70,6 +96,11 @@ Examples:
 	cmd.Flags().StringVar(&opts.blocksFile, "blocks-file", "", "Read blocks from JSON file (recommended for complex payloads)")
 	cmd.Flags().BoolVar(&opts.blocksStdin, "blocks-stdin", false, "Read blocks from stdin (for piping from other tools)")
 	cmd.Flags().BoolVar(&opts.simple, "simple", false, "Send as plain text without block formatting")
+	cmd.Flags().StringArrayVar(&opts.files, "file", nil, "Attach a file (repeatable; \"-\" reads stdin)")
+	cmd.Flags().StringVar(&opts.filename, "filename", "", "File name for a file attached from stdin")
+	cmd.Flags().BoolVar(&opts.ephemeral, "ephemeral", false, "Show the message only to --user")
+	cmd.Flags().StringVar(&opts.user, "user", "", "Recipient of an --ephemeral message (ID, @handle or email)")
+	cmd.Flags().BoolVar(&opts.me, "me", false, "Send a /me action message (text only)")
 
 	return cmd
 }
@@ -87,82 +118,118 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		}
 	}
 
-	// Validate mutually exclusive blocks options
-	blocksOptionsCount := 0
-	if opts.blocksJSON != "" {
-		blocksOptionsCount++
-	}
-	if opts.blocksFile != "" {
-		blocksOptionsCount++
-	}
-	if opts.blocksStdin {
-		blocksOptionsCount++
-	}
-	if blocksOptionsCount > 1 {
-		return fmt.Errorf("only one of --blocks, --blocks-file, or --blocks-stdin can be specified")
+	if err := validateSendMode(opts); err != nil {
+		return err
 	}
 
-	// Read from stdin if text is "-"
-	if text == "-" {
-		if opts.blocksStdin {
-			return fmt.Errorf("cannot use '-' for text and --blocks-stdin together; stdin can only be used for one")
-		}
-		reader := opts.stdin
-		if reader == nil {
-			reader = os.Stdin
+	if len(opts.files) > 0 {
+		if opts.blocksJSON != "" || opts.blocksFile != "" || opts.blocksStdin {
+			return fmt.Errorf("--file cannot be combined with --blocks, --blocks-file, or --blocks-stdin")
 		}
-		scanner := bufio.NewScanner(reader)
-		var lines []byte
-		for scanner.Scan() {
-			if len(lines) > 0 {
-				lines = append(lines, '\n')
+		for _, f := range opts.files {
+			if f == "-" && text == "-" {
+				return fmt.Errorf("cannot use '-' for text and --file - together; stdin can only be used for one")
 			}
-			lines = append(lines, scanner.Bytes()...)
-		}
-		if err := scanner.Err(); err != nil {
-			return fmt.Errorf("reading stdin: %w", err)
 		}
-		text = string(lines)
 	}
 
-	// Unescape shell-escaped characters (e.g., \! from zsh)
-	text = unescapeShellChars(text)
+	text, blocks, err := messageInput{
+		text:        text,
+		blocksJSON:  opts.blocksJSON,
+		blocksFile:  opts.blocksFile,
+		blocksStdin: opts.blocksStdin,
+		simple:      opts.simple || opts.me,
+		stdin:       opts.stdin,
+		allowEmpty:  len(opts.files) > 0,
+	}.resolve()
+	if err != nil {
+		return err
+	}
+
+	if len(opts.files) > 0 {
+		return sendFiles(channel, text, opts, c)
+	}
+	if opts.ephemeral {
+		return sendEphemeral(channel, text, blocks, opts, c)
+	}
+	if opts.me {
+		return sendMe(channel, text, c)
+	}
 
-	// Determine blocks source
-	var blocksSource string
-	if opts.blocksJSON != "" {
-		blocksSource = opts.blocksJSON
-	} else if opts.blocksFile != "" {
-		data, err := os.ReadFile(opts.blocksFile)
+	if c == nil {
+		c, err = client.New()
 		if err != nil {
-			return fmt.Errorf("reading blocks file: %w", err)
-		}
-		blocksSource = string(data)
-	} else if opts.blocksStdin {
-		reader := opts.stdin
-		if reader == nil {
-			reader = os.Stdin
-		}
-		scanner := bufio.NewScanner(reader)
-		var lines []byte
-		for scanner.Scan() {
-			if len(lines) > 0 {
-				lines = append(lines, '\n')
-			}
-			lines = append(lines, scanner.Bytes()...)
+			return err
 		}
-		if err := scanner.Err(); err != nil {
-			return fmt.Errorf("reading blocks from stdin: %w", err)
+	}
+
+	msg, err := c.SendMessage(channel, text, opts.threadTS, blocks)
+	if err != nil {
+		return client.WrapError("send message", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(msg)
+	}
+
+	output.Printf("Message sent (ts: %s)\n", msg.TS)
+	return nil
+}
+
+// sendFiles shares files in a channel with text as their comment
+func sendFiles(channel, text string, opts *sendOptions, c *client.Client) error {
+	uploads, closeFiles, err := client.OpenUploads(opts.files, opts.stdin, opts.filename)
+	if err != nil {
+		return err
+	}
+	defer closeFiles()
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
 		}
-		blocksSource = string(lines)
 	}
 
-	// Validate: must have text or blocks (or both)
-	hasBlocks := blocksSource != ""
-	if text == "" && !hasBlocks {
-		return fmt.Errorf("message text cannot be empty (or provide blocks via --blocks, --blocks-file, or --blocks-stdin)")
+	files, err := c.UploadFiles(uploads, client.UploadOptions{
+		Channel:        channel,
+		InitialComment: text,
+		ThreadTS:       opts.threadTS,
+	})
+	if err != nil {
+		return client.WrapError("send files", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(files)
+	}
+
+	output.Printf("Message sent with %d file(s)\n", len(files))
+	return nil
+}
+
+// validateSendMode rejects option combinations that --ephemeral and --me
+// don't support
+func validateSendMode(opts *sendOptions) error {
+	switch {
+	case opts.ephemeral && opts.me:
+		return fmt.Errorf("--ephemeral and --me cannot be used together")
+	case opts.ephemeral && opts.user == "":
+		return fmt.Errorf("--ephemeral requires --user")
+	case !opts.ephemeral && opts.user != "":
+		return fmt.Errorf("--user can only be used with --ephemeral")
+	case opts.ephemeral && len(opts.files) > 0:
+		return fmt.Errorf("--file cannot be used with --ephemeral")
+	case opts.me && (opts.blocksJSON != "" || opts.blocksFile != "" || opts.blocksStdin):
+		return fmt.Errorf("--me messages are text only and cannot have blocks")
+	case opts.me && (opts.threadTS != "" || len(opts.files) > 0):
+		return fmt.Errorf("--me cannot be combined with --thread or --file")
 	}
+	return nil
+}
 
+// sendEphemeral shows a message to a single user in a channel
+func sendEphemeral(channel, text string, blocks []interface{}, opts *sendOptions, c *client.Client) error {
 	if c == nil {
 		var err error
 		c, err = client.New()
@@ -171,19 +238,37 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		}
 	}
 
-	var blocks []interface{}
-	if blocksSource != "" {
-		if err := json.Unmarshal([]byte(blocksSource), &blocks); err != nil {
-			return fmt.Errorf("invalid blocks JSON: %w", err)
+	users, err := client.ResolveUsers(c, []string{opts.user})
+	if err != nil {
+		return err
+	}
+
+	ts, err := c.SendEphemeral(channel, users[0], text, opts.threadTS, blocks)
+	if err != nil {
+		return client.WrapError("send ephemeral message", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(map[string]string{"channel": channel, "user": users[0], "message_ts": ts})
+	}
+
+	output.Printf("Ephemeral message sent to %s (ts: %s)\n", users[0], ts)
+	return nil
+}
+
+// sendMe posts a /me action message
+func sendMe(channel, text string, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
 		}
-	} else if !opts.simple && text != "" {
-		// Default to block style for a more refined appearance
-		blocks = buildDefaultBlocks(text)
 	}
 
-	msg, err := c.SendMessage(channel, text, opts.threadTS, blocks)
+	msg, err := c.SendMeMessage(channel, text)
 	if err != nil {
-		return client.WrapError("send message", err)
+		return client.WrapError("send /me message", err)
 	}
 
 	if output.IsJSON() {
@@ -4,7 +4,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type threadOptions struct {
@@ -15,11 +17,25 @@ func newThreadCmd() *cobra.Command {
 	opts := &threadOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "thread <channel> <thread-ts>",
-		Short: "Get thread replies",
-		Args:  cobra.ExactArgs(2),
+		Use:         "thread <channel> <thread-ts>",
+		Short:       "Get thread replies",
+		Annotations: scopes.BotScopes("channels:history", "groups:history"),
+		Long: `Get a thread's parent message and replies.
+
+` + msgref.Help + `
+
+A permalink to any reply shows the whole thread.`,
+		Args: msgref.Args(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runThread(args[0], args[1], opts, nil)
+			ref, _, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			threadTS := ref.TS
+			if ref.ThreadTS != "" {
+				threadTS = ref.ThreadTS
+			}
+			return runThread(ref.Channel, threadTS, opts, nil)
 		},
 	}
 
@@ -52,9 +68,7 @@ func runThread(channel, threadTS string, opts *threadOptions, c *client.Client)
 	}
 
 	for _, m := range messages {
-		ts := formatTimestamp(m.TS)
-		text := truncate(m.Text, 80)
-		output.Printf("[%s] %s: %s\n", ts, m.User, text)
+		output.Println(formatMessage(m))
 	}
 
 	return nil
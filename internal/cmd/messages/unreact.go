@@ -6,7 +6,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 	"github.com/piekstra/slack-chat-api/internal/validate"
 )
 
@@ -16,11 +18,17 @@ func newUnreactCmd() *cobra.Command {
 	opts := &unreactOptions{}
 
 	return &cobra.Command{
-		Use:   "unreact <channel> <timestamp> <emoji>",
-		Short: "Remove a reaction from a message",
-		Args:  cobra.ExactArgs(3),
+		Use:         "unreact <channel> <timestamp> <emoji>",
+		Short:       "Remove a reaction from a message",
+		Annotations: scopes.BotScopes("reactions:write"),
+		Long:        "Remove a reaction from a message.\n\n" + msgref.Help,
+		Args:        msgref.Args(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUnreact(args[0], args[1], args[2], opts, nil)
+			ref, rest, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			return runUnreact(ref.Channel, ref.TS, rest[0], opts, nil)
 		},
 	}
 }
@@ -7,7 +7,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type updateOptions struct {
@@ -19,15 +21,22 @@ func newUpdateCmd() *cobra.Command {
 	opts := &updateOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "update <channel> <timestamp> <text>",
-		Short: "Update an existing message",
+		Use:         "update <channel> <timestamp> <text>",
+		Short:       "Update an existing message",
+		Annotations: scopes.BotScopes("chat:write"),
 		Long: `Update an existing message.
 
 By default, messages are updated using Slack Block Kit formatting for a more
-refined appearance. Use --simple to update with plain text instead.`,
-		Args: cobra.ExactArgs(3),
+refined appearance. Use --simple to update with plain text instead.
+
+` + msgref.Help,
+		Args: msgref.Args(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpdate(args[0], args[1], args[2], opts, nil)
+			ref, rest, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			return runUpdate(ref.Channel, ref.TS, rest[0], opts, nil)
 		},
 	}
 
@@ -0,0 +1,58 @@
+package pins
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type addOptions struct{}
+
+func newAddCmd() *cobra.Command {
+	opts := &addOptions{}
+
+	return &cobra.Command{
+		Use:         "add <channel> <timestamp>",
+		Short:       "Pin a message to its channel",
+		Annotations: scopes.BotScopes("pins:write"),
+		Long:        "Pin a message to its channel.\n\n" + msgref.Help,
+		Args:        msgref.Args(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, _, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			return runAdd(ref.Channel, ref.TS, opts, nil)
+		},
+	}
+}
+
+func runAdd(channel, timestamp string, opts *addOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.AddPin(channel, timestamp); err != nil {
+		return client.WrapError(fmt.Sprintf("pin message %s", timestamp), err)
+	}
+
+	output.Printf("Pinned message %s in %s\n", timestamp, channel)
+	return nil
+}
@@ -0,0 +1,88 @@
+package pins
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type listOptions struct{}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	return &cobra.Command{
+		Use:         "list <channel>",
+		Short:       "List a channel's pinned items",
+		Annotations: scopes.BotScopes("pins:read"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(args[0], opts, nil)
+		},
+	}
+}
+
+func runList(channel string, opts *listOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	items, err := c.ListPins(channel)
+	if err != nil {
+		return client.WrapError("list pins", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(items)
+	}
+
+	if len(items) == 0 {
+		output.Println("No pinned items")
+		return nil
+	}
+
+	headers := []string{"TYPE", "ID", "FROM", "PINNED BY", "PINNED AT", "TEXT"}
+	rows := make([][]string, 0, len(items))
+	for _, it := range items {
+		var id, from, text string
+		switch {
+		case it.Message != nil:
+			id, from, text = it.Message.TS, it.Message.Sender(), it.Message.Text
+		case it.File != nil:
+			id, from, text = it.File.ID, it.File.User, it.File.Name
+		}
+		rows = append(rows, []string{it.Type, id, from, it.CreatedBy, formatTime(it.Created), oneLine(text, 60)})
+	}
+	output.Table(headers, rows)
+	return nil
+}
+
+func formatTime(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).Format("2006-01-02 15:04")
+}
+
+// oneLine shortens text to maxLen on a single line
+func oneLine(s string, maxLen int) string {
+	s = strings.ReplaceAll(s, "\n", " ")
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,20 @@
+package pins
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates the pins command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pins",
+		Aliases: []string{"pin"},
+		Short:   "Pin and unpin messages",
+	}
+
+	cmd.AddCommand(newAddCmd())
+	cmd.AddCommand(newRemoveCmd())
+	cmd.AddCommand(newListCmd())
+
+	return cmd
+}
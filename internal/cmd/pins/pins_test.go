@@ -0,0 +1,55 @@
+package pins
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	t.Cleanup(func() { output.Writer = orig })
+	return buf
+}
+
+func TestPinsWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	channel := srv.AddChannel(slacktest.Channel{Name: "inc-42", Members: []string{slacktest.BotUserID}})
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+	ts := srv.AddMessage(channel, slacktest.Message{User: slacktest.UserID, Text: "Runbook: https://wiki.example.com/runbook"})
+	buf := captureOutput(t)
+
+	require.NoError(t, runAdd(channel, ts, &addOptions{}, c))
+	assert.Equal(t, []string{ts}, srv.Pins(channel))
+
+	err := runAdd(channel, ts, &addOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already pinned")
+
+	buf.Reset()
+	require.NoError(t, runList(channel, &listOptions{}, c))
+	assert.Contains(t, buf.String(), "Runbook")
+	assert.Contains(t, buf.String(), slacktest.BotUserID)
+
+	require.NoError(t, runRemove(channel, ts, &removeOptions{}, c))
+	assert.Empty(t, srv.Pins(channel))
+
+	buf.Reset()
+	require.NoError(t, runList(channel, &listOptions{}, c))
+	assert.Contains(t, buf.String(), "No pinned items")
+}
+
+func TestRunAdd_Validation(t *testing.T) {
+	assert.Error(t, runAdd("general", "1234567890.123456", &addOptions{}, nil))
+	assert.Error(t, runAdd("C123", "yesterday", &addOptions{}, nil))
+}
@@ -0,0 +1,59 @@
+package pins
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/msgref"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+type removeOptions struct{}
+
+func newRemoveCmd() *cobra.Command {
+	opts := &removeOptions{}
+
+	return &cobra.Command{
+		Use:         "remove <channel> <timestamp>",
+		Aliases:     []string{"rm"},
+		Short:       "Unpin a message",
+		Annotations: scopes.BotScopes("pins:write"),
+		Long:        "Unpin a message.\n\n" + msgref.Help,
+		Args:        msgref.Args(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, _, err := msgref.Split(args)
+			if err != nil {
+				return err
+			}
+			return runRemove(ref.Channel, ref.TS, opts, nil)
+		},
+	}
+}
+
+func runRemove(channel, timestamp string, opts *removeOptions, c *client.Client) error {
+	if err := validate.ChannelID(channel); err != nil {
+		return err
+	}
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.RemovePin(channel, timestamp); err != nil {
+		return client.WrapError(fmt.Sprintf("unpin message %s", timestamp), err)
+	}
+
+	output.Printf("Unpinned message %s in %s\n", timestamp, channel)
+	return nil
+}
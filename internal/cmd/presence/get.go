@@ -0,0 +1,72 @@
+package presence
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type getOptions struct{}
+
+func newGetCmd() *cobra.Command {
+	opts := &getOptions{}
+
+	return &cobra.Command{
+		Use:         "get [user]",
+		Short:       "Get presence (yours by default)",
+		Annotations: scopes.UserScopes("users:read", "users:read.email"),
+		Long: `Get a user's presence: active or away. With no user, get your own,
+including whether you set yourself away.
+
+The user can be a user ID, @handle or email.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			user := ""
+			if len(args) > 0 {
+				user = args[0]
+			}
+			return runGet(user, opts, nil)
+		},
+	}
+}
+
+func runGet(user string, opts *getOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if user != "" {
+		ids, err := client.ResolveUsers(c, []string{user})
+		if err != nil {
+			return err
+		}
+		user = ids[0]
+	}
+
+	p, err := c.GetPresence(user)
+	if err != nil {
+		return client.WrapError("get presence", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(p)
+	}
+
+	output.KeyValue("Presence", p.Presence)
+	if user == "" {
+		output.KeyValue("Set Away", p.ManualAway)
+		output.KeyValue("Online", p.Online)
+		if p.LastActivity != 0 {
+			output.KeyValue("Last Activity", time.Unix(p.LastActivity, 0).Format("2006-01-02 15:04"))
+		}
+	}
+	return nil
+}
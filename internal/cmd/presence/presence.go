@@ -0,0 +1,20 @@
+package presence
+
+import "github.com/spf13/cobra"
+
+// NewCmd creates the presence command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "presence",
+		Short: "Get or set presence (requires user token)",
+		Long: `Get your presence or someone else's, or set yourself away.
+
+These commands use the user token (xoxp-*): users:read to get presence and
+users:write to set it.`,
+	}
+
+	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(newSetCmd())
+
+	return cmd
+}
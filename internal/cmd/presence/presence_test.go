@@ -0,0 +1,37 @@
+package presence
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func TestPresenceWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.UserToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runSet("away", &setOptions{}, c))
+	assert.Equal(t, "away", srv.Presence(slacktest.UserID))
+
+	buf.Reset()
+	require.NoError(t, runGet("@tester", &getOptions{}, c))
+	assert.Contains(t, buf.String(), "away")
+	assert.NotContains(t, buf.String(), "Set Away", "only your own presence says why")
+
+	require.NoError(t, runSet("auto", &setOptions{}, c))
+	assert.Equal(t, "active", srv.Presence(slacktest.UserID))
+
+	assert.Error(t, runSet("busy", &setOptions{}, c))
+}
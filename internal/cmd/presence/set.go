@@ -0,0 +1,51 @@
+package presence
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type setOptions struct{}
+
+func newSetCmd() *cobra.Command {
+	opts := &setOptions{}
+
+	return &cobra.Command{
+		Use:         "set <auto|away>",
+		Short:       "Set yourself away, or back to automatic",
+		Annotations: scopes.UserScopes("users:write"),
+		Long: `Set your presence. "away" marks you away until you set "auto" again;
+"auto" lets Slack decide from your activity.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{"auto", "away"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(args[0], opts, nil)
+		},
+	}
+}
+
+func runSet(presence string, opts *setOptions, c *client.Client) error {
+	if presence != "auto" && presence != "away" {
+		return fmt.Errorf("invalid presence %q: use auto or away", presence)
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.SetPresence(presence); err != nil {
+		return client.WrapError("set presence", err)
+	}
+
+	output.Printf("Presence set to %s\n", presence)
+	return nil
+}
@@ -0,0 +1,127 @@
+package reminders
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/timeparse"
+)
+
+type addOptions struct {
+	at   string
+	tz   string
+	user string
+	now  func() time.Time // For testing
+}
+
+func newAddCmd() *cobra.Command {
+	opts := &addOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "add <text>",
+		Short:       "Create a reminder",
+		Annotations: scopes.UserScopes("reminders:write", "users:read", "users:read.email"),
+		Long: `Create a reminder for yourself, or for someone else with --user.
+
+--at accepts:
+  09:00, 9am, 5:30pm, noon     the next time the clock shows that time
+  tomorrow 9am, friday 14:00   a day (today, tomorrow, a weekday, "next monday")
+  2026-03-14 09:30             a date and time
+  in 2h, +30m, in 3 days       an offset from now
+  every weekday at 9am         a recurring reminder, passed to Slack as written
+
+Times are in the local time zone unless --tz names another. --user takes a
+user ID, @handle or email.
+
+Examples:
+  slack-chat-api reminders add "Review the deploy" --at "in 2h"
+  slack-chat-api reminders add "Send the report" --at "friday 4pm" --user @bob
+  slack-chat-api reminders add "Stand up and stretch" --at "every weekday at 3pm"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.at, "at", "", "When to remind (e.g., \"tomorrow 09:00\", \"in 2h\", \"every monday at 9am\")")
+	cmd.Flags().StringVar(&opts.tz, "tz", "", "Time zone for --at (IANA name, e.g., America/New_York; default local)")
+	cmd.Flags().StringVar(&opts.user, "user", "", "Remind someone else (user ID, @handle or email)")
+	_ = cmd.MarkFlagRequired("at")
+
+	return cmd
+}
+
+func runAdd(text string, opts *addOptions, c *client.Client) error {
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("reminder text cannot be empty")
+	}
+
+	// Recurring reminders are left to Slack, which parses "every ..." itself
+	var when string
+	var at time.Time
+	if recurring(opts.at) {
+		when = strings.TrimSpace(opts.at)
+	} else {
+		loc, err := timeparse.LoadLocation(opts.tz)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if opts.now != nil {
+			now = opts.now()
+		}
+		at, err = timeparse.Parse(opts.at, now, loc)
+		if err != nil {
+			return err
+		}
+		if !at.After(now) {
+			return fmt.Errorf("reminder time %s is in the past", at.Format("2006-01-02 15:04 MST"))
+		}
+		when = strconv.FormatInt(at.Unix(), 10)
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	var user string
+	if opts.user != "" {
+		ids, err := client.ResolveUsers(c, []string{opts.user})
+		if err != nil {
+			return err
+		}
+		user = ids[0]
+	}
+
+	rem, err := c.AddReminder(text, when, user)
+	if err != nil {
+		return client.WrapError("add reminder", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(rem)
+	}
+
+	switch {
+	case rem.Recurring:
+		output.Printf("Recurring reminder created: %s (id: %s)\n", when, rem.ID)
+	default:
+		output.Printf("Reminder set for %s (id: %s)\n", at.Format("Mon 2006-01-02 15:04 MST"), rem.ID)
+	}
+	return nil
+}
+
+func recurring(at string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(at)), "every ")
+}
@@ -0,0 +1,45 @@
+package reminders
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type completeOptions struct{}
+
+func newCompleteCmd() *cobra.Command {
+	opts := &completeOptions{}
+
+	return &cobra.Command{
+		Use:         "complete <reminder-id>",
+		Aliases:     []string{"done"},
+		Short:       "Mark a reminder as complete",
+		Annotations: scopes.UserScopes("reminders:write"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runComplete(args[0], opts, nil)
+		},
+	}
+}
+
+func runComplete(id string, opts *completeOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.CompleteReminder(id); err != nil {
+		return client.WrapError(fmt.Sprintf("complete reminder %s", id), err)
+	}
+
+	output.Printf("Reminder %s completed\n", id)
+	return nil
+}
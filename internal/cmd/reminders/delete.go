@@ -0,0 +1,76 @@
+package reminders
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type deleteOptions struct {
+	force bool
+	stdin io.Reader // For testing
+}
+
+func newDeleteCmd() *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "delete <reminder-id>",
+		Aliases:     []string{"rm"},
+		Short:       "Delete a reminder",
+		Annotations: scopes.UserScopes("reminders:write"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runDelete(id string, opts *deleteOptions, c *client.Client) error {
+	// Prompt for confirmation unless --force
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		output.Printf("About to delete reminder %s\n", id)
+		output.Printf("Are you sure? [y/N]: ")
+
+		scanner := bufio.NewScanner(reader)
+		if scanner.Scan() {
+			confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+			if confirm != "y" && confirm != "yes" {
+				output.Println("Cancelled.")
+				return nil
+			}
+		}
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.DeleteReminder(id); err != nil {
+		return client.WrapError(fmt.Sprintf("delete reminder %s", id), err)
+	}
+
+	output.Println("Reminder deleted")
+	return nil
+}
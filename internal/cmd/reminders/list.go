@@ -0,0 +1,93 @@
+package reminders
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type listOptions struct {
+	all bool
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Short:       "List your reminders",
+		Annotations: scopes.UserScopes("reminders:read"),
+		Long: `List the reminders you created or that remind you.
+
+Completed reminders are hidden unless --all is given.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Include completed reminders")
+
+	return cmd
+}
+
+func runList(opts *listOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	reminders, err := c.ListReminders()
+	if err != nil {
+		return client.WrapError("list reminders", err)
+	}
+
+	if !opts.all {
+		pending := reminders[:0]
+		for _, r := range reminders {
+			if r.CompleteTS == 0 {
+				pending = append(pending, r)
+			}
+		}
+		reminders = pending
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(reminders)
+	}
+
+	if len(reminders) == 0 {
+		output.Println("No reminders found")
+		return nil
+	}
+
+	headers := []string{"ID", "WHEN", "USER", "STATUS", "TEXT"}
+	rows := make([][]string, 0, len(reminders))
+	for _, r := range reminders {
+		when := "recurring"
+		if !r.Recurring {
+			when = formatTime(r.Time)
+		}
+		status := "pending"
+		if r.CompleteTS != 0 {
+			status = "completed " + formatTime(r.CompleteTS)
+		}
+		rows = append(rows, []string{r.ID, when, r.User, status, r.Text})
+	}
+	output.Table(headers, rows)
+	return nil
+}
+
+func formatTime(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	return time.Unix(unix, 0).Format("2006-01-02 15:04")
+}
@@ -0,0 +1,24 @@
+package reminders
+
+import "github.com/spf13/cobra"
+
+// NewCmd creates the reminders command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "reminders",
+		Aliases: []string{"reminder", "remind"},
+		Short:   "Manage reminders (requires user token)",
+		Long: `Create, list, complete and delete Slack reminders.
+
+Reminders belong to people, not apps, so these commands need a user token
+(xoxp-*) with the reminders:read and reminders:write scopes. Run
+'slack-chat-api config set-token <your-xoxp-token>' to configure one.`,
+	}
+
+	cmd.AddCommand(newAddCmd())
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newCompleteCmd())
+	cmd.AddCommand(newDeleteCmd())
+
+	return cmd
+}
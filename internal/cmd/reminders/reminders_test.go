@@ -0,0 +1,75 @@
+package reminders
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	t.Cleanup(func() { output.Writer = orig })
+	return buf
+}
+
+func TestRemindersWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.UserToken, nil)
+	buf := captureOutput(t)
+
+	require.NoError(t, runAdd("Review the deploy", &addOptions{at: "in 2h"}, c))
+	require.NoError(t, runAdd("Standup", &addOptions{at: "every weekday at 9am", user: "@slacktest-bot"}, c))
+	reminders := srv.Reminders()
+	require.Len(t, reminders, 2)
+	assert.Equal(t, slacktest.UserID, reminders[0].User)
+	assert.InDelta(t, time.Now().Add(2*time.Hour).Unix(), reminders[0].Time, 5)
+	assert.Equal(t, slacktest.BotUserID, reminders[1].User)
+	assert.True(t, reminders[1].Recurring)
+
+	require.NoError(t, runComplete(reminders[0].ID, &completeOptions{}, c))
+
+	buf.Reset()
+	require.NoError(t, runList(&listOptions{}, c))
+	assert.NotContains(t, buf.String(), "Review the deploy")
+	assert.Contains(t, buf.String(), "Standup")
+
+	buf.Reset()
+	require.NoError(t, runList(&listOptions{all: true}, c))
+	assert.Contains(t, buf.String(), "Review the deploy")
+
+	require.NoError(t, runDelete(reminders[1].ID, &deleteOptions{stdin: strings.NewReader("n\n")}, c))
+	assert.Len(t, srv.Reminders(), 2)
+	require.NoError(t, runDelete(reminders[1].ID, &deleteOptions{force: true}, c))
+	assert.Len(t, srv.Reminders(), 1)
+}
+
+func TestRunAdd_Validation(t *testing.T) {
+	now := func() time.Time { return time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC) }
+	assert.Error(t, runAdd("", &addOptions{at: "in 2h"}, nil))
+	assert.Error(t, runAdd("hi", &addOptions{at: "whenever"}, nil))
+	err := runAdd("hi", &addOptions{at: "2026-03-13 09:00", tz: "UTC", now: now}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "in the past")
+}
+
+func TestRunAdd_BotTokenRejected(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	err := runAdd("hi", &addOptions{at: "in 1h"}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not_allowed_token_type")
+}
@@ -2,21 +2,40 @@ package root
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/cmd/app"
+	"github.com/piekstra/slack-chat-api/internal/cmd/bookmarks"
 	"github.com/piekstra/slack-chat-api/internal/cmd/channels"
 	"github.com/piekstra/slack-chat-api/internal/cmd/config"
+	"github.com/piekstra/slack-chat-api/internal/cmd/dnd"
+	"github.com/piekstra/slack-chat-api/internal/cmd/files"
 	"github.com/piekstra/slack-chat-api/internal/cmd/messages"
+	"github.com/piekstra/slack-chat-api/internal/cmd/pins"
+	"github.com/piekstra/slack-chat-api/internal/cmd/presence"
+	"github.com/piekstra/slack-chat-api/internal/cmd/reminders"
 	"github.com/piekstra/slack-chat-api/internal/cmd/search"
+	"github.com/piekstra/slack-chat-api/internal/cmd/status"
+	"github.com/piekstra/slack-chat-api/internal/cmd/usergroups"
 	"github.com/piekstra/slack-chat-api/internal/cmd/users"
 	"github.com/piekstra/slack-chat-api/internal/cmd/workspace"
 	"github.com/piekstra/slack-chat-api/internal/output"
 	"github.com/piekstra/slack-chat-api/internal/version"
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+	"github.com/piekstra/slack-chat-api/pkg/slack/cassette"
 )
 
-var outputFormat string
+var (
+	outputFormat string
+	recordDir    string
+	replayDir    string
+	debugFormat  string
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "slack-chat-api",
@@ -38,10 +57,69 @@ Or set the SLACK_API_TOKEN environment variable.`,
 			return err
 		}
 		output.OutputFormat = format
-		return nil
+
+		if err := setupCassette(); err != nil {
+			return err
+		}
+		return setupDebug(cmd)
 	},
 }
 
+// setupCassette routes API traffic through a cassette recorder or replayer
+// for --record and --replay
+func setupCassette() error {
+	switch {
+	case recordDir != "" && replayDir != "":
+		return fmt.Errorf("--record and --replay cannot be used together")
+	case recordDir != "":
+		rec, err := cassette.NewRecorder(recordDir, nil)
+		if err != nil {
+			return err
+		}
+		client.Transport = rec
+	case replayDir != "":
+		rep, err := cassette.Load(replayDir)
+		if err != nil {
+			return err
+		}
+		client.Transport = rep
+		client.Replaying = true
+	}
+	return nil
+}
+
+// setupDebug logs every API call to stderr for --debug or SLACK_DEBUG.
+// The flag or variable selects text (the default) or JSON logs.
+func setupDebug(cmd *cobra.Command) error {
+	format := debugFormat
+	if !cmd.Flags().Changed("debug") {
+		switch env := strings.ToLower(os.Getenv("SLACK_DEBUG")); env {
+		case "", "0", "false":
+			return nil
+		case "json":
+			format = "json"
+		default:
+			format = "text"
+		}
+	}
+
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	var handler slog.Handler
+	switch format {
+	case "text":
+		handler = slog.NewTextHandler(cmd.ErrOrStderr(), opts)
+	case "json":
+		handler = slog.NewJSONHandler(cmd.ErrOrStderr(), opts)
+	default:
+		return fmt.Errorf("invalid debug format %q: use text or json", format)
+	}
+
+	logger := slog.New(handler)
+	client.Logger = logger
+	client.Transport = &slack.LoggingTransport{Next: client.Transport, Logger: logger}
+	return nil
+}
+
 // Execute runs the root command
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
@@ -53,6 +131,10 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "text", "Output format: text, json, or table")
 	rootCmd.PersistentFlags().BoolVar(&output.NoColor, "no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().StringVar(&recordDir, "record", "", "Record API requests and responses (tokens redacted) to a cassette directory")
+	rootCmd.PersistentFlags().StringVar(&replayDir, "replay", "", "Serve API responses from a cassette directory instead of Slack")
+	rootCmd.PersistentFlags().StringVar(&debugFormat, "debug", "", "Log API calls to stderr (--debug or --debug=json)")
+	rootCmd.PersistentFlags().Lookup("debug").NoOptDefVal = "text"
 
 	// Set custom version template to include commit and build date
 	rootCmd.SetVersionTemplate("slack-chat-api " + version.Info() + "\n")
@@ -60,8 +142,17 @@ func init() {
 	// Add subcommands
 	rootCmd.AddCommand(channels.NewCmd())
 	rootCmd.AddCommand(users.NewCmd())
+	rootCmd.AddCommand(usergroups.NewCmd())
 	rootCmd.AddCommand(messages.NewCmd())
+	rootCmd.AddCommand(files.NewCmd())
+	rootCmd.AddCommand(pins.NewCmd())
+	rootCmd.AddCommand(bookmarks.NewCmd())
+	rootCmd.AddCommand(reminders.NewCmd())
+	rootCmd.AddCommand(status.NewCmd())
+	rootCmd.AddCommand(presence.NewCmd())
+	rootCmd.AddCommand(dnd.NewCmd())
 	rootCmd.AddCommand(search.NewCmd())
 	rootCmd.AddCommand(workspace.NewCmd())
 	rootCmd.AddCommand(config.NewCmd())
+	rootCmd.AddCommand(app.NewCmd())
 }
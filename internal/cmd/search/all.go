@@ -5,6 +5,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type allOptions struct {
@@ -26,8 +27,9 @@ func newAllCmd() *cobra.Command {
 	opts := &allOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "all <query>",
-		Short: "Search messages and files",
+		Use:         "all <query>",
+		Short:       "Search messages and files",
+		Annotations: scopes.UserScopes("search:read"),
 		Long: `Search both messages and files across channels.
 
 Requires a user token (xoxp-*) with search:read scope.
@@ -1,12 +1,16 @@
 package search
 
 import (
+	"fmt"
+	"io"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/download"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type filesOptions struct {
@@ -22,14 +26,20 @@ type filesOptions struct {
 	before    string
 	fileType  string
 	hasPin    bool
+
+	download    string
+	concurrency int
+	force       bool
+	progress    io.Writer
 }
 
 func newFilesCmd() *cobra.Command {
 	opts := &filesOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "files <query>",
-		Short: "Search files",
+		Use:         "files <query>",
+		Short:       "Search files",
+		Annotations: scopes.UserScopes("search:read", "files:read"),
 		Long: `Search files across channels.
 
 Requires a user token (xoxp-*) with search:read scope.
@@ -46,9 +56,17 @@ Examples:
   slack-chat-api search files "quarterly report" --in "#finance"
   slack-chat-api search files "logo" --from "@alice" --type image
   slack-chat-api search files "contract" --type pdf
-  slack-chat-api search files "document" --scope public`,
+  slack-chat-api search files "document" --scope public
+
+Use --download to save the matching files (the current page of results) to a
+directory. Downloading needs the files:read scope on the user token. Files
+already in the directory are skipped and interrupted downloads resume; see
+'slack-chat-api files download --help'.
+
+  slack-chat-api search files "in:#design" --count 100 --download ./design-docs`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.progress = cmd.ErrOrStderr()
 			return runSearchFiles(args[0], opts, nil)
 		},
 	}
@@ -68,6 +86,11 @@ Examples:
 	cmd.Flags().StringVar(&opts.fileType, "type", "", "Filter by file type (pdf, doc, image, etc.)")
 	cmd.Flags().BoolVar(&opts.hasPin, "has-pin", false, "Files that are pinned")
 
+	// Download flags
+	cmd.Flags().StringVar(&opts.download, "download", "", "Download the matching files to this directory")
+	cmd.Flags().IntVarP(&opts.concurrency, "concurrency", "j", download.DefaultConcurrency, "Files to download at once (with --download)")
+	cmd.Flags().BoolVar(&opts.force, "force", false, "Download files again even if already present (with --download)")
+
 	return cmd
 }
 
@@ -105,6 +128,10 @@ func runSearchFiles(query string, opts *filesOptions, c *client.Client) error {
 		return err
 	}
 
+	if opts.download != "" && output.IsJSON() {
+		return downloadMatches(result, opts, c)
+	}
+
 	if output.IsJSON() {
 		return output.PrintJSON(result)
 	}
@@ -131,9 +158,42 @@ func runSearchFiles(query string, opts *filesOptions, c *client.Client) error {
 	output.Printf("\nPage %d of %d (showing %d of %d results)\n",
 		paging.Page, paging.Pages, len(result.Files.Matches), paging.Total)
 
+	if opts.download != "" {
+		output.Println()
+		return downloadMatches(result, opts, c)
+	}
 	return nil
 }
 
+// downloadMatches saves the files in a page of search results
+func downloadMatches(result *client.SearchResult, opts *filesOptions, c *client.Client) error {
+	var items []download.Item
+	if result.Files != nil {
+		for _, m := range result.Files.Matches {
+			item := download.Item{ID: m.ID, Name: m.Name, URL: m.URLPrivateDownload, Size: m.Size}
+			if item.URL == "" {
+				file, err := c.GetFileInfo(m.ID)
+				if err != nil {
+					return client.WrapError(fmt.Sprintf("get file %s", m.ID), err)
+				}
+				item = download.FileItem(file)
+			}
+			items = append(items, item)
+		}
+	}
+
+	results, err := download.Run(c, items, download.Options{
+		Dir:         opts.download,
+		Concurrency: opts.concurrency,
+		Force:       opts.force,
+		Progress:    opts.progress,
+	})
+	if err != nil {
+		return err
+	}
+	return download.PrintResults(results)
+}
+
 func formatUnixTimestamp(ts int64) string {
 	if ts == 0 {
 		return ""
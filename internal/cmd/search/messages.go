@@ -10,6 +10,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type messagesOptions struct {
@@ -31,8 +32,9 @@ func newMessagesCmd() *cobra.Command {
 	opts := &messagesOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "messages <query>",
-		Short: "Search messages",
+		Use:         "messages <query>",
+		Short:       "Search messages",
+		Annotations: scopes.UserScopes("search:read"),
 		Long: `Search messages across channels.
 
 Requires a user token (xoxp-*) with search:read scope.
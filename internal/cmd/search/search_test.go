@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
 )
 
 // Helper to create a test client with a mock server
@@ -739,3 +742,31 @@ func TestSearchMessages_WithSortOptions(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestRunSearchFiles_Download(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	srv.AddFile(slacktest.File{ID: "F0000001", Name: "q1-report.pdf", User: slacktest.UserID, Content: []byte("q1")})
+	srv.AddFile(slacktest.File{ID: "F0000002", Name: "q2-report.pdf", User: slacktest.UserID, Content: []byte("q2")})
+	srv.AddFile(slacktest.File{ID: "F0000003", Name: "lunch.png", User: slacktest.UserID, Content: []byte("png")})
+	c := client.NewWithConfig(srv.URL, slacktest.UserToken, nil)
+
+	dir := t.TempDir()
+	opts := &filesOptions{count: 20, page: 1, sort: "score", sortDir: "desc", download: dir}
+	if err := runSearchFiles("report", opts, c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, want := range map[string]string{"q1-report.pdf": "q1", "q2-report.pdf": "q2"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("expected %s to be downloaded: %v", name, err)
+		}
+		if string(data) != want {
+			t.Errorf("%s: expected %q, got %q", name, want, data)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "lunch.png")); err == nil {
+		t.Error("expected files not matching the search to be left alone")
+	}
+}
@@ -0,0 +1,42 @@
+package status
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type clearOptions struct{}
+
+func newClearCmd() *cobra.Command {
+	opts := &clearOptions{}
+
+	return &cobra.Command{
+		Use:         "clear",
+		Short:       "Clear your status",
+		Annotations: scopes.UserScopes("users.profile:write"),
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runClear(opts, nil)
+		},
+	}
+}
+
+func runClear(opts *clearOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.ClearStatus(); err != nil {
+		return client.WrapError("clear status", err)
+	}
+
+	output.Println("Status cleared")
+	return nil
+}
@@ -0,0 +1,116 @@
+package status
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+	"github.com/piekstra/slack-chat-api/internal/timeparse"
+)
+
+var emojiPattern = regexp.MustCompile(`^:[^\s:]+:$`)
+
+type setOptions struct {
+	until string
+	tz    string
+	now   func() time.Time // For testing
+}
+
+func newSetCmd() *cobra.Command {
+	opts := &setOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "set [emoji] <text>",
+		Short:       "Set your status",
+		Annotations: scopes.UserScopes("users.profile:write"),
+		Long: `Set your status emoji and text. A single :emoji: argument sets just the
+emoji; a single other argument sets just the text.
+
+--until clears the status automatically. It accepts the same times as
+'messages schedule --at', such as "17:00", "tomorrow 9am", "2026-11-01" or
+"in 2h", in the local time zone unless --tz names another.
+
+Examples:
+  slack-chat-api status set ":palm_tree:" "On vacation" --until 2026-11-01
+  slack-chat-api status set ":headphones:" "Heads down" --until "in 2h"
+  slack-chat-api status set "In a meeting"`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			emoji, text := splitStatus(args)
+			return runSet(emoji, text, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.until, "until", "", "Clear the status at this time (e.g., \"17:00\", \"2026-11-01\", \"in 2h\")")
+	cmd.Flags().StringVar(&opts.tz, "tz", "", "Time zone for --until (IANA name, e.g., America/New_York; default local)")
+
+	return cmd
+}
+
+// splitStatus returns the emoji and text given as arguments
+func splitStatus(args []string) (emoji, text string) {
+	if len(args) == 2 {
+		return args[0], args[1]
+	}
+	if emojiPattern.MatchString(args[0]) {
+		return args[0], ""
+	}
+	return "", args[0]
+}
+
+func runSet(emoji, text string, opts *setOptions, c *client.Client) error {
+	if emoji != "" && !emojiPattern.MatchString(emoji) {
+		return fmt.Errorf("invalid emoji %q: use the :name: form, e.g. :palm_tree:", emoji)
+	}
+	if strings.TrimSpace(emoji+text) == "" {
+		return fmt.Errorf("status needs an emoji or text; use 'slack-chat-api status clear' to clear it")
+	}
+	if n := utf8.RuneCountInString(text); n > 100 {
+		return fmt.Errorf("status text is %d characters; the maximum is 100", n)
+	}
+
+	var until time.Time
+	if opts.until != "" {
+		loc, err := timeparse.LoadLocation(opts.tz)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if opts.now != nil {
+			now = opts.now()
+		}
+		until, err = timeparse.Parse(opts.until, now, loc)
+		if err != nil {
+			return err
+		}
+		if !until.After(now) {
+			return fmt.Errorf("--until %s is in the past", until.Format("2006-01-02 15:04 MST"))
+		}
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.NewUserClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := c.SetStatus(emoji, text, until); err != nil {
+		return client.WrapError("set status", err)
+	}
+
+	msg := "Status set: " + strings.TrimSpace(emoji+" "+text)
+	if !until.IsZero() {
+		msg += fmt.Sprintf(" (until %s)", until.Format("Mon 2006-01-02 15:04 MST"))
+	}
+	output.Println(msg)
+	return nil
+}
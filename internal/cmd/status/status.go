@@ -0,0 +1,21 @@
+package status
+
+import "github.com/spf13/cobra"
+
+// NewCmd creates the status command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Set or clear your status (requires user token)",
+		Long: `Set or clear your Slack status.
+
+Statuses belong to people, not apps, so these commands need a user token
+(xoxp-*) with the users.profile:write scope. Use 'slack-chat-api users get'
+to see anyone's status.`,
+	}
+
+	cmd.AddCommand(newSetCmd())
+	cmd.AddCommand(newClearCmd())
+
+	return cmd
+}
@@ -0,0 +1,85 @@
+package status
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	t.Cleanup(func() { output.Writer = orig })
+	return buf
+}
+
+func TestStatusWorkflow_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.UserToken, nil)
+	buf := captureOutput(t)
+
+	require.NoError(t, runSet(":palm_tree:", "On vacation", &setOptions{until: "in 3 days"}, c))
+	assert.Contains(t, buf.String(), "Status set: :palm_tree: On vacation (until")
+	u, err := c.GetUserInfo(slacktest.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, ":palm_tree:", u.Profile.StatusEmoji)
+	assert.InDelta(t, time.Now().Add(72*time.Hour).Unix(), u.Profile.StatusExpiration, 5)
+
+	require.NoError(t, runClear(&clearOptions{}, c))
+	u, err = c.GetUserInfo(slacktest.UserID)
+	require.NoError(t, err)
+	assert.Empty(t, u.Profile.StatusEmoji)
+	assert.Empty(t, u.Profile.StatusText)
+}
+
+func TestSplitStatus(t *testing.T) {
+	emoji, text := splitStatus([]string{":palm_tree:", "On vacation"})
+	assert.Equal(t, ":palm_tree:", emoji)
+	assert.Equal(t, "On vacation", text)
+
+	emoji, text = splitStatus([]string{":headphones:"})
+	assert.Equal(t, ":headphones:", emoji)
+	assert.Empty(t, text)
+
+	emoji, text = splitStatus([]string{"In a meeting"})
+	assert.Empty(t, emoji)
+	assert.Equal(t, "In a meeting", text)
+}
+
+func TestRunSet_Validation(t *testing.T) {
+	now := func() time.Time { return time.Date(2026, 3, 14, 12, 0, 0, 0, time.UTC) }
+	assert.Error(t, runSet("palm_tree", "On vacation", &setOptions{}, nil))
+	assert.Error(t, runSet("", " ", &setOptions{}, nil))
+	err := runSet("", "Out", &setOptions{until: "2026-03-01", tz: "UTC", now: now}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "in the past")
+
+	err = runSet("", strings.Repeat("x", 101), &setOptions{}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "101 characters")
+}
+
+func TestRunSet_LengthCountsCharacters(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.UserToken, nil)
+	captureOutput(t)
+
+	// 100 characters, but far more than 100 bytes
+	text := strings.Repeat("🌴", 50) + strings.Repeat("é", 50)
+	require.NoError(t, runSet("", text, &setOptions{}, c))
+	u, err := c.GetUserInfo(slacktest.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, text, u.Profile.StatusText)
+}
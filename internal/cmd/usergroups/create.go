@@ -0,0 +1,93 @@
+package usergroups
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type createOptions struct {
+	name        string
+	description string
+	channels    string
+	users       string
+}
+
+func newCreateCmd() *cobra.Command {
+	opts := &createOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "create <handle>",
+		Short:       "Create a user group",
+		Annotations: scopes.BotScopes("usergroups:write", "users:read", "users:read.email"),
+		Long: `Create a user group, mentioned as @<handle>. The display name defaults
+to the handle.
+
+Examples:
+  slack-chat-api usergroups create oncall --name "On-call" --users @alice,@bob
+  slack-chat-api usergroups create sre --description "Site reliability" --channels C1234567890`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "Display name (default: the handle)")
+	cmd.Flags().StringVar(&opts.description, "description", "", "Description")
+	cmd.Flags().StringVar(&opts.channels, "channels", "", "Default channel IDs, comma-separated")
+	cmd.Flags().StringVar(&opts.users, "users", "", "Members: user IDs, @handles or emails, comma-separated")
+
+	return cmd
+}
+
+func runCreate(handle string, opts *createOptions, c *client.Client) error {
+	handle = strings.TrimPrefix(handle, "@")
+	if handle == "" {
+		return fmt.Errorf("handle cannot be empty")
+	}
+	name := opts.name
+	if name == "" {
+		name = handle
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve members first, so a typo doesn't leave an empty group behind
+	var members []string
+	if refs := splitList(opts.users); len(refs) > 0 {
+		var err error
+		members, err = client.ResolveUsers(c, refs)
+		if err != nil {
+			return err
+		}
+	}
+
+	group, err := c.CreateUsergroup(name, handle, opts.description, splitList(opts.channels))
+	if err != nil {
+		return client.WrapError("create user group", err)
+	}
+	if len(members) > 0 {
+		group, err = c.SetUsergroupMembers(group.ID, members)
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("add members to @%s (created as %s)", handle, group.ID), err)
+		}
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(group)
+	}
+
+	output.Printf("Created user group %s (%s) with %d member(s)\n", groupLabel(group), group.ID, len(members))
+	return nil
+}
@@ -0,0 +1,84 @@
+package usergroups
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type disableOptions struct{}
+
+func newDisableCmd() *cobra.Command {
+	opts := &disableOptions{}
+
+	return &cobra.Command{
+		Use:         "disable <group>",
+		Short:       "Disable a user group",
+		Long:        "Disable a user group, so its handle no longer notifies anyone. Its members are kept; re-enable it with 'usergroups enable'.",
+		Annotations: scopes.BotScopes("usergroups:read", "usergroups:write"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDisable(args[0], opts, nil)
+		},
+	}
+}
+
+func runDisable(ref string, opts *disableOptions, c *client.Client) error {
+	return setEnabled(ref, false, c)
+}
+
+type enableOptions struct{}
+
+func newEnableCmd() *cobra.Command {
+	opts := &enableOptions{}
+
+	return &cobra.Command{
+		Use:         "enable <group>",
+		Short:       "Re-enable a disabled user group",
+		Annotations: scopes.BotScopes("usergroups:read", "usergroups:write"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnable(args[0], opts, nil)
+		},
+	}
+}
+
+func runEnable(ref string, opts *enableOptions, c *client.Client) error {
+	return setEnabled(ref, true, c)
+}
+
+// setEnabled disables or re-enables a user group
+func setEnabled(ref string, enable bool, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	group, err := resolveGroup(c, ref)
+	if err != nil {
+		return err
+	}
+
+	op, verb, call := "disable", "Disabled", c.DisableUsergroup
+	if enable {
+		op, verb, call = "enable", "Enabled", c.EnableUsergroup
+	}
+	updated, err := call(group.ID)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("%s user group %s", op, group.ID), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(updated)
+	}
+
+	output.Printf("%s user group %s (%s)\n", verb, groupLabel(updated), updated.ID)
+	return nil
+}
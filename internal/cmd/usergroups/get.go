@@ -0,0 +1,73 @@
+package usergroups
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type getOptions struct{}
+
+func newGetCmd() *cobra.Command {
+	opts := &getOptions{}
+
+	return &cobra.Command{
+		Use:         "get <group>",
+		Short:       "Get a user group's details and members",
+		Annotations: scopes.BotScopes("usergroups:read"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGet(args[0], opts, nil)
+		},
+	}
+}
+
+func runGet(ref string, opts *getOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	group, err := resolveGroup(c, ref)
+	if err != nil {
+		return err
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(group)
+	}
+
+	output.KeyValue("ID", group.ID)
+	output.KeyValue("Handle", groupLabel(group))
+	output.KeyValue("Name", group.Name)
+	if group.Description != "" {
+		output.KeyValue("Description", group.Description)
+	}
+	if len(group.Prefs.Channels) > 0 {
+		output.KeyValue("Channels", strings.Join(group.Prefs.Channels, ", "))
+	}
+	if group.DateCreate != 0 {
+		created := time.Unix(group.DateCreate, 0).Format("2006-01-02 15:04")
+		if group.CreatedBy != "" {
+			created += " by " + group.CreatedBy
+		}
+		output.KeyValue("Created", created)
+	}
+	if group.IsDisabled() {
+		output.KeyValue("Disabled", time.Unix(group.DateDelete, 0).Format("2006-01-02 15:04"))
+	}
+	output.KeyValue("Users", fmt.Sprintf("%d", len(group.Users)))
+	if len(group.Users) > 0 {
+		output.KeyValue("Members", strings.Join(group.Users, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package usergroups
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type listOptions struct {
+	includeDisabled bool
+	includeUsers    bool
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "list",
+		Short:       "List user groups",
+		Annotations: scopes.BotScopes("usergroups:read"),
+		Args:        cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.includeDisabled, "include-disabled", false, "Include disabled groups")
+	cmd.Flags().BoolVar(&opts.includeUsers, "include-users", false, "Include member IDs (JSON output)")
+
+	return cmd
+}
+
+func runList(opts *listOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	groups, err := c.ListUsergroups(opts.includeDisabled, opts.includeUsers)
+	if err != nil {
+		return client.WrapError("list user groups", err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(groups)
+	}
+
+	if len(groups) == 0 {
+		output.Println("No user groups found")
+		return nil
+	}
+
+	headers := []string{"ID", "HANDLE", "NAME", "USERS", "DESCRIPTION"}
+	rows := make([][]string, 0, len(groups))
+	for i := range groups {
+		g := &groups[i]
+		handle := groupLabel(g)
+		if g.IsDisabled() {
+			handle += " (disabled)"
+		}
+		rows = append(rows, []string{g.ID, handle, g.Name, fmt.Sprintf("%d", g.UserCount), g.Description})
+	}
+	output.Table(headers, rows)
+	return nil
+}
@@ -0,0 +1,346 @@
+package usergroups
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+func newMembersCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "members",
+		Short: "List or change a user group's members",
+		Long: `List or change a user group's members. Users can be user IDs, @handles
+or emails, given as separate arguments or comma-separated.
+
+Examples:
+  slack-chat-api usergroups members list @oncall --names
+  slack-chat-api usergroups members set @oncall @alice @bob
+  slack-chat-api usergroups members add @oncall carol@example.com
+  slack-chat-api usergroups members remove @oncall @bob`,
+	}
+
+	cmd.AddCommand(newMembersListCmd())
+	cmd.AddCommand(newMembersSetCmd())
+	cmd.AddCommand(newMembersAddCmd())
+	cmd.AddCommand(newMembersRemoveCmd())
+
+	return cmd
+}
+
+type membersListOptions struct {
+	names bool
+}
+
+func newMembersListCmd() *cobra.Command {
+	opts := &membersListOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "list <group>",
+		Short:       "List a user group's members",
+		Annotations: scopes.BotScopes("usergroups:read", "users:read", "users:read.email"),
+		Args:        cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMembersList(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.names, "names", false, "Look up each member's name and email")
+
+	return cmd
+}
+
+func runMembersList(ref string, opts *membersListOptions, c *client.Client) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	group, err := resolveGroup(c, ref)
+	if err != nil {
+		return err
+	}
+
+	if !opts.names {
+		if output.IsJSON() {
+			return output.PrintJSON(group.Users)
+		}
+		if len(group.Users) == 0 {
+			output.Println("No members found")
+			return nil
+		}
+		rows := make([][]string, len(group.Users))
+		for i, id := range group.Users {
+			rows[i] = []string{id}
+		}
+		output.Table([]string{"ID"}, rows)
+		return nil
+	}
+
+	all, err := c.ListUsers(math.MaxInt32)
+	if err != nil {
+		return client.WrapError("list users", err)
+	}
+	byID := make(map[string]client.User, len(all))
+	for _, u := range all {
+		byID[u.ID] = u
+	}
+	users := make([]client.User, len(group.Users))
+	for i, id := range group.Users {
+		u, ok := byID[id]
+		if !ok {
+			u = client.User{ID: id}
+		}
+		users[i] = u
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(users)
+	}
+
+	if len(users) == 0 {
+		output.Println("No members found")
+		return nil
+	}
+
+	headers := []string{"ID", "USERNAME", "REAL NAME", "EMAIL"}
+	rows := make([][]string, len(users))
+	for i, u := range users {
+		rows[i] = []string{u.ID, u.Name, u.RealName, u.Profile.Email}
+	}
+	output.Table(headers, rows)
+	return nil
+}
+
+type membersSetOptions struct {
+	force bool
+	stdin io.Reader // For testing
+}
+
+func newMembersSetCmd() *cobra.Command {
+	opts := &membersSetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set <group> <user>...",
+		Short: "Replace a user group's members",
+		Long: `Replace a user group's members with the users given. Members not given are
+removed from the group, after a confirmation unless --force is given.`,
+		Annotations: scopes.BotScopes("usergroups:read", "usergroups:write", "users:read", "users:read.email"),
+		Args:        cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMembersSet(args[0], args[1:], opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt when members would be removed")
+
+	return cmd
+}
+
+type membersChangeOptions struct{}
+
+func newMembersAddCmd() *cobra.Command {
+	opts := &membersChangeOptions{}
+
+	return &cobra.Command{
+		Use:         "add <group> <user>...",
+		Short:       "Add users to a user group",
+		Annotations: scopes.BotScopes("usergroups:read", "usergroups:write", "users:read", "users:read.email"),
+		Args:        cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMembersAdd(args[0], args[1:], opts, nil)
+		},
+	}
+}
+
+func newMembersRemoveCmd() *cobra.Command {
+	opts := &membersChangeOptions{}
+
+	return &cobra.Command{
+		Use:         "remove <group> <user>...",
+		Short:       "Remove users from a user group",
+		Annotations: scopes.BotScopes("usergroups:read", "usergroups:write", "users:read", "users:read.email"),
+		Args:        cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMembersRemove(args[0], args[1:], opts, nil)
+		},
+	}
+}
+
+func runMembersSet(ref string, users []string, opts *membersSetOptions, c *client.Client) error {
+	next := func(current, given []string) []string {
+		return given
+	}
+	return changeMembers(ref, users, c, next, func(group *client.Usergroup, change membersChange) (bool, error) {
+		if opts.force || len(change.Removed) == 0 {
+			return true, nil
+		}
+		// A prompt would corrupt the JSON
+		if output.IsJSON() {
+			return false, fmt.Errorf("this would remove %d member(s) from %s: use --force with -o json", len(change.Removed), groupLabel(group))
+		}
+		return confirmRemoval(opts.stdin, group, change.Removed), nil
+	})
+}
+
+func runMembersAdd(ref string, users []string, opts *membersChangeOptions, c *client.Client) error {
+	return changeMembers(ref, users, c, func(current, given []string) []string {
+		return append(append([]string{}, current...), given...)
+	}, nil)
+}
+
+func runMembersRemove(ref string, users []string, opts *membersChangeOptions, c *client.Client) error {
+	return changeMembers(ref, users, c, func(current, given []string) []string {
+		drop := make(map[string]bool, len(given))
+		for _, id := range given {
+			drop[id] = true
+		}
+		var kept []string
+		for _, id := range current {
+			if !drop[id] {
+				kept = append(kept, id)
+			}
+		}
+		return kept
+	}, nil)
+}
+
+// confirmRemoval asks before members set drops members not given
+func confirmRemoval(stdin io.Reader, group *client.Usergroup, removed []string) bool {
+	reader := stdin
+	if reader == nil {
+		reader = os.Stdin
+	}
+
+	output.Printf("About to remove %d member(s) from %s: %s\n", len(removed), groupLabel(group), strings.Join(removed, ", "))
+	output.Printf("Are you sure? [y/N]: ")
+
+	scanner := bufio.NewScanner(reader)
+	if scanner.Scan() {
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		return confirm == "y" || confirm == "yes"
+	}
+	return false
+}
+
+// membersChange reports how a user group's membership changed
+type membersChange struct {
+	Usergroup string   `json:"usergroup"`
+	Handle    string   `json:"handle,omitempty"`
+	Added     []string `json:"added"`
+	Removed   []string `json:"removed"`
+	Users     []string `json:"users"`
+}
+
+// changeMembers resolves the users given, computes the group's new members
+// from its current ones, and saves them if they differ and confirm, if set,
+// approves the change
+func changeMembers(ref string, users []string, c *client.Client, next func(current, given []string) []string, confirm func(*client.Usergroup, membersChange) (bool, error)) error {
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	refs := splitList(users...)
+	if len(refs) == 0 {
+		return fmt.Errorf("no users given")
+	}
+	given, err := client.ResolveUsers(c, refs)
+	if err != nil {
+		return err
+	}
+
+	group, err := resolveGroup(c, ref)
+	if err != nil {
+		return err
+	}
+
+	members := unique(next(group.Users, given))
+	change := membersChange{
+		Usergroup: group.ID,
+		Handle:    group.Handle,
+		Added:     difference(members, group.Users),
+		Removed:   difference(group.Users, members),
+		Users:     members,
+	}
+
+	if len(members) == 0 {
+		return fmt.Errorf("cannot remove every member of %s: Slack requires at least one; use 'slack-chat-api usergroups disable' instead", groupLabel(group))
+	}
+	changed := len(change.Added) > 0 || len(change.Removed) > 0
+	if changed && confirm != nil {
+		ok, err := confirm(group, change)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			output.Println("Cancelled.")
+			return nil
+		}
+	}
+	if changed {
+		if _, err := c.SetUsergroupMembers(group.ID, members); err != nil {
+			return client.WrapError(fmt.Sprintf("update members of %s", group.ID), err)
+		}
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(change)
+	}
+
+	if len(change.Added) == 0 && len(change.Removed) == 0 {
+		output.Printf("No change: %s already has these members\n", groupLabel(group))
+		return nil
+	}
+	if len(change.Added) > 0 {
+		output.Printf("Added: %s\n", strings.Join(change.Added, ", "))
+	}
+	if len(change.Removed) > 0 {
+		output.Printf("Removed: %s\n", strings.Join(change.Removed, ", "))
+	}
+	output.Printf("%s now has %d member(s)\n", groupLabel(group), len(members))
+	return nil
+}
+
+// unique drops repeated IDs, keeping the first of each
+func unique(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	out := []string{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// difference returns the IDs in a that are not in b
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, id := range b {
+		in[id] = true
+	}
+	out := []string{}
+	for _, id := range a {
+		if !in[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
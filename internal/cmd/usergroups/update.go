@@ -0,0 +1,96 @@
+package usergroups
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+type updateOptions struct {
+	name        string
+	handle      string
+	description string
+	channels    string
+
+	update client.UsergroupUpdate
+}
+
+func newUpdateCmd() *cobra.Command {
+	opts := &updateOptions{}
+
+	cmd := &cobra.Command{
+		Use:         "update <group>",
+		Short:       "Update a user group's name, handle, description or channels",
+		Annotations: scopes.BotScopes("usergroups:read", "usergroups:write"),
+		Long: `Update a user group. Only the fields given are changed; --channels ""
+clears the default channels.
+
+Examples:
+  slack-chat-api usergroups update @oncall --description "Primary on-call"
+  slack-chat-api usergroups update S0123ABCDEF --handle oncall-primary`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				opts.update.Name = &opts.name
+			}
+			if flags.Changed("handle") {
+				opts.update.Handle = &opts.handle
+			}
+			if flags.Changed("description") {
+				opts.update.Description = &opts.description
+			}
+			if flags.Changed("channels") {
+				channels := splitList(opts.channels)
+				if channels == nil {
+					channels = []string{}
+				}
+				opts.update.Channels = &channels
+			}
+			return runUpdate(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.name, "name", "", "New display name")
+	cmd.Flags().StringVar(&opts.handle, "handle", "", "New handle")
+	cmd.Flags().StringVar(&opts.description, "description", "", "New description")
+	cmd.Flags().StringVar(&opts.channels, "channels", "", "New default channel IDs, comma-separated")
+
+	return cmd
+}
+
+func runUpdate(ref string, opts *updateOptions, c *client.Client) error {
+	u := opts.update
+	if u.Name == nil && u.Handle == nil && u.Description == nil && u.Channels == nil {
+		return fmt.Errorf("nothing to update: give --name, --handle, --description or --channels")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	group, err := resolveGroup(c, ref)
+	if err != nil {
+		return err
+	}
+
+	updated, err := c.UpdateUsergroup(group.ID, u)
+	if err != nil {
+		return client.WrapError(fmt.Sprintf("update user group %s", group.ID), err)
+	}
+
+	if output.IsJSON() {
+		return output.PrintJSON(updated)
+	}
+
+	output.Printf("Updated user group %s (%s)\n", groupLabel(updated), updated.ID)
+	return nil
+}
@@ -0,0 +1,71 @@
+package usergroups
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+)
+
+// NewCmd creates the usergroups command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "usergroups",
+		Aliases: []string{"usergroup", "ug"},
+		Short:   "Manage user groups (@team handles)",
+		Long: `List, create, update, disable and enable user groups, and manage their
+members.
+
+A group can be given by its ID (S...) or its @handle. User groups are only
+available on paid Slack plans.`,
+	}
+
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(newCreateCmd())
+	cmd.AddCommand(newUpdateCmd())
+	cmd.AddCommand(newDisableCmd())
+	cmd.AddCommand(newEnableCmd())
+	cmd.AddCommand(newMembersCmd())
+
+	return cmd
+}
+
+// resolveGroup finds a user group by ID or handle, with or without "@".
+// Disabled groups are included.
+func resolveGroup(c *client.Client, ref string) (*client.Usergroup, error) {
+	groups, err := c.ListUsergroups(true, true)
+	if err != nil {
+		return nil, client.WrapError("list user groups", err)
+	}
+	handle := strings.ToLower(strings.TrimPrefix(ref, "@"))
+	for i := range groups {
+		if groups[i].ID == ref || strings.ToLower(groups[i].Handle) == handle {
+			return &groups[i], nil
+		}
+	}
+	return nil, fmt.Errorf("user group %q not found: use a group ID or @handle (see 'slack-chat-api usergroups list')", ref)
+}
+
+// splitList splits comma-separated arguments
+func splitList(args ...string) []string {
+	var out []string
+	for _, arg := range args {
+		for _, v := range strings.Split(arg, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+// groupLabel returns "@handle", or the name if the group has no handle
+func groupLabel(g *client.Usergroup) string {
+	if g.Handle != "" {
+		return "@" + g.Handle
+	}
+	return g.Name
+}
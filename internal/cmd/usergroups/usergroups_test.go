@@ -0,0 +1,172 @@
+package usergroups
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func captureOutput(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	t.Cleanup(func() { output.Writer = orig })
+	return buf
+}
+
+func jsonOutput(t *testing.T) {
+	t.Helper()
+	orig := output.OutputFormat
+	output.OutputFormat = output.FormatJSON
+	t.Cleanup(func() { output.OutputFormat = orig })
+}
+
+func newServer(t *testing.T) (*slacktest.Server, *client.Client) {
+	t.Helper()
+	srv := slacktest.NewServer()
+	t.Cleanup(srv.Close)
+	for _, name := range []string{"alice", "bob", "carol"} {
+		u := slack.User{ID: "U0000" + strings.ToUpper(name), Name: name}
+		u.Profile.Email = name + "@example.com"
+		srv.AddUser(u)
+	}
+	return srv, client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+}
+
+func TestUsergroupsWorkflow_Slacktest(t *testing.T) {
+	srv, c := newServer(t)
+	buf := captureOutput(t)
+
+	require.NoError(t, runCreate("@oncall", &createOptions{name: "On-call", users: "@alice,bob@example.com"}, c))
+	assert.Contains(t, buf.String(), "Created user group @oncall")
+	assert.Contains(t, buf.String(), "2 member(s)")
+
+	groups, err := c.ListUsergroups(false, true)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	id := groups[0].ID
+	assert.Equal(t, "On-call", groups[0].Name)
+	assert.Equal(t, []string{"U0000ALICE", "U0000BOB"}, groups[0].Users)
+
+	buf.Reset()
+	require.NoError(t, runList(&listOptions{}, c))
+	assert.Contains(t, buf.String(), "@oncall")
+	assert.Contains(t, buf.String(), "On-call")
+
+	buf.Reset()
+	require.NoError(t, runGet("oncall", &getOptions{}, c))
+	assert.Contains(t, buf.String(), id)
+	assert.Contains(t, buf.String(), "U0000ALICE, U0000BOB")
+
+	desc := "Primary on-call"
+	require.NoError(t, runUpdate("@oncall", &updateOptions{update: client.UsergroupUpdate{Description: &desc}}, c))
+	g, _ := srv.Usergroup(id)
+	assert.Equal(t, "Primary on-call", g.Description)
+	assert.Error(t, runUpdate("@oncall", &updateOptions{}, c))
+
+	require.NoError(t, runDisable("@oncall", &disableOptions{}, c))
+	g, _ = srv.Usergroup(id)
+	assert.True(t, g.IsDisabled())
+
+	buf.Reset()
+	require.NoError(t, runList(&listOptions{includeDisabled: true}, c))
+	assert.Contains(t, buf.String(), "@oncall (disabled)")
+
+	require.NoError(t, runEnable(id, &enableOptions{}, c))
+	g, _ = srv.Usergroup(id)
+	assert.False(t, g.IsDisabled())
+
+	err = runGet("@nobody", &getOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestMembersChanges_Slacktest(t *testing.T) {
+	srv, c := newServer(t)
+	id := srv.AddUsergroup(slack.Usergroup{Name: "On-call", Handle: "oncall", Users: []string{"U0000ALICE"}})
+	buf := captureOutput(t)
+
+	require.NoError(t, runMembersSet("@oncall", []string{"@bob", "@carol"}, &membersSetOptions{force: true}, c))
+	assert.Contains(t, buf.String(), "Added: U0000BOB, U0000CAROL")
+	assert.Contains(t, buf.String(), "Removed: U0000ALICE")
+	g, _ := srv.Usergroup(id)
+	assert.Equal(t, []string{"U0000BOB", "U0000CAROL"}, g.Users)
+
+	require.NoError(t, runMembersAdd("@oncall", []string{"alice@example.com,@bob"}, &membersChangeOptions{}, c))
+	g, _ = srv.Usergroup(id)
+	assert.Equal(t, []string{"U0000BOB", "U0000CAROL", "U0000ALICE"}, g.Users)
+
+	require.NoError(t, runMembersRemove("@oncall", []string{"@bob"}, &membersChangeOptions{}, c))
+	g, _ = srv.Usergroup(id)
+	assert.Equal(t, []string{"U0000CAROL", "U0000ALICE"}, g.Users)
+
+	// Nothing to change makes no update call
+	calls := srv.Calls("usergroups.users.update")
+	buf.Reset()
+	require.NoError(t, runMembersAdd("@oncall", []string{"@carol"}, &membersChangeOptions{}, c))
+	assert.Contains(t, buf.String(), "No change")
+	assert.Equal(t, calls, srv.Calls("usergroups.users.update"))
+
+	err := runMembersRemove("@oncall", []string{"@carol", "@alice"}, &membersChangeOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "usergroups disable")
+
+	buf.Reset()
+	require.NoError(t, runMembersList("@oncall", &membersListOptions{names: true}, c))
+	assert.Contains(t, buf.String(), "carol@example.com")
+}
+
+func TestMembersSet_JSON(t *testing.T) {
+	srv, c := newServer(t)
+	srv.AddUsergroup(slack.Usergroup{Name: "On-call", Handle: "oncall", Users: []string{"U0000ALICE"}})
+	buf := captureOutput(t)
+	jsonOutput(t)
+
+	require.NoError(t, runMembersSet("@oncall", []string{"@alice", "@bob"}, &membersSetOptions{}, c))
+	var change membersChange
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &change))
+	assert.Equal(t, []string{"U0000BOB"}, change.Added)
+	assert.Empty(t, change.Removed)
+	assert.Equal(t, []string{"U0000ALICE", "U0000BOB"}, change.Users)
+}
+
+func TestMembersSet_ConfirmsRemoval(t *testing.T) {
+	srv, c := newServer(t)
+	id := srv.AddUsergroup(slack.Usergroup{Name: "On-call", Handle: "oncall", Users: []string{"U0000ALICE", "U0000BOB"}})
+	buf := captureOutput(t)
+
+	require.NoError(t, runMembersSet("@oncall", []string{"@carol"}, &membersSetOptions{stdin: strings.NewReader("n\n")}, c))
+	assert.Contains(t, buf.String(), "About to remove 2 member(s) from @oncall: U0000ALICE, U0000BOB")
+	assert.Contains(t, buf.String(), "Cancelled.")
+	g, _ := srv.Usergroup(id)
+	assert.Equal(t, []string{"U0000ALICE", "U0000BOB"}, g.Users)
+
+	// Only adding needs no confirmation
+	buf.Reset()
+	require.NoError(t, runMembersSet("@oncall", []string{"@alice", "@bob", "@carol"}, &membersSetOptions{}, c))
+	assert.NotContains(t, buf.String(), "Are you sure")
+
+	buf.Reset()
+	require.NoError(t, runMembersSet("@oncall", []string{"@carol"}, &membersSetOptions{stdin: strings.NewReader("y\n")}, c))
+	assert.Contains(t, buf.String(), "Removed: U0000ALICE, U0000BOB")
+	g, _ = srv.Usergroup(id)
+	assert.Equal(t, []string{"U0000CAROL"}, g.Users)
+
+	// With JSON output there is no prompt, so removing needs --force
+	jsonOutput(t)
+	buf.Reset()
+	err := runMembersSet("@oncall", []string{"@alice"}, &membersSetOptions{stdin: strings.NewReader("y\n")}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--force")
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,137 @@
+package users
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/timeparse"
+)
+
+// filterOptions are the user filters shared by list and export. Every
+// filter given must match.
+type filterOptions struct {
+	admins       bool
+	owners       bool
+	bots         bool
+	deleted      bool
+	guests       bool
+	tz           string
+	has2FA       *bool // nil means either
+	updatedSince string
+	now          func() time.Time // For testing
+}
+
+// addFilterFlags registers the filter flags. --has-2fa is bound to has2FA
+// rather than f, since it only filters when given: callers point f.has2FA
+// at it once the flag has changed.
+func addFilterFlags(cmd *cobra.Command, f *filterOptions, has2FA *bool) {
+	cmd.Flags().BoolVar(&f.admins, "admins", false, "Only workspace admins")
+	cmd.Flags().BoolVar(&f.owners, "owners", false, "Only workspace owners")
+	cmd.Flags().BoolVar(&f.bots, "bots", false, "Only bots")
+	cmd.Flags().BoolVar(&f.deleted, "deleted", false, "Only deactivated users")
+	cmd.Flags().BoolVar(&f.guests, "guests", false, "Only guests (multi- and single-channel)")
+	cmd.Flags().StringVar(&f.tz, "tz", "", "Only users in this time zone (e.g., Europe/Berlin, or Europe/ for a region)")
+	cmd.Flags().BoolVar(has2FA, "has-2fa", false, "Only users with (or with --has-2fa=false, without) two-factor auth; visible to admin tokens only")
+	cmd.Flags().StringVar(&f.updatedSince, "updated-since", "", "Only users whose profile changed since this time (e.g., 2026-01-01, \"30 days ago\")")
+}
+
+// filtering reports whether any filter is given, which needs every user
+func (f *filterOptions) filtering() bool {
+	return f.admins || f.owners || f.bots || f.deleted || f.guests ||
+		f.tz != "" || f.has2FA != nil || f.updatedSince != ""
+}
+
+// userFilter is a compiled set of user filters
+type userFilter struct {
+	admins       bool
+	owners       bool
+	bots         bool
+	deleted      bool
+	guests       bool
+	tz           string
+	has2FA       *bool
+	updatedSince time.Time
+}
+
+func (f *filterOptions) filter() (*userFilter, error) {
+	uf := &userFilter{
+		admins:  f.admins,
+		owners:  f.owners,
+		bots:    f.bots,
+		deleted: f.deleted,
+		guests:  f.guests,
+		tz:      f.tz,
+		has2FA:  f.has2FA,
+	}
+	if f.updatedSince != "" {
+		now := time.Now()
+		if f.now != nil {
+			now = f.now()
+		}
+		t, err := timeparse.Parse(f.updatedSince, now, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		uf.updatedSince = t
+	}
+	return uf, nil
+}
+
+func (f *userFilter) match(u client.User) bool {
+	switch {
+	case f.admins && !u.IsAdmin:
+		return false
+	case f.owners && !u.IsOwner:
+		return false
+	case f.bots && !u.IsBot:
+		return false
+	case f.deleted && !u.Deleted:
+		return false
+	case f.guests && !u.IsGuest():
+		return false
+	case f.tz != "" && !matchTZ(u.TZ, f.tz):
+		return false
+	case f.has2FA != nil && (u.Has2FA == nil || *u.Has2FA != *f.has2FA):
+		return false
+	case !f.updatedSince.IsZero() && !time.Unix(u.Updated, 0).After(f.updatedSince):
+		return false
+	}
+	return true
+}
+
+// matchTZ matches a time zone name, or a region such as "Europe/"
+func matchTZ(tz, want string) bool {
+	if strings.HasSuffix(want, "/") {
+		return strings.HasPrefix(strings.ToLower(tz), strings.ToLower(want))
+	}
+	return strings.EqualFold(tz, want)
+}
+
+// filterUsers returns the users that match. --has-2fa fails if no user's
+// 2FA status is visible, rather than silently matching nobody.
+func filterUsers(users []client.User, f *userFilter) ([]client.User, error) {
+	if f.has2FA != nil {
+		visible := false
+		for _, u := range users {
+			if u.Has2FA != nil {
+				visible = true
+				break
+			}
+		}
+		if !visible && len(users) > 0 {
+			return nil, fmt.Errorf("--has-2fa: two-factor status is not visible to this token (Slack only shows it to admins and owners)")
+		}
+	}
+
+	matched := make([]client.User, 0, len(users))
+	for _, u := range users {
+		if f.match(u) {
+			matched = append(matched, u)
+		}
+	}
+	return matched, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type getOptions struct{}
@@ -15,9 +16,10 @@ func newGetCmd() *cobra.Command {
 	opts := &getOptions{}
 
 	return &cobra.Command{
-		Use:   "get <user-id>",
-		Short: "Get user information",
-		Args:  cobra.ExactArgs(1),
+		Use:         "get <user-id>",
+		Short:       "Get user information",
+		Annotations: scopes.BotScopes("users:read", "users:read.email"),
+		Args:        cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runGet(args[0], opts, nil)
 		},
@@ -47,8 +49,24 @@ func runGet(userID string, opts *getOptions, c *client.Client) error {
 	output.KeyValue("Real Name", user.RealName)
 	output.KeyValue("Display Name", user.Profile.DisplayName)
 	output.KeyValue("Email", user.Profile.Email)
+	if user.Profile.Title != "" {
+		output.KeyValue("Title", user.Profile.Title)
+	}
+	if user.Profile.Phone != "" {
+		output.KeyValue("Phone", user.Profile.Phone)
+	}
+	if user.TZ != "" {
+		output.KeyValue("Time Zone", user.TZ)
+	}
 	output.KeyValue("Admin", user.IsAdmin)
+	output.KeyValue("Owner", user.IsOwner)
 	output.KeyValue("Bot", user.IsBot)
+	if user.IsGuest() {
+		output.KeyValue("Guest", true)
+	}
+	if user.Deleted {
+		output.KeyValue("Deactivated", true)
+	}
 	if user.Profile.StatusText != "" {
 		output.KeyValue("Status", fmt.Sprintf("%s %s", user.Profile.StatusEmoji, user.Profile.StatusText))
 	}
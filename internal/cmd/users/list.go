@@ -1,47 +1,104 @@
 package users
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/spf13/cobra"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type listOptions struct {
-	limit int
+	filterOptions
+	limit    int
+	presence bool
 }
 
 func newListCmd() *cobra.Command {
 	opts := &listOptions{}
+	var has2FA bool
 
 	cmd := &cobra.Command{
-		Use:   "list",
-		Short: "List all users",
+		Use:         "list",
+		Short:       "List all users",
+		Annotations: scopes.BotScopes("users:read", "users:read.email"),
+		Long: `List the workspace's users. Filters narrow the list; when several are
+given, a user must match all of them. Bots are left out of the table
+unless --bots is given.
+
+Examples:
+  slack-chat-api users list --admins
+  slack-chat-api users list --guests --updated-since "30 days ago"
+  slack-chat-api users list --tz America/ --limit 500
+  slack-chat-api users list --has-2fa=false -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("has-2fa") {
+				opts.has2FA = &has2FA
+			}
 			return runList(opts, nil)
 		},
 	}
 
 	cmd.Flags().IntVar(&opts.limit, "limit", 100, "Maximum users to return")
+	cmd.Flags().BoolVar(&opts.presence, "presence", false, "Add a presence column (one extra API call per user)")
+	addFilterFlags(cmd, &opts.filterOptions, &has2FA)
 
 	return cmd
 }
 
 func runList(opts *listOptions, c *client.Client) error {
+	filter, err := opts.filter()
+	if err != nil {
+		return err
+	}
+
 	if c == nil {
-		var err error
 		c, err = client.New()
 		if err != nil {
 			return err
 		}
 	}
 
-	users, err := c.ListUsers(opts.limit)
+	fetch := opts.limit
+	if opts.filtering() {
+		fetch = math.MaxInt32
+	}
+	users, err := c.ListUsers(fetch)
 	if err != nil {
 		return err
 	}
 
+	if opts.filtering() {
+		if users, err = filterUsers(users, filter); err != nil {
+			return err
+		}
+		if len(users) > opts.limit {
+			users = users[:opts.limit]
+		}
+	}
+
+	var presence map[string]string
+	if opts.presence {
+		if presence, err = getPresence(c, users); err != nil {
+			return err
+		}
+	}
+
 	if output.IsJSON() {
+		if opts.presence {
+			type userWithPresence struct {
+				client.User
+				Presence string `json:"presence"`
+			}
+			out := make([]userWithPresence, len(users))
+			for i, u := range users {
+				out[i] = userWithPresence{User: u, Presence: presence[u.ID]}
+			}
+			return output.PrintJSON(out)
+		}
 		return output.PrintJSON(users)
 	}
 
@@ -51,14 +108,38 @@ func runList(opts *listOptions, c *client.Client) error {
 	}
 
 	headers := []string{"ID", "USERNAME", "REAL NAME", "EMAIL"}
+	if opts.presence {
+		headers = append(headers, "PRESENCE")
+	}
 	rows := make([][]string, 0, len(users))
 	for _, u := range users {
-		if u.IsBot {
+		if u.IsBot && !opts.bots {
 			continue
 		}
-		rows = append(rows, []string{u.ID, u.Name, u.RealName, u.Profile.Email})
+		row := []string{u.ID, u.Name, u.RealName, u.Profile.Email}
+		if opts.presence {
+			row = append(row, presence[u.ID])
+		}
+		rows = append(rows, row)
 	}
 	output.Table(headers, rows)
 
 	return nil
 }
+
+// getPresence looks up the presence of each human user. Slack has no bulk
+// presence method, so this costs one call per user.
+func getPresence(c *client.Client, users []client.User) (map[string]string, error) {
+	presence := make(map[string]string, len(users))
+	for _, u := range users {
+		if u.IsBot {
+			continue
+		}
+		p, err := c.GetPresence(u.ID)
+		if err != nil {
+			return nil, client.WrapError(fmt.Sprintf("get presence of %s", u.ID), err)
+		}
+		presence[u.ID] = p.Presence
+	}
+	return presence, nil
+}
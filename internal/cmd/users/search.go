@@ -8,6 +8,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 // ValidFields contains the allowed field filter values
@@ -23,8 +24,9 @@ func newSearchCmd() *cobra.Command {
 	opts := &searchOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "search <query>",
-		Short: "Search users by name, email, or display name",
+		Use:         "search <query>",
+		Short:       "Search users by name, email, or display name",
+		Annotations: scopes.BotScopes("users:read", "users:read.email"),
 		Long: `Search users by name, email, or display name.
 
 Uses the users.list API with local filtering. Requires a bot token (xoxb-*)
@@ -15,6 +15,7 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(newListCmd())
 	cmd.AddCommand(newGetCmd())
 	cmd.AddCommand(newSearchCmd())
+	cmd.AddCommand(newExportCmd())
 
 	return cmd
 }
@@ -1,15 +1,23 @@
 package users
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
 )
 
 func TestRunList_Success(t *testing.T) {
@@ -190,3 +198,191 @@ func TestRunGet_NoStatus(t *testing.T) {
 	err := runGet("U001", opts, c)
 	require.NoError(t, err)
 }
+
+func TestRunList_Presence_Slacktest(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	require.NoError(t, srv.Client(slacktest.UserToken).SetPresence("away"))
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runList(&listOptions{limit: 100, presence: true}, c))
+	assert.Contains(t, buf.String(), "PRESENCE")
+	assert.Contains(t, buf.String(), "away")
+	assert.Equal(t, 1, srv.Calls("users.getPresence"), "bots are skipped")
+}
+
+// directory is a slacktest workspace with users for the list filters
+func directory(t *testing.T) (*slacktest.Server, *client.Client) {
+	t.Helper()
+	srv := slacktest.NewServer()
+	t.Cleanup(srv.Close)
+
+	yes, no := true, false
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	ann := slack.User{ID: "U0ANN", Name: "ann", IsAdmin: true, IsOwner: true, TZ: "Europe/Berlin", Has2FA: &yes, Updated: base + 86400}
+	ann.Profile.Email = "ann@example.com"
+	ann.Profile.Title = "CEO"
+	srv.AddUser(ann)
+	srv.AddUser(slack.User{ID: "U0BEN", Name: "ben", IsAdmin: true, TZ: "America/New_York", Has2FA: &no, Updated: base - 86400})
+	srv.AddUser(slack.User{ID: "U0CAT", Name: "cat", IsRestricted: true, TZ: "Europe/Paris", Has2FA: &no, Updated: base + 2*86400})
+	srv.AddUser(slack.User{ID: "U0DAN", Name: "dan", Deleted: true, Updated: base - 2*86400})
+
+	return srv, client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+}
+
+func listIDs(t *testing.T, opts *listOptions, c *client.Client) []string {
+	t.Helper()
+	orig := output.OutputFormat
+	output.OutputFormat = output.FormatJSON
+	defer func() { output.OutputFormat = orig }()
+	buf := &bytes.Buffer{}
+	origW := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = origW }()
+
+	require.NoError(t, runList(opts, c))
+	var users []client.User
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &users))
+	ids := []string{}
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	return ids
+}
+
+func TestRunList_Filters(t *testing.T) {
+	_, c := directory(t)
+	yes, no := true, false
+	now := func() time.Time { return time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name string
+		opts filterOptions
+		want []string
+	}{
+		{"admins", filterOptions{admins: true}, []string{"U0ANN", "U0BEN"}},
+		{"owners", filterOptions{owners: true}, []string{"U0ANN"}},
+		{"bots", filterOptions{bots: true}, []string{slacktest.BotUserID}},
+		{"deleted", filterOptions{deleted: true}, []string{"U0DAN"}},
+		{"guests", filterOptions{guests: true}, []string{"U0CAT"}},
+		{"tz", filterOptions{tz: "europe/berlin"}, []string{"U0ANN"}},
+		{"tz region", filterOptions{tz: "Europe/"}, []string{"U0ANN", "U0CAT"}},
+		{"has 2fa", filterOptions{has2FA: &yes}, []string{"U0ANN"}},
+		{"no 2fa", filterOptions{has2FA: &no}, []string{"U0BEN", "U0CAT"}},
+		{"updated since", filterOptions{updatedSince: "2026-01-01", now: now}, []string{"U0ANN", "U0CAT"}},
+		{"combined", filterOptions{admins: true, tz: "Europe/"}, []string{"U0ANN"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := listIDs(t, &listOptions{filterOptions: tt.opts, limit: 100}, c)
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestRunList_FilterLimit(t *testing.T) {
+	srv, c := directory(t)
+	ids := listIDs(t, &listOptions{filterOptions: filterOptions{admins: true}, limit: 1}, c)
+	assert.Equal(t, []string{"U0ANN"}, ids)
+	assert.Equal(t, 1, srv.Calls("users.list"))
+}
+
+func TestRunList_Has2FANotVisible(t *testing.T) {
+	srv := slacktest.NewServer()
+	defer srv.Close()
+	c := client.NewWithConfig(srv.URL, slacktest.BotToken, nil)
+
+	yes := true
+	err := runList(&listOptions{filterOptions: filterOptions{has2FA: &yes}, limit: 100}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not visible")
+}
+
+func TestRunList_BadUpdatedSince(t *testing.T) {
+	err := runList(&listOptions{filterOptions: filterOptions{updatedSince: "whenever"}, limit: 100}, nil)
+	require.Error(t, err)
+}
+
+func TestRunExport_CSV(t *testing.T) {
+	_, c := directory(t)
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	require.NoError(t, runExport(&exportOptions{}, c))
+
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, exportColumns, records[0])
+	require.Len(t, records, 7, "header, two default users and four added, bots and deactivated included")
+	ann := records[3]
+	assert.Equal(t, []string{
+		"U0ANN", "ann", "", "", "ann@example.com", "CEO", "", "Europe/Berlin",
+		"true", "true", "false", "false", "false", "false", "true", "2026-01-02T00:00:00Z",
+	}, ann)
+	assert.Equal(t, "", records[1][14], "2FA status not visible")
+}
+
+func TestRunExport_CSVFormulas(t *testing.T) {
+	srv, c := directory(t)
+	eve := slack.User{ID: "U0EVE", Name: "eve", RealName: "@eve"}
+	eve.Profile.Title = `=HYPERLINK("https://evil.example.com","click")`
+	eve.Profile.Phone = "+1 555 0100"
+	eve.Profile.DisplayName = "-eve"
+	srv.AddUser(eve)
+
+	file := filepath.Join(t.TempDir(), "users.csv")
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+	require.NoError(t, runExport(&exportOptions{file: file}, c))
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	require.NoError(t, err)
+	row := records[len(records)-1]
+	assert.Equal(t, "U0EVE", row[0])
+	assert.Equal(t, "'@eve", row[2])
+	assert.Equal(t, "'-eve", row[3])
+	assert.Equal(t, `'=HYPERLINK("https://evil.example.com","click")`, row[5])
+	assert.Equal(t, "'+1 555 0100", row[6])
+}
+
+func TestRunExport_JSONFile(t *testing.T) {
+	_, c := directory(t)
+	buf := &bytes.Buffer{}
+	orig := output.Writer
+	output.Writer = buf
+	defer func() { output.Writer = orig }()
+
+	file := filepath.Join(t.TempDir(), "guests.json")
+	opts := &exportOptions{filterOptions: filterOptions{guests: true}, format: "json", file: file}
+	require.NoError(t, runExport(opts, c))
+	assert.Contains(t, buf.String(), "Exported 1 user(s) to "+file)
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var users []client.User
+	require.NoError(t, json.Unmarshal(data, &users))
+	require.Len(t, users, 1)
+	assert.Equal(t, "U0CAT", users[0].ID)
+	assert.True(t, users[0].IsGuest())
+}
+
+func TestRunExport_InvalidFormat(t *testing.T) {
+	err := runExport(&exportOptions{format: "xlsx"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "csv or json")
+}
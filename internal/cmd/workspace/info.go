@@ -7,6 +7,7 @@ import (
 
 	"github.com/piekstra/slack-chat-api/internal/client"
 	"github.com/piekstra/slack-chat-api/internal/output"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
 )
 
 type infoOptions struct{}
@@ -15,8 +16,9 @@ func newInfoCmd() *cobra.Command {
 	opts := &infoOptions{}
 
 	return &cobra.Command{
-		Use:   "info",
-		Short: "Get workspace/team information",
+		Use:         "info",
+		Short:       "Get workspace/team information",
+		Annotations: scopes.BotScopes("team:read"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runInfo(opts, nil)
 		},
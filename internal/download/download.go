@@ -0,0 +1,356 @@
+// Package download fetches Slack files into a local directory. Downloads run
+// concurrently, resume from partial ".part" files, skip files that are
+// already present, and never overwrite a different local file with the same
+// name.
+//
+// Each directory keeps a manifest (.slack-downloads.json) recording which
+// Slack file each local file came from, with its size and SHA-256 checksum,
+// so repeated runs only fetch what is new.
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/internal/output"
+)
+
+// ManifestFile is the name of the manifest kept in each download directory
+const ManifestFile = ".slack-downloads.json"
+
+// DefaultConcurrency is how many files are downloaded at once by default
+const DefaultConcurrency = 4
+
+// Status is the outcome of one download
+type Status string
+
+const (
+	Downloaded Status = "downloaded"
+	Resumed    Status = "resumed"
+	Skipped    Status = "skipped"
+	Failed     Status = "failed"
+)
+
+// Item is a Slack file to download
+type Item struct {
+	ID   string
+	Name string
+	URL  string
+	// Size is the expected size in bytes; 0 if unknown
+	Size int64
+}
+
+// Options controls a download run
+type Options struct {
+	Dir         string
+	Concurrency int
+	// Force re-downloads files that are already present
+	Force bool
+	// Progress receives progress updates; nil disables them
+	Progress io.Writer
+}
+
+// Result reports what happened to one item
+type Result struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Path   string `json:"path,omitempty"`
+	Size   int64  `json:"size"`
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// manifestEntry records a completed download
+type manifestEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// job is an item with its planned destination
+type job struct {
+	Item
+	path string
+	skip bool
+}
+
+// Run downloads items into opts.Dir and returns one result per file, in the
+// order given; a file listed more than once is downloaded once. It returns an error only if the directory or manifest cannot
+// be used; failed downloads are reported in the results.
+func Run(c *client.Client, items []Item, opts Options) ([]Result, error) {
+	if err := os.MkdirAll(opts.Dir, 0755); err != nil {
+		return nil, err
+	}
+	manifest, err := readManifest(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := plan(items, manifest, opts)
+	results := make([]Result, len(jobs))
+	progress := newProgress(opts.Progress, len(jobs))
+
+	var mu sync.Mutex
+	work := make(chan int)
+	var wg sync.WaitGroup
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultConcurrency
+	}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				r, entry := fetch(c, jobs[i], progress)
+				results[i] = r
+				progress.done(r)
+				if entry != nil {
+					mu.Lock()
+					manifest[jobs[i].ID] = *entry
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for i := range jobs {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	progress.finish()
+
+	return results, writeManifest(opts.Dir, manifest)
+}
+
+// plan picks a destination for every item before any download starts, so
+// concurrent downloads never race for a file name. Repeated file IDs, as when
+// a file is shared in several messages, are planned once.
+func plan(items []Item, manifest map[string]manifestEntry, opts Options) []job {
+	claimed := make(map[string]string) // relative path -> file ID
+	for id, e := range manifest {
+		claimed[e.Path] = id
+	}
+
+	planned := make(map[string]bool, len(items))
+	jobs := make([]job, 0, len(items))
+	for _, it := range items {
+		if planned[it.ID] {
+			continue
+		}
+		planned[it.ID] = true
+		j := job{Item: it}
+
+		modified := ""
+		if e, ok := manifest[it.ID]; ok && !pathTaken(claimed, e.Path, it.ID) {
+			j.path = filepath.Join(opts.Dir, e.Path)
+			if !opts.Force && unchanged(j.path, e) {
+				j.skip = true
+				jobs = append(jobs, j)
+				continue
+			}
+			if opts.Force || !exists(j.path) {
+				claimed[e.Path] = it.ID
+				jobs = append(jobs, j)
+				continue
+			}
+			// The local copy was changed since it was downloaded; keep it
+			modified = e.Path
+		}
+
+		name := safeName(it.Name, it.ID)
+		if name == modified || pathTaken(claimed, name, it.ID) || (exists(filepath.Join(opts.Dir, name)) && !sameSize(filepath.Join(opts.Dir, name), it.Size)) {
+			name = withID(name, it.ID)
+		}
+		claimed[name] = it.ID
+		j.path = filepath.Join(opts.Dir, name)
+		// A file of the right size left by an earlier run or another tool is
+		// taken as already downloaded
+		j.skip = !opts.Force && exists(j.path) && sameSize(j.path, it.Size)
+		jobs = append(jobs, j)
+	}
+	return jobs
+}
+
+// fetch downloads one job, resuming from a .part file if one exists
+func fetch(c *client.Client, j job, p *progress) (Result, *manifestEntry) {
+	r := Result{ID: j.ID, Name: j.Name, Path: j.path}
+	if j.skip {
+		sum, size, err := checksum(j.path)
+		if err != nil {
+			return failed(r, err), nil
+		}
+		r.Size, r.Status = size, Skipped
+		return r, &manifestEntry{Path: filepath.Base(j.path), Size: size, SHA256: sum}
+	}
+	if j.URL == "" {
+		return failed(r, errors.New("file has no downloadable content")), nil
+	}
+
+	part := j.path + ".part"
+	var offset int64
+	if info, err := os.Stat(part); err == nil {
+		offset = info.Size()
+	}
+
+	f, err := os.OpenFile(part, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return failed(r, err), nil
+	}
+	_, err = c.DownloadFileRange(j.URL, offset, p.counter(f))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return failed(r, err), nil
+	}
+
+	sum, size, err := checksum(part)
+	if err != nil {
+		return failed(r, err), nil
+	}
+	if j.Size > 0 && size != j.Size {
+		_ = os.Remove(part)
+		return failed(r, fmt.Errorf("expected %d bytes, got %d", j.Size, size)), nil
+	}
+	if err := os.Rename(part, j.path); err != nil {
+		return failed(r, err), nil
+	}
+
+	r.Size, r.Status = size, Downloaded
+	if offset > 0 {
+		r.Status = Resumed
+	}
+	return r, &manifestEntry{Path: filepath.Base(j.path), Size: size, SHA256: sum}
+}
+
+func failed(r Result, err error) Result {
+	r.Status = Failed
+	r.Error = err.Error()
+	return r
+}
+
+// safeName turns a Slack file name into a local one that cannot escape the
+// download directory
+func safeName(name, id string) string {
+	name = filepath.Base(filepath.Clean("/" + strings.ReplaceAll(name, "\\", "/")))
+	switch {
+	case name == "/" || name == ".":
+		return id
+	case strings.HasPrefix(name, "."):
+		// Keep downloads visible and clear of the manifest
+		return id + name
+	}
+	return name
+}
+
+// withID disambiguates a file name with the file's ID: report (F0123).pdf
+func withID(name, id string) string {
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + " (" + id + ")" + ext
+}
+
+func pathTaken(claimed map[string]string, path, id string) bool {
+	owner, ok := claimed[path]
+	return ok && owner != id
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func sameSize(path string, size int64) bool {
+	info, err := os.Stat(path)
+	return err == nil && size > 0 && info.Size() == size
+}
+
+// unchanged reports whether path still holds the download recorded in e
+func unchanged(path string, e manifestEntry) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() != e.Size {
+		return false
+	}
+	sum, _, err := checksum(path)
+	return err == nil && sum == e.SHA256
+}
+
+func checksum(path string) (sum string, size int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	size, err = io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+func readManifest(dir string) (map[string]manifestEntry, error) {
+	manifest := make(map[string]manifestEntry)
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", ManifestFile, err)
+	}
+	return manifest, nil
+}
+
+func writeManifest(dir string, manifest map[string]manifestEntry) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0644)
+}
+
+// FileItem converts a file to a download item
+func FileItem(f *client.File) Item {
+	u := f.URLPrivateDownload
+	if u == "" {
+		u = f.URLPrivate
+	}
+	return Item{ID: f.ID, Name: f.Name, URL: u, Size: f.Size}
+}
+
+// PrintResults reports a download run, failing if any file failed
+func PrintResults(results []Result) error {
+	counts := make(map[Status]int)
+	var size int64
+	for _, r := range results {
+		counts[r.Status]++
+		size += r.Size
+	}
+
+	if output.IsJSON() {
+		if err := output.PrintJSON(results); err != nil {
+			return err
+		}
+	} else {
+		output.Printf("%d downloaded, %d resumed, %d skipped, %d failed (%s)\n",
+			counts[Downloaded], counts[Resumed], counts[Skipped], counts[Failed], output.FormatSize(size))
+	}
+
+	if counts[Failed] > 0 {
+		return fmt.Errorf("%d of %d downloads failed", counts[Failed], len(results))
+	}
+	return nil
+}
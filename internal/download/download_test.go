@@ -0,0 +1,171 @@
+package download
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/pkg/slack/slacktest"
+)
+
+func newServer(t *testing.T, files ...slacktest.File) (*slacktest.Server, *client.Client) {
+	t.Helper()
+	srv := slacktest.NewServer()
+	t.Cleanup(srv.Close)
+	for _, f := range files {
+		srv.AddFile(f)
+	}
+	return srv, client.NewWithConfig(srv.URL, slacktest.UserToken, nil)
+}
+
+func items(t *testing.T, c *client.Client, ids ...string) []Item {
+	t.Helper()
+	var out []Item
+	for _, id := range ids {
+		f, err := c.GetFileInfo(id)
+		require.NoError(t, err)
+		out = append(out, FileItem(f))
+	}
+	return out
+}
+
+func statuses(results []Result) []Status {
+	var out []Status
+	for _, r := range results {
+		out = append(out, r.Status)
+	}
+	return out
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestRun_DownloadsThenSkips(t *testing.T) {
+	srv, c := newServer(t,
+		slacktest.File{ID: "F0000001", Name: "a.txt", User: slacktest.UserID, Content: []byte("alpha")},
+		slacktest.File{ID: "F0000002", Name: "b.txt", User: slacktest.UserID, Content: []byte("bravo")},
+		slacktest.File{ID: "F0000003", Name: "c.txt", User: slacktest.UserID, Content: []byte("charlie")},
+	)
+	dir := t.TempDir()
+	all := items(t, c, "F0000001", "F0000002", "F0000003")
+
+	var progress bytes.Buffer
+	results, err := Run(c, all, Options{Dir: dir, Concurrency: 2, Progress: &progress})
+	require.NoError(t, err)
+	assert.Equal(t, []Status{Downloaded, Downloaded, Downloaded}, statuses(results))
+	assert.Equal(t, "charlie", readFile(t, filepath.Join(dir, "c.txt")))
+	assert.Contains(t, progress.String(), "[3/3]")
+	assert.FileExists(t, filepath.Join(dir, ManifestFile))
+
+	before := srv.Calls("download")
+	results, err = Run(c, all, Options{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []Status{Skipped, Skipped, Skipped}, statuses(results))
+	assert.Equal(t, before, srv.Calls("download"), "skipped files are not fetched")
+
+	results, err = Run(c, all[:1], Options{Dir: dir, Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, []Status{Downloaded}, statuses(results))
+}
+
+func TestRun_NameCollisions(t *testing.T) {
+	_, c := newServer(t,
+		slacktest.File{ID: "F0000001", Name: "report.pdf", User: slacktest.UserID, Content: []byte("first")},
+		slacktest.File{ID: "F0000002", Name: "report.pdf", User: slacktest.UserID, Content: []byte("second")},
+		slacktest.File{ID: "F0000003", Name: "notes.txt", User: slacktest.UserID, Content: []byte("from slack")},
+	)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("mine"), 0644))
+
+	results, err := Run(c, items(t, c, "F0000001", "F0000002", "F0000003"), Options{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []Status{Downloaded, Downloaded, Downloaded}, statuses(results))
+	assert.Equal(t, "first", readFile(t, filepath.Join(dir, "report.pdf")))
+	assert.Equal(t, "second", readFile(t, filepath.Join(dir, "report (F0000002).pdf")))
+	assert.Equal(t, "mine", readFile(t, filepath.Join(dir, "notes.txt")), "unrelated local files are kept")
+	assert.Equal(t, "from slack", readFile(t, filepath.Join(dir, "notes (F0000003).txt")))
+
+	// A second run maps each file back to where it was saved
+	results, err = Run(c, items(t, c, "F0000002", "F0000001"), Options{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []Status{Skipped, Skipped}, statuses(results))
+	assert.Equal(t, filepath.Join(dir, "report (F0000002).pdf"), results[0].Path)
+}
+
+func TestRun_DuplicateItems(t *testing.T) {
+	srv, c := newServer(t,
+		slacktest.File{ID: "F0000001", Name: "a.txt", User: slacktest.UserID, Content: []byte("alpha")},
+		slacktest.File{ID: "F0000002", Name: "b.txt", User: slacktest.UserID, Content: []byte("bravo")},
+	)
+	dir := t.TempDir()
+
+	// The same file shared in two messages
+	results, err := Run(c, items(t, c, "F0000001", "F0000002", "F0000001"), Options{Dir: dir, Concurrency: 3})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, []Status{Downloaded, Downloaded}, statuses(results))
+	assert.Equal(t, 2, srv.Calls("download"))
+	assert.Equal(t, "alpha", readFile(t, filepath.Join(dir, "a.txt")))
+	assert.NoFileExists(t, filepath.Join(dir, "a (F0000001).txt"))
+}
+
+func TestRun_ModifiedLocalCopyIsKept(t *testing.T) {
+	_, c := newServer(t, slacktest.File{ID: "F0000001", Name: "plan.txt", User: slacktest.UserID, Content: []byte("v1")})
+	dir := t.TempDir()
+	all := items(t, c, "F0000001")
+
+	_, err := Run(c, all, Options{Dir: dir})
+	require.NoError(t, err)
+	// Same size, different content: only the checksum tells them apart
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plan.txt"), []byte("v2"), 0644))
+
+	results, err := Run(c, all, Options{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []Status{Downloaded}, statuses(results))
+	assert.Equal(t, "v2", readFile(t, filepath.Join(dir, "plan.txt")))
+	assert.Equal(t, "v1", readFile(t, filepath.Join(dir, "plan (F0000001).txt")))
+}
+
+func TestRun_ResumesPartialDownload(t *testing.T) {
+	_, c := newServer(t, slacktest.File{ID: "F0000001", Name: "big.bin", User: slacktest.UserID, Content: []byte("0123456789")})
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "big.bin.part"), []byte("01234"), 0644))
+
+	results, err := Run(c, items(t, c, "F0000001"), Options{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, []Status{Resumed}, statuses(results))
+	assert.Equal(t, "0123456789", readFile(t, filepath.Join(dir, "big.bin")))
+	assert.NoFileExists(t, filepath.Join(dir, "big.bin.part"))
+}
+
+func TestRun_ReportsFailures(t *testing.T) {
+	srv, c := newServer(t, slacktest.File{ID: "F0000001", Name: "a.txt", User: slacktest.UserID, Content: []byte("a")})
+	all := items(t, c, "F0000001")
+	srv.AddToken("xoxp-nofiles", slacktest.UserID, false, "search:read")
+
+	results, err := Run(client.NewWithConfig(srv.URL, "xoxp-nofiles", nil), all, Options{Dir: t.TempDir()})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, Failed, results[0].Status)
+	assert.Contains(t, results[0].Error, "files:read")
+
+	err = PrintResults(results)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 1 downloads failed")
+}
+
+func TestSafeName(t *testing.T) {
+	assert.Equal(t, "passwd", safeName("../../etc/passwd", "F1"))
+	assert.Equal(t, "evil.txt", safeName(`..\..\evil.txt`, "F1"))
+	assert.Equal(t, "F1.bashrc", safeName(".bashrc", "F1"))
+	assert.Equal(t, "F1", safeName("", "F1"))
+}
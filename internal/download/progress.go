@@ -0,0 +1,107 @@
+package download
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/piekstra/slack-chat-api/internal/output"
+)
+
+// progress reports each finished file and, on a terminal, a running byte
+// count while downloads are in flight
+type progress struct {
+	w        io.Writer
+	total    int
+	finished int
+	bytes    atomic.Int64
+	live     bool
+	mu       sync.Mutex
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+func newProgress(w io.Writer, total int) *progress {
+	p := &progress{w: w, total: total}
+	if w == nil {
+		return p
+	}
+	if f, ok := w.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			p.live = true
+			p.stop = make(chan struct{})
+			p.stopped = make(chan struct{})
+			go p.tick()
+		}
+	}
+	return p
+}
+
+// counter wraps a destination so its bytes count toward the running total
+func (p *progress) counter(w io.Writer) io.Writer {
+	return &countingWriter{w: w, n: &p.bytes}
+}
+
+// done reports a finished file
+func (p *progress) done(r Result) {
+	if p.w == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finished++
+	p.clearLine()
+	switch r.Status {
+	case Failed:
+		_, _ = fmt.Fprintf(p.w, "[%d/%d] failed %s: %s\n", p.finished, p.total, r.Name, r.Error)
+	default:
+		_, _ = fmt.Fprintf(p.w, "[%d/%d] %s %s (%s)\n", p.finished, p.total, r.Status, r.Path, output.FormatSize(r.Size))
+	}
+}
+
+func (p *progress) finish() {
+	if p.live {
+		close(p.stop)
+		<-p.stopped
+		p.mu.Lock()
+		p.clearLine()
+		p.mu.Unlock()
+	}
+}
+
+func (p *progress) tick() {
+	defer close(p.stopped)
+	t := time.NewTicker(200 * time.Millisecond)
+	defer t.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-t.C:
+			p.mu.Lock()
+			_, _ = fmt.Fprintf(p.w, "\r\033[K%d/%d files, %s", p.finished, p.total, output.FormatSize(p.bytes.Load()))
+			p.mu.Unlock()
+		}
+	}
+}
+
+// clearLine erases the running count before a status line is printed
+func (p *progress) clearLine() {
+	if p.live {
+		_, _ = fmt.Fprint(p.w, "\r\033[K")
+	}
+}
+
+type countingWriter struct {
+	w io.Writer
+	n *atomic.Int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n.Add(int64(n))
+	return n, err
+}
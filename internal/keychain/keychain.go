@@ -6,15 +6,34 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
 	serviceName  = "slack-chat-api"
 	apiTokenKey  = "api_token"
 	userTokenKey = "user_token"
+
+	apiTokenScopesKey  = "api_token_scopes"
+	userTokenScopesKey = "user_token_scopes"
+
+	apiRefreshTokenKey   = "api_refresh_token"
+	apiTokenExpiresKey   = "api_token_expires_at"
+	userRefreshTokenKey  = "user_refresh_token"
+	userTokenExpiresKey  = "user_token_expires_at"
+	oauthClientIDKey     = "client_id"
+	oauthClientSecretKey = "client_secret"
 )
 
+// TokenRotation holds the refresh token and expiry Slack issues for an
+// access token when token rotation is enabled for the app.
+type TokenRotation struct {
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
 // GetAPIToken retrieves the Slack API token from keychain/config or environment
 func GetAPIToken() (string, error) {
 	// Try secure storage first (keychain on macOS, config file on Linux)
@@ -39,9 +58,47 @@ func SetAPIToken(token string) error {
 
 // DeleteAPIToken removes the Slack API token
 func DeleteAPIToken() error {
+	// Scopes and rotation data are only recorded by 'config login'; ignore missing entries
+	DeleteAPITokenScopes()
+	DeleteAPITokenRotation()
 	return deleteCredential(apiTokenKey)
 }
 
+// SetAPITokenRotation stores the refresh token and expiry for the bot token
+func SetAPITokenRotation(r TokenRotation) error {
+	return setRotation(apiRefreshTokenKey, apiTokenExpiresKey, r)
+}
+
+// GetAPITokenRotation returns rotation data for the bot token, or nil if it doesn't rotate
+func GetAPITokenRotation() *TokenRotation {
+	return getRotation(apiRefreshTokenKey, apiTokenExpiresKey)
+}
+
+// DeleteAPITokenRotation removes any rotation data for the bot token
+func DeleteAPITokenRotation() {
+	_ = deleteCredential(apiRefreshTokenKey)
+	_ = deleteCredential(apiTokenExpiresKey)
+}
+
+// SetAPITokenScopes records the comma-separated scopes granted to the bot token
+func SetAPITokenScopes(scopes string) error {
+	return setCredential(apiTokenScopesKey, scopes)
+}
+
+// GetAPITokenScopes returns the recorded bot token scopes, or "" if unknown
+func GetAPITokenScopes() string {
+	scopes, err := getCredential(apiTokenScopesKey)
+	if err != nil {
+		return ""
+	}
+	return scopes
+}
+
+// DeleteAPITokenScopes removes the recorded bot token scopes
+func DeleteAPITokenScopes() {
+	_ = deleteCredential(apiTokenScopesKey)
+}
+
 // IsSecureStorage returns true if using secure storage (macOS Keychain)
 func IsSecureStorage() bool {
 	return runtime.GOOS == "darwin"
@@ -92,9 +149,91 @@ func SetUserToken(token string) error {
 
 // DeleteUserToken removes the stored user token
 func DeleteUserToken() error {
+	DeleteUserTokenScopes()
+	DeleteUserTokenRotation()
 	return deleteCredential(userTokenKey)
 }
 
+// SetUserTokenRotation stores the refresh token and expiry for the user token
+func SetUserTokenRotation(r TokenRotation) error {
+	return setRotation(userRefreshTokenKey, userTokenExpiresKey, r)
+}
+
+// GetUserTokenRotation returns rotation data for the user token, or nil if it doesn't rotate
+func GetUserTokenRotation() *TokenRotation {
+	return getRotation(userRefreshTokenKey, userTokenExpiresKey)
+}
+
+// DeleteUserTokenRotation removes any rotation data for the user token
+func DeleteUserTokenRotation() {
+	_ = deleteCredential(userRefreshTokenKey)
+	_ = deleteCredential(userTokenExpiresKey)
+}
+
+// --- OAuth client credentials (for token refresh) ---
+
+// SetOAuthClient stores the app's client ID and secret
+func SetOAuthClient(clientID, clientSecret string) error {
+	if err := setCredential(oauthClientIDKey, clientID); err != nil {
+		return err
+	}
+	return setCredential(oauthClientSecretKey, clientSecret)
+}
+
+// GetOAuthClient returns the stored client ID and secret
+func GetOAuthClient() (clientID, clientSecret string, err error) {
+	clientID, err = getCredential(oauthClientIDKey)
+	if err != nil || clientID == "" {
+		return "", "", fmt.Errorf("no OAuth client credentials stored - run 'slack-chat-api config login'")
+	}
+	clientSecret, err = getCredential(oauthClientSecretKey)
+	if err != nil || clientSecret == "" {
+		return "", "", fmt.Errorf("no OAuth client credentials stored - run 'slack-chat-api config login'")
+	}
+	return clientID, clientSecret, nil
+}
+
+func setRotation(refreshKey, expiresKey string, r TokenRotation) error {
+	if err := setCredential(refreshKey, r.RefreshToken); err != nil {
+		return err
+	}
+	return setCredential(expiresKey, strconv.FormatInt(r.ExpiresAt.Unix(), 10))
+}
+
+func getRotation(refreshKey, expiresKey string) *TokenRotation {
+	refresh, err := getCredential(refreshKey)
+	if err != nil || refresh == "" {
+		return nil
+	}
+
+	r := &TokenRotation{RefreshToken: refresh}
+	if expires, err := getCredential(expiresKey); err == nil {
+		if sec, err := strconv.ParseInt(expires, 10, 64); err == nil {
+			r.ExpiresAt = time.Unix(sec, 0)
+		}
+	}
+	return r
+}
+
+// SetUserTokenScopes records the comma-separated scopes granted to the user token
+func SetUserTokenScopes(scopes string) error {
+	return setCredential(userTokenScopesKey, scopes)
+}
+
+// GetUserTokenScopes returns the recorded user token scopes, or "" if unknown
+func GetUserTokenScopes() string {
+	scopes, err := getCredential(userTokenScopesKey)
+	if err != nil {
+		return ""
+	}
+	return scopes
+}
+
+// DeleteUserTokenScopes removes the recorded user token scopes
+func DeleteUserTokenScopes() {
+	_ = deleteCredential(userTokenScopesKey)
+}
+
 // HasStoredUserToken returns true if a user token is stored in keychain/config (not env var)
 func HasStoredUserToken() bool {
 	token, err := getCredential(userTokenKey)
@@ -188,6 +327,11 @@ func deleteFromKeychain(account string) error {
 
 // --- Config File (Linux fallback) ---
 
+// ConfigDir returns the directory holding the CLI's config files
+func ConfigDir() string {
+	return getConfigDir()
+}
+
 func getConfigDir() string {
 	if xdgConfig := os.Getenv("XDG_CONFIG_HOME"); xdgConfig != "" {
 		return filepath.Join(xdgConfig, "slack-chat-api")
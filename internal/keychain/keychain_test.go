@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 )
 
 func TestGetAPIToken_FromEnvVar(t *testing.T) {
@@ -665,3 +666,54 @@ func TestBothTokenTypes_Coexist(t *testing.T) {
 		t.Error("bot token should be deleted")
 	}
 }
+
+func TestTokenRotation_RoundTrip(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("Skipping on macOS - uses Keychain")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if GetAPITokenRotation() != nil {
+		t.Fatal("expected no rotation data before it is set")
+	}
+
+	expires := time.Unix(1893456000, 0)
+	if err := SetAPITokenRotation(TokenRotation{RefreshToken: "xoxe-1-abc", ExpiresAt: expires}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := GetAPITokenRotation()
+	if r == nil {
+		t.Fatal("expected rotation data")
+	}
+	if r.RefreshToken != "xoxe-1-abc" || !r.ExpiresAt.Equal(expires) {
+		t.Errorf("unexpected rotation data: %+v", r)
+	}
+
+	DeleteAPITokenRotation()
+	if GetAPITokenRotation() != nil {
+		t.Error("expected rotation data to be deleted")
+	}
+}
+
+func TestOAuthClient_RoundTrip(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("Skipping on macOS - uses Keychain")
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if _, _, err := GetOAuthClient(); err == nil {
+		t.Fatal("expected error when no client credentials are stored")
+	}
+
+	if err := SetOAuthClient("123.456", "secret"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	id, secret, err := GetOAuthClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "123.456" || secret != "secret" {
+		t.Errorf("unexpected client credentials: %s / %s", id, secret)
+	}
+}
@@ -0,0 +1,100 @@
+// Package manifest builds the Slack app manifest from the scopes declared by
+// the CLI's commands.
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/piekstra/slack-chat-api/internal/oauth"
+	"github.com/piekstra/slack-chat-api/internal/scopes"
+)
+
+const (
+	defaultName        = "Slack Chat API"
+	defaultDescription = "Command-line interface for Slack Chat API"
+	backgroundColor    = "#4A154B"
+)
+
+// Manifest is the subset of the Slack app manifest the CLI needs
+type Manifest struct {
+	DisplayInformation DisplayInformation `yaml:"display_information" json:"display_information"`
+	OAuthConfig        OAuthConfig        `yaml:"oauth_config" json:"oauth_config"`
+	Settings           Settings           `yaml:"settings" json:"settings"`
+}
+
+// DisplayInformation describes how the app appears in Slack
+type DisplayInformation struct {
+	Name            string `yaml:"name" json:"name"`
+	Description     string `yaml:"description,omitempty" json:"description,omitempty"`
+	BackgroundColor string `yaml:"background_color,omitempty" json:"background_color,omitempty"`
+}
+
+// OAuthConfig holds redirect URLs and requested scopes
+type OAuthConfig struct {
+	RedirectURLs []string `yaml:"redirect_urls,omitempty" json:"redirect_urls,omitempty"`
+	Scopes       Scopes   `yaml:"scopes" json:"scopes"`
+}
+
+// Scopes lists the bot and user token scopes
+type Scopes struct {
+	Bot  []string `yaml:"bot,omitempty" json:"bot,omitempty"`
+	User []string `yaml:"user,omitempty" json:"user,omitempty"`
+}
+
+// Settings holds app-level settings
+type Settings struct {
+	OrgDeployEnabled     bool `yaml:"org_deploy_enabled" json:"org_deploy_enabled"`
+	SocketModeEnabled    bool `yaml:"socket_mode_enabled" json:"socket_mode_enabled"`
+	TokenRotationEnabled bool `yaml:"token_rotation_enabled" json:"token_rotation_enabled"`
+}
+
+// Options customizes the generated manifest
+type Options struct {
+	// Name overrides the app display name
+	Name string
+	// TokenRotation enables token rotation (irreversible once set on an app)
+	TokenRotation bool
+}
+
+// Generate builds a manifest requesting every scope in reqs
+func Generate(reqs []scopes.Requirement, opts Options) *Manifest {
+	name := opts.Name
+	if name == "" {
+		name = defaultName
+	}
+
+	return &Manifest{
+		DisplayInformation: DisplayInformation{
+			Name:            name,
+			Description:     defaultDescription,
+			BackgroundColor: backgroundColor,
+		},
+		OAuthConfig: OAuthConfig{
+			RedirectURLs: []string{fmt.Sprintf("http://localhost:%d/callback", oauth.DefaultPort)},
+			Scopes: Scopes{
+				Bot:  scopes.Union(scopes.ForToken(reqs, scopes.Bot)),
+				User: scopes.Union(scopes.ForToken(reqs, scopes.User)),
+			},
+		},
+		Settings: Settings{
+			TokenRotationEnabled: opts.TokenRotation,
+		},
+	}
+}
+
+// YAML renders the manifest as YAML with two-space indentation
+func (m *Manifest) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
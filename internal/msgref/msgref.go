@@ -0,0 +1,43 @@
+// Package msgref handles the arguments of commands that act on a message:
+// either "<channel> <timestamp>" or a message permalink in their place.
+package msgref
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Help is appended to the help of commands that take a message
+const Help = `A message permalink (copied with "Copy link" in Slack) can be given in place
+of <channel> <timestamp>:
+  https://acme.slack.com/archives/C1234567890/p1234567890123456`
+
+// IsPermalink reports whether arg is a URL rather than a channel ID
+func IsPermalink(arg string) bool {
+	return strings.HasPrefix(arg, "https://") || strings.HasPrefix(arg, "http://")
+}
+
+// Args validates "<channel> <timestamp>", or a permalink in their place,
+// followed by n more arguments
+func Args(n int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && IsPermalink(args[0]) {
+			return cobra.ExactArgs(n+1)(cmd, args)
+		}
+		return cobra.ExactArgs(n+2)(cmd, args)
+	}
+}
+
+// Split returns the message named by the leading arguments and the
+// arguments after it. A thread reply's permalink also gives its thread.
+func Split(args []string) (client.MessageRef, []string, error) {
+	if IsPermalink(args[0]) {
+		ref, err := slack.ParsePermalink(args[0])
+		return ref, args[1:], err
+	}
+	return client.MessageRef{Channel: args[0], TS: args[1]}, args[2:], nil
+}
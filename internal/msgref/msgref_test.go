@@ -0,0 +1,38 @@
+package msgref
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/internal/client"
+)
+
+const link = "https://acme.slack.com/archives/C0123ABCD/p1700000100000200?thread_ts=1700000000.123456&cid=C0123ABCD"
+
+func TestArgs(t *testing.T) {
+	cmd := &cobra.Command{}
+
+	assert.NoError(t, Args(1)(cmd, []string{"C0123ABCD", "1700000000.123456", "tada"}))
+	assert.NoError(t, Args(1)(cmd, []string{link, "tada"}))
+	assert.Error(t, Args(1)(cmd, []string{link}))
+	assert.Error(t, Args(1)(cmd, []string{"C0123ABCD", "tada"}))
+	assert.NoError(t, Args(0)(cmd, []string{link}))
+}
+
+func TestSplit(t *testing.T) {
+	ref, rest, err := Split([]string{link, "tada"})
+	require.NoError(t, err)
+	assert.Equal(t, client.MessageRef{Channel: "C0123ABCD", TS: "1700000100.000200", ThreadTS: "1700000000.123456"}, ref)
+	assert.Equal(t, []string{"tada"}, rest)
+
+	ref, rest, err = Split([]string{"C0123ABCD", "1700000000.123456"})
+	require.NoError(t, err)
+	assert.Equal(t, client.MessageRef{Channel: "C0123ABCD", TS: "1700000000.123456"}, ref)
+	assert.Empty(t, rest)
+
+	_, _, err = Split([]string{"https://acme.slack.com/archives/C0123ABCD"})
+	assert.Error(t, err)
+}
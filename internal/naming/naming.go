@@ -0,0 +1,170 @@
+// Package naming checks channel names against an organization's naming
+// policy, read from a YAML file:
+//
+//	prefixes: [team-, proj-, inc-]   # names must start with one of these
+//	forbidden: [tmp, test]           # words that may not appear in a name
+//	pattern: '^[a-z0-9-]+$'          # a regular expression names must match
+//	exempt: [general, random]        # names the policy does not apply to
+//
+// The policy is read from $SLACK_CHANNEL_POLICY if set, otherwise from
+// channel-policy.yaml in the CLI's config directory. Without a policy file
+// every name is allowed.
+package naming
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/piekstra/slack-chat-api/internal/keychain"
+	"github.com/piekstra/slack-chat-api/internal/validate"
+)
+
+// PolicyFile is the policy's file name in the config directory
+const PolicyFile = "channel-policy.yaml"
+
+// Policy is a channel naming policy. Empty fields impose no rule.
+type Policy struct {
+	Prefixes  []string `yaml:"prefixes" json:"prefixes,omitempty"`
+	Forbidden []string `yaml:"forbidden" json:"forbidden,omitempty"`
+	Pattern   string   `yaml:"pattern" json:"pattern,omitempty"`
+	Exempt    []string `yaml:"exempt" json:"exempt,omitempty"`
+
+	// Path is the file the policy was read from
+	Path string `yaml:"-" json:"path"`
+
+	pattern *regexp.Regexp
+}
+
+// DefaultPath returns where the policy is read from when no path is given
+func DefaultPath() string {
+	if path := os.Getenv("SLACK_CHANNEL_POLICY"); path != "" {
+		return path
+	}
+	return filepath.Join(keychain.ConfigDir(), PolicyFile)
+}
+
+// Load reads the policy at path, or at DefaultPath if path is empty. It
+// returns nil, and no error, if no path was given and there is no policy
+// file at the default path.
+func Load(path string) (*Policy, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultPath()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) && os.Getenv("SLACK_CHANNEL_POLICY") == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read naming policy: %w", err)
+	}
+	return Parse(data, path)
+}
+
+// Parse reads a policy from YAML. path is only used in errors.
+func Parse(data []byte, path string) (*Policy, error) {
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid naming policy %s: %w", path, err)
+	}
+	p.Path = path
+
+	if p.Pattern != "" {
+		re, err := regexp.Compile(p.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid naming policy %s: bad pattern: %w", path, err)
+		}
+		p.pattern = re
+	}
+	for i, f := range p.Forbidden {
+		p.Forbidden[i] = strings.ToLower(f)
+	}
+	return &p, nil
+}
+
+// Check returns the ways name breaks the policy, or nothing if it complies
+func (p *Policy) Check(name string) []string {
+	for _, e := range p.Exempt {
+		if name == e {
+			return nil
+		}
+	}
+
+	var problems []string
+	if len(p.Prefixes) > 0 && !p.hasPrefix(name) {
+		problems = append(problems, "must start with "+strings.Join(p.Prefixes, ", "))
+	}
+	for _, word := range p.forbiddenIn(name) {
+		problems = append(problems, fmt.Sprintf("contains %q", word))
+	}
+	if p.pattern != nil && !p.pattern.MatchString(name) {
+		problems = append(problems, fmt.Sprintf("does not match %s", p.Pattern))
+	}
+	return problems
+}
+
+// Suggest returns a name close to name that complies with the policy, or
+// "" if it cannot find one. Forbidden words are dropped, separators become
+// hyphens (so team_x becomes team-x), and if the name still lacks a prefix
+// the first one is added.
+func (p *Policy) Suggest(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+	kept := words[:0]
+	for _, w := range words {
+		if !p.forbidden(w) {
+			kept = append(kept, w)
+		}
+	}
+	if len(kept) == 0 {
+		return ""
+	}
+	suggestion := strings.Join(kept, "-")
+
+	if len(p.Prefixes) > 0 && !p.hasPrefix(suggestion) {
+		suggestion = p.Prefixes[0] + suggestion
+	}
+
+	if suggestion == name || validate.ChannelName(suggestion) != nil || len(p.Check(suggestion)) > 0 {
+		return ""
+	}
+	return suggestion
+}
+
+func (p *Policy) hasPrefix(name string) bool {
+	for _, pre := range p.Prefixes {
+		if strings.HasPrefix(name, pre) {
+			return true
+		}
+	}
+	return false
+}
+
+// forbiddenIn returns the forbidden words among name's words
+func (p *Policy) forbiddenIn(name string) []string {
+	var found []string
+	for _, w := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' }) {
+		if p.forbidden(w) {
+			found = append(found, w)
+		}
+	}
+	return found
+}
+
+func (p *Policy) forbidden(word string) bool {
+	for _, f := range p.Forbidden {
+		if word == f {
+			return true
+		}
+	}
+	return false
+}
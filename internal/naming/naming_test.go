@@ -0,0 +1,108 @@
+package naming
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `prefixes: [team-, proj-, inc-]
+forbidden: [tmp, TEST]
+pattern: '^[a-z0-9-]+$'
+exempt: [general, random]
+`
+
+func mustParse(t *testing.T) *Policy {
+	t.Helper()
+	p, err := Parse([]byte(testPolicy), "policy.yaml")
+	require.NoError(t, err)
+	return p
+}
+
+func TestCheck(t *testing.T) {
+	p := mustParse(t)
+
+	tests := []struct {
+		name     string
+		problems []string
+	}{
+		{"team-platform", nil},
+		{"proj-apollo-2026", nil},
+		{"general", nil},
+		{"apollo", []string{"must start with team-, proj-, inc-"}},
+		{"proj-tmp-apollo", []string{`contains "tmp"`}},
+		{"team_platform", []string{"must start with team-, proj-, inc-", `does not match ^[a-z0-9-]+$`}},
+		{"test-stuff", []string{"must start with team-, proj-, inc-", `contains "test"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.problems, p.Check(tt.name))
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	p := mustParse(t)
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"apollo", "team-apollo"},
+		{"team_platform", "team-platform"},
+		{"proj-tmp-apollo", "proj-apollo"},
+		{"tmp", ""},
+		{"team-platform", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.Suggest(tt.name))
+		})
+	}
+}
+
+func TestEmptyPolicyAllowsEverything(t *testing.T) {
+	p, err := Parse([]byte("{}"), "policy.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, p.Check("anything_goes"))
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse([]byte("pattern: '('\n"), "policy.yaml")
+	assert.Error(t, err)
+
+	_, err = Parse([]byte("prefix: [team-]\n"), "policy.yaml")
+	assert.Error(t, err)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("SLACK_CHANNEL_POLICY", "")
+
+	// No policy file means no policy
+	p, err := Load("")
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	path := filepath.Join(dir, "slack-chat-api", PolicyFile)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(testPolicy), 0o600))
+	p, err = Load("")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, path, p.Path)
+
+	// An explicit path must exist
+	_, err = Load(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+
+	t.Setenv("SLACK_CHANNEL_POLICY", filepath.Join(dir, "missing.yaml"))
+	_, err = Load("")
+	assert.Error(t, err)
+}
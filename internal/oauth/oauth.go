@@ -0,0 +1,287 @@
+// Package oauth implements the Slack OAuth v2 install flow used by
+// 'config login': a local redirect listener, PKCE and state checks, and the
+// oauth.v2.access code exchange.
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAuthorizeURL is Slack's OAuth v2 authorization endpoint
+	DefaultAuthorizeURL = "https://slack.com/oauth/v2/authorize"
+
+	// DefaultAPIBaseURL is the Web API base used for oauth.v2.access
+	DefaultAPIBaseURL = "https://slack.com/api"
+
+	// DefaultPort is the local redirect port. The resulting redirect URL
+	// (http://localhost:8338/callback) must be listed in the app's settings.
+	DefaultPort = 8338
+
+	callbackPath = "/callback"
+)
+
+// Config describes an OAuth v2 install flow
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	BotScopes    []string
+	UserScopes   []string
+
+	// AuthorizeURL and APIBaseURL default to Slack's endpoints; tests point
+	// them at a local fake authorization server.
+	AuthorizeURL string
+	APIBaseURL   string
+
+	// Port for the local redirect listener. Zero picks a free port.
+	Port int
+
+	// Timeout bounds how long Login waits for the browser redirect.
+	Timeout time.Duration
+
+	HTTPClient *http.Client
+
+	// OpenBrowser is called with the authorization URL (default: OpenBrowser)
+	OpenBrowser func(authURL string) error
+}
+
+// AuthedUser holds the user token section of an oauth.v2.access response
+type AuthedUser struct {
+	ID           string `json:"id"`
+	Scope        string `json:"scope"`
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+}
+
+// AccessResponse represents the response from oauth.v2.access
+type AccessResponse struct {
+	OK           bool   `json:"ok"`
+	Error        string `json:"error,omitempty"`
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	Scope        string `json:"scope"`
+	BotUserID    string `json:"bot_user_id"`
+	AppID        string `json:"app_id"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	Team         struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"team"`
+	AuthedUser AuthedUser `json:"authed_user"`
+}
+
+// callbackResult is what the redirect handler hands back to Login
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// Login runs the full install flow: it starts the redirect listener, opens
+// the browser at the authorization URL, waits for the redirect and exchanges
+// the returned code for tokens.
+func Login(ctx context.Context, cfg Config) (*AccessResponse, error) {
+	if cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("client ID and client secret are required")
+	}
+	if len(cfg.BotScopes) == 0 && len(cfg.UserScopes) == 0 {
+		return nil, fmt.Errorf("at least one bot or user scope is required")
+	}
+
+	state, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", cfg.Port))
+	if err != nil {
+		return nil, fmt.Errorf("starting redirect listener: %w", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	redirectURI := fmt.Sprintf("http://localhost:%d%s", port, callbackPath)
+
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(callbackPath, callbackHandler(state, results))
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Close() }()
+
+	authURL := AuthorizeURL(cfg, redirectURI, state, CodeChallenge(verifier))
+	open := cfg.OpenBrowser
+	if open == nil {
+		open = OpenBrowser
+	}
+	if err := open(authURL); err != nil {
+		return nil, fmt.Errorf("opening browser: %w", err)
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var code string
+	select {
+	case res := <-results:
+		if res.err != nil {
+			return nil, res.err
+		}
+		code = res.code
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for authorization")
+	}
+
+	form := url.Values{}
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("code_verifier", verifier)
+	return Access(ctx, cfg, form)
+}
+
+// Access calls oauth.v2.access with the client credentials from cfg plus the
+// given form values (an authorization code or a refresh token grant).
+func Access(ctx context.Context, cfg Config, form url.Values) (*AccessResponse, error) {
+	baseURL := cfg.APIBaseURL
+	if baseURL == "" {
+		baseURL = DefaultAPIBaseURL
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	form.Set("client_id", cfg.ClientID)
+	form.Set("client_secret", cfg.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/oauth.v2.access", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result AccessResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	if !result.OK {
+		return nil, fmt.Errorf("slack API error: %s", result.Error)
+	}
+
+	return &result, nil
+}
+
+// AuthorizeURL builds the URL the user visits to approve the install
+func AuthorizeURL(cfg Config, redirectURI, state, challenge string) string {
+	base := cfg.AuthorizeURL
+	if base == "" {
+		base = DefaultAuthorizeURL
+	}
+
+	params := url.Values{}
+	params.Set("client_id", cfg.ClientID)
+	if len(cfg.BotScopes) > 0 {
+		params.Set("scope", strings.Join(cfg.BotScopes, ","))
+	}
+	if len(cfg.UserScopes) > 0 {
+		params.Set("user_scope", strings.Join(cfg.UserScopes, ","))
+	}
+	params.Set("redirect_uri", redirectURI)
+	params.Set("state", state)
+	params.Set("code_challenge", challenge)
+	params.Set("code_challenge_method", "S256")
+
+	return base + "?" + params.Encode()
+}
+
+// CodeChallenge derives the S256 PKCE challenge for a verifier
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// OpenBrowser opens url in the user's default browser
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+func callbackHandler(state string, results chan<- callbackResult) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		var res callbackResult
+		switch {
+		case query.Get("state") != state:
+			res.err = errors.New("authorization failed: state mismatch")
+		case query.Get("error") != "":
+			res.err = fmt.Errorf("authorization failed: %s", query.Get("error"))
+		case query.Get("code") == "":
+			res.err = errors.New("authorization failed: no code in redirect")
+		default:
+			res.code = query.Get("code")
+		}
+
+		if res.err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprintf(w, "%s. You can close this window.\n", res.err)
+		} else {
+			_, _ = fmt.Fprintln(w, "slack-chat-api is authorized. You can close this window.")
+		}
+
+		// Only the first redirect counts; ignore repeats (e.g. browser reloads)
+		select {
+		case results <- res:
+		default:
+		}
+	}
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,185 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeAuthServer emulates Slack's authorize endpoint (which redirects back
+// with a code) and oauth.v2.access (which checks the PKCE verifier).
+func newFakeAuthServer(t *testing.T, authorizeErr string) *httptest.Server {
+	t.Helper()
+
+	var (
+		mu        sync.Mutex
+		challenge string
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/v2/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "client-123", q.Get("client_id"))
+		assert.Equal(t, "chat:write,users:read", q.Get("scope"))
+		assert.Equal(t, "search:read", q.Get("user_scope"))
+		assert.Equal(t, "S256", q.Get("code_challenge_method"))
+		mu.Lock()
+		challenge = q.Get("code_challenge")
+		mu.Unlock()
+
+		redirect, err := url.Parse(q.Get("redirect_uri"))
+		require.NoError(t, err)
+		params := url.Values{}
+		params.Set("state", q.Get("state"))
+		if authorizeErr != "" {
+			params.Set("error", authorizeErr)
+		} else {
+			params.Set("code", "auth-code")
+		}
+		redirect.RawQuery = params.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/api/oauth.v2.access", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client-123", r.PostForm.Get("client_id"))
+		assert.Equal(t, "secret-456", r.PostForm.Get("client_secret"))
+		assert.Equal(t, "auth-code", r.PostForm.Get("code"))
+
+		mu.Lock()
+		expected := challenge
+		mu.Unlock()
+		if CodeChallenge(r.PostForm.Get("code_verifier")) != expected {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid_code_verifier"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":           true,
+			"access_token": "xoxb-bot",
+			"token_type":   "bot",
+			"scope":        "chat:write,users:read",
+			"bot_user_id":  "U0BOT",
+			"app_id":       "A123",
+			"team":         map[string]string{"id": "T123", "name": "Test Team"},
+			"authed_user": map[string]interface{}{
+				"id":           "U123",
+				"scope":        "search:read",
+				"access_token": "xoxp-user",
+				"token_type":   "user",
+			},
+		})
+	})
+
+	return httptest.NewServer(mux)
+}
+
+func testConfig(server *httptest.Server) Config {
+	return Config{
+		ClientID:     "client-123",
+		ClientSecret: "secret-456",
+		BotScopes:    []string{"chat:write", "users:read"},
+		UserScopes:   []string{"search:read"},
+		AuthorizeURL: server.URL + "/oauth/v2/authorize",
+		APIBaseURL:   server.URL + "/api",
+		Timeout:      5 * time.Second,
+		OpenBrowser: func(authURL string) error {
+			// Stand in for the browser: follow the authorize redirect to our listener
+			resp, err := http.Get(authURL)
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		},
+	}
+}
+
+func TestLogin_Success(t *testing.T) {
+	server := newFakeAuthServer(t, "")
+	defer server.Close()
+
+	resp, err := Login(context.Background(), testConfig(server))
+	require.NoError(t, err)
+
+	assert.Equal(t, "xoxb-bot", resp.AccessToken)
+	assert.Equal(t, "chat:write,users:read", resp.Scope)
+	assert.Equal(t, "xoxp-user", resp.AuthedUser.AccessToken)
+	assert.Equal(t, "search:read", resp.AuthedUser.Scope)
+	assert.Equal(t, "T123", resp.Team.ID)
+}
+
+func TestLogin_AuthorizationDenied(t *testing.T) {
+	server := newFakeAuthServer(t, "access_denied")
+	defer server.Close()
+
+	_, err := Login(context.Background(), testConfig(server))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access_denied")
+}
+
+func TestLogin_StateMismatch(t *testing.T) {
+	server := newFakeAuthServer(t, "")
+	defer server.Close()
+
+	cfg := testConfig(server)
+	cfg.OpenBrowser = func(authURL string) error {
+		u, _ := url.Parse(authURL)
+		redirect := u.Query().Get("redirect_uri") + "?code=auth-code&state=forged"
+		resp, err := http.Get(redirect)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	_, err := Login(context.Background(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "state mismatch")
+}
+
+func TestLogin_Timeout(t *testing.T) {
+	server := newFakeAuthServer(t, "")
+	defer server.Close()
+
+	cfg := testConfig(server)
+	cfg.Timeout = 50 * time.Millisecond
+	cfg.OpenBrowser = func(string) error { return nil }
+
+	_, err := Login(context.Background(), cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+}
+
+func TestLogin_MissingCredentials(t *testing.T) {
+	_, err := Login(context.Background(), Config{BotScopes: []string{"chat:write"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "client ID and client secret")
+}
+
+func TestAuthorizeURL(t *testing.T) {
+	cfg := Config{ClientID: "abc", BotScopes: []string{"chat:write"}}
+	authURL := AuthorizeURL(cfg, "http://localhost:8338/callback", "st", "ch")
+
+	assert.True(t, strings.HasPrefix(authURL, DefaultAuthorizeURL+"?"))
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	assert.Equal(t, "abc", q.Get("client_id"))
+	assert.Equal(t, "chat:write", q.Get("scope"))
+	assert.Empty(t, q.Get("user_scope"))
+	assert.Equal(t, "st", q.Get("state"))
+	assert.Equal(t, "ch", q.Get("code_challenge"))
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// Test vector from RFC 7636 Appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallenge(verifier))
+}
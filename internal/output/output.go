@@ -114,6 +114,20 @@ func KeyValue(key string, value interface{}) {
 	_, _ = fmt.Fprintf(Writer, "%-12s  %v\n", key+":", value)
 }
 
+// FormatSize renders a byte count for humans, e.g. "1.5 MB"
+func FormatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
 // ValidFormats returns the list of valid output formats for flag validation
 func ValidFormats() []string {
 	return []string{string(FormatText), string(FormatJSON), string(FormatTable)}
@@ -0,0 +1,13 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSize(t *testing.T) {
+	assert.Equal(t, "512 B", FormatSize(512))
+	assert.Equal(t, "1.5 KB", FormatSize(1536))
+	assert.Equal(t, "2.0 MB", FormatSize(2*1024*1024))
+}
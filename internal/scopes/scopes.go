@@ -0,0 +1,131 @@
+// Package scopes records which OAuth scopes each CLI command needs and checks
+// them against the scopes a token has been granted.
+package scopes
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Token identifies which token a command authenticates with
+type Token string
+
+const (
+	Bot  Token = "bot"
+	User Token = "user"
+)
+
+// Requirement lists the scopes a command needs on its token
+type Requirement struct {
+	Command string   `json:"command"`
+	Token   Token    `json:"token"`
+	Scopes  []string `json:"scopes"`
+}
+
+const (
+	botAnnotation  = "slack-chat-api/bot-scopes"
+	userAnnotation = "slack-chat-api/user-scopes"
+)
+
+// BotScopes returns command annotations declaring the bot token scopes a
+// command needs. Use it as the cobra.Command Annotations field. With no
+// scopes it declares a command that uses the bot token but needs no scope.
+func BotScopes(scopes ...string) map[string]string {
+	return map[string]string{botAnnotation: strings.Join(scopes, ",")}
+}
+
+// UserScopes returns command annotations declaring the user token scopes a
+// command needs. Use it as the cobra.Command Annotations field.
+func UserScopes(scopes ...string) map[string]string {
+	return map[string]string{userAnnotation: strings.Join(scopes, ",")}
+}
+
+// Collect walks the command tree under root and returns the requirements
+// declared by each command, in command path order.
+func Collect(root *cobra.Command) []Requirement {
+	var reqs []Requirement
+	var walk func(cmd *cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		path := strings.TrimPrefix(cmd.CommandPath(), root.CommandPath()+" ")
+		for _, a := range []struct {
+			key   string
+			token Token
+		}{{botAnnotation, Bot}, {userAnnotation, User}} {
+			if v, ok := cmd.Annotations[a.key]; ok {
+				scopes := []string{}
+				if v != "" {
+					scopes = strings.Split(v, ",")
+				}
+				reqs = append(reqs, Requirement{Command: path, Token: a.token, Scopes: scopes})
+			}
+		}
+		for _, child := range cmd.Commands() {
+			walk(child)
+		}
+	}
+	walk(root)
+	return reqs
+}
+
+// supersets maps a scope to broader scopes that also grant it
+var supersets = map[string][]string{
+	"channels:manage":        {"channels:write"},
+	"channels:write.topic":   {"channels:manage", "channels:write"},
+	"channels:write.invites": {"channels:manage", "channels:write"},
+	"chat:write":             {"chat:write:bot"},
+}
+
+// Has reports whether granted includes scope, directly or via a superset
+func Has(granted []string, scope string) bool {
+	for _, g := range granted {
+		if g == scope {
+			return true
+		}
+		for _, s := range supersets[scope] {
+			if g == s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Missing returns the required scopes not covered by granted
+func Missing(granted, required []string) []string {
+	var missing []string
+	for _, r := range required {
+		if !Has(granted, r) {
+			missing = append(missing, r)
+		}
+	}
+	return missing
+}
+
+// ForToken filters reqs down to commands using the given token
+func ForToken(reqs []Requirement, token Token) []Requirement {
+	var filtered []Requirement
+	for _, r := range reqs {
+		if r.Token == token {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// Union returns the sorted, de-duplicated scopes across reqs
+func Union(reqs []Requirement) []string {
+	seen := make(map[string]bool)
+	var all []string
+	for _, r := range reqs {
+		for _, s := range r.Scopes {
+			if !seen[s] {
+				seen[s] = true
+				all = append(all, s)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
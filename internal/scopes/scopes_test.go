@@ -0,0 +1,61 @@
+package scopes
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHas(t *testing.T) {
+	granted := []string{"chat:write", "channels:manage"}
+
+	assert.True(t, Has(granted, "chat:write"))
+	assert.True(t, Has(granted, "channels:write.topic"), "channels:manage covers channels:write.topic")
+	assert.False(t, Has(granted, "users:read"))
+	assert.False(t, Has(nil, "chat:write"))
+}
+
+func TestMissing(t *testing.T) {
+	missing := Missing([]string{"channels:read"}, []string{"channels:read", "groups:read", "im:read"})
+	assert.Equal(t, []string{"groups:read", "im:read"}, missing)
+
+	assert.Empty(t, Missing([]string{"search:read"}, []string{"search:read"}))
+}
+
+func newTestTree() *cobra.Command {
+	root := &cobra.Command{Use: "slack-chat-api"}
+	messages := &cobra.Command{Use: "messages"}
+	messages.AddCommand(
+		&cobra.Command{Use: "send", Annotations: BotScopes("chat:write")},
+		&cobra.Command{Use: "history", Annotations: BotScopes("channels:history", "groups:history")},
+		&cobra.Command{Use: "permalink", Annotations: BotScopes()},
+	)
+	search := &cobra.Command{Use: "search"}
+	search.AddCommand(&cobra.Command{Use: "all", Annotations: UserScopes("search:read")})
+	root.AddCommand(messages, search, &cobra.Command{Use: "version"})
+	return root
+}
+
+func TestCollect(t *testing.T) {
+	reqs := Collect(newTestTree())
+
+	assert.Equal(t, []Requirement{
+		{Command: "messages history", Token: Bot, Scopes: []string{"channels:history", "groups:history"}},
+		{Command: "messages permalink", Token: Bot, Scopes: []string{}},
+		{Command: "messages send", Token: Bot, Scopes: []string{"chat:write"}},
+		{Command: "search all", Token: User, Scopes: []string{"search:read"}},
+	}, reqs)
+}
+
+func TestForTokenAndUnion(t *testing.T) {
+	reqs := Collect(newTestTree())
+
+	user := ForToken(reqs, User)
+	for _, r := range user {
+		assert.Equal(t, User, r.Token)
+	}
+	assert.Equal(t, []string{"search:read"}, Union(user))
+
+	assert.Equal(t, []string{"channels:history", "chat:write", "groups:history"}, Union(ForToken(reqs, Bot)))
+}
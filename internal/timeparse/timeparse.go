@@ -0,0 +1,247 @@
+// Package timeparse parses the times people type on the command line:
+// absolute dates and times, clock times, day names and relative offsets,
+// interpreted in a chosen time zone.
+//
+//	2026-03-14 09:30     2026-03-14T09:30:00Z   1773495000
+//	09:00   9am   5:30pm   noon
+//	today 17:00   tomorrow 9am   friday 14:00   next monday at noon
+//	in 2h   in 90 minutes   +1d   3 days ago
+package timeparse
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadLocation resolves a --tz value. An empty name means the local time
+// zone.
+func LoadLocation(name string) (*time.Location, error) {
+	switch strings.ToLower(name) {
+	case "", "local":
+		return time.Local, nil
+	case "utc", "z":
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: use an IANA name such as America/New_York", name)
+	}
+	return loc, nil
+}
+
+var (
+	unixPattern     = regexp.MustCompile(`^\d{9,}(\.\d+)?$`)
+	durationPattern = regexp.MustCompile(`^(\d+)\s*([a-z]+)$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?\s*(am|pm)?$`)
+
+	dateLayouts = []string{"2006-01-02", "2006/01/02"}
+
+	weekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+	}
+)
+
+// Parse interprets s relative to now in loc. Times without a date are the
+// next time that clock time comes around; day names are the next such day.
+// A date without a time means midnight.
+func Parse(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.Local
+	}
+	now = now.In(loc)
+	if t, err := time.Parse(time.RFC3339, strings.TrimSpace(s)); err == nil {
+		return t.In(loc), nil
+	}
+	in := strings.ToLower(strings.Join(strings.Fields(s), " "))
+	if in == "" {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+
+	if unixPattern.MatchString(in) {
+		sec, err := strconv.ParseFloat(in, 64)
+		if err == nil {
+			return time.Unix(int64(sec), 0).In(loc), nil
+		}
+	}
+
+	// Relative: "in 2h", "+90m", "3 days ago"
+	switch {
+	case strings.HasPrefix(in, "in "):
+		d, err := ParseDuration(strings.TrimPrefix(in, "in "))
+		if err != nil {
+			return time.Time{}, invalid(s, err)
+		}
+		return now.Add(d), nil
+	case strings.HasPrefix(in, "+"):
+		d, err := ParseDuration(strings.TrimPrefix(in, "+"))
+		if err != nil {
+			return time.Time{}, invalid(s, err)
+		}
+		return now.Add(d), nil
+	case strings.HasSuffix(in, " ago"):
+		d, err := ParseDuration(strings.TrimSuffix(in, " ago"))
+		if err != nil {
+			return time.Time{}, invalid(s, err)
+		}
+		return now.Add(-d), nil
+	case in == "now":
+		return now, nil
+	}
+
+	// Absolute: "[day] [at] [clock]"
+	datePart, clockPart := splitDateClock(in)
+	hour, min, sec := 0, 0, 0
+	if clockPart != "" {
+		var err error
+		if hour, min, sec, err = parseClock(clockPart); err != nil {
+			return time.Time{}, invalid(s, err)
+		}
+	}
+
+	at := func(day time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, min, sec, 0, loc)
+	}
+
+	switch {
+	case datePart == "":
+		t := at(now)
+		if !t.After(now) {
+			t = at(now.AddDate(0, 0, 1))
+		}
+		return t, nil
+	case datePart == "today":
+		return at(now), nil
+	case datePart == "tomorrow":
+		return at(now.AddDate(0, 0, 1)), nil
+	case datePart == "yesterday":
+		return at(now.AddDate(0, 0, -1)), nil
+	}
+
+	if day, ok := weekdays[strings.TrimPrefix(datePart, "next ")]; ok {
+		offset := (int(day) - int(now.Weekday()) + 7) % 7
+		t := at(now.AddDate(0, 0, offset))
+		if !t.After(now) || strings.HasPrefix(datePart, "next ") && offset == 0 {
+			t = at(now.AddDate(0, 0, offset+7))
+		}
+		return t, nil
+	}
+
+	for _, layout := range dateLayouts {
+		if d, err := time.ParseInLocation(layout, datePart, loc); err == nil {
+			return at(d), nil
+		}
+	}
+
+	return time.Time{}, invalid(s, nil)
+}
+
+// splitDateClock separates "tomorrow at 9am" into its day and clock parts
+func splitDateClock(in string) (datePart, clockPart string) {
+	in = strings.Replace(in, " at ", " ", 1)
+	in = strings.TrimPrefix(in, "at ")
+	// ISO date and time joined by T
+	if len(in) > 11 && in[10] == 't' {
+		if _, err := time.Parse("2006-01-02", in[:10]); err == nil {
+			return in[:10], in[11:]
+		}
+	}
+
+	fields := strings.Fields(in)
+	// The clock is the trailing field(s) if they parse as one: "5:30 pm"
+	for i := 0; i < len(fields); i++ {
+		rest := strings.Join(fields[i:], " ")
+		if _, _, _, err := parseClock(rest); err == nil {
+			return strings.Join(fields[:i], " "), rest
+		}
+	}
+	return in, ""
+}
+
+func parseClock(s string) (hour, min, sec int, err error) {
+	switch s {
+	case "noon":
+		return 12, 0, 0, nil
+	case "midnight":
+		return 0, 0, 0, nil
+	}
+	m := clockPattern.FindStringSubmatch(s)
+	// A bare number is only a clock time with am/pm ("9am"), so dates and
+	// counts aren't mistaken for hours
+	if m == nil || (m[2] == "" && m[4] == "") {
+		return 0, 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		min, _ = strconv.Atoi(m[2])
+	}
+	if m[3] != "" {
+		sec, _ = strconv.Atoi(m[3])
+	}
+	switch m[4] {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, 0, fmt.Errorf("invalid hour %d", hour)
+		}
+		if hour == 12 {
+			hour = 0
+		}
+		if m[4] == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || min > 59 || sec > 59 {
+		return 0, 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	return hour, min, sec, nil
+}
+
+// ParseDuration parses a duration such as "90m", "2h30m", "3 days" or "1w".
+// It accepts everything time.ParseDuration does plus days and weeks.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	m := durationPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration %q: use e.g. 30m, 2h, 3d or 1w", s)
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, err
+	}
+	var unit time.Duration
+	switch m[2] {
+	case "s", "sec", "secs", "second", "seconds":
+		unit = time.Second
+	case "m", "min", "mins", "minute", "minutes":
+		unit = time.Minute
+	case "h", "hr", "hrs", "hour", "hours":
+		unit = time.Hour
+	case "d", "day", "days":
+		unit = 24 * time.Hour
+	case "w", "wk", "wks", "week", "weeks":
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid duration %q: unknown unit %q", s, m[2])
+	}
+	return time.Duration(n) * unit, nil
+}
+
+func invalid(s string, err error) error {
+	msg := fmt.Sprintf("invalid time %q: use e.g. \"tomorrow 09:00\", \"friday 5pm\", \"2026-03-14 09:30\" or \"in 2h\"", s)
+	if err != nil {
+		msg += " (" + err.Error() + ")"
+	}
+	return errors.New(msg)
+}
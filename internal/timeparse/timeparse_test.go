@@ -0,0 +1,116 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// Wednesday 2026-03-11 10:15 in New York
+	now := time.Date(2026, 3, 11, 10, 15, 0, 0, ny)
+	at := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, ny)
+	}
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2026-03-14 09:30", at(3, 14, 9, 30)},
+		{"2026-03-14T09:30", at(3, 14, 9, 30)},
+		{"2026-03-14", at(3, 14, 0, 0)},
+		{"2026-03-14T13:30:00Z", at(3, 14, 9, 30)},
+		{"1773495000", at(3, 14, 9, 30)},
+		{"11:00", at(3, 11, 11, 0)},
+		{"09:00", at(3, 12, 9, 0)}, // already past today
+		{"9am", at(3, 12, 9, 0)},
+		{"5:30pm", at(3, 11, 17, 30)},
+		{"5:30 PM", at(3, 11, 17, 30)},
+		{"12am", at(3, 12, 0, 0)},
+		{"noon", at(3, 11, 12, 0)},
+		{"today 17:00", at(3, 11, 17, 0)},
+		{"tomorrow 09:00", at(3, 12, 9, 0)},
+		{"Tomorrow at 9am", at(3, 12, 9, 0)},
+		{"tomorrow", at(3, 12, 0, 0)},
+		{"friday 14:00", at(3, 13, 14, 0)},
+		{"wed 11:00", at(3, 11, 11, 0)},
+		{"wed 9:00", at(3, 18, 9, 0)}, // already past this Wednesday
+		{"next wednesday at noon", at(3, 18, 12, 0)},
+		{"in 2h", at(3, 11, 12, 15)},
+		{"in 90 minutes", at(3, 11, 11, 45)},
+		{"+1d", at(3, 12, 10, 15)},
+		{"3 days ago", at(3, 8, 10, 15)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in, now, ny)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+			assert.Equal(t, ny, got.Location())
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Date(2026, 3, 11, 10, 15, 0, 0, time.UTC)
+	for _, in := range []string{"", "soon", "25:00", "13pm", "tomorrow 9", "in forever", "2026-13-40"} {
+		t.Run(in, func(t *testing.T) {
+			_, err := Parse(in, now, time.UTC)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParse_DST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	// The day before clocks spring forward, "tomorrow 09:00" is still 09:00
+	// local time, 23 hours later
+	now := time.Date(2026, 3, 7, 10, 0, 0, 0, ny)
+	got, err := Parse("tomorrow 10:00", now, ny)
+	require.NoError(t, err)
+	assert.Equal(t, 10, got.Hour())
+	assert.Equal(t, 23*time.Hour, got.Sub(now))
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"90m":     90 * time.Minute,
+		"2h30m":   150 * time.Minute,
+		"3d":      72 * time.Hour,
+		"3 days":  72 * time.Hour,
+		"1w":      7 * 24 * time.Hour,
+		"2 weeks": 14 * 24 * time.Hour,
+	}
+	for in, want := range tests {
+		got, err := ParseDuration(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := ParseDuration("3 fortnights")
+	assert.Error(t, err)
+}
+
+func TestLoadLocation(t *testing.T) {
+	loc, err := LoadLocation("")
+	require.NoError(t, err)
+	assert.Equal(t, time.Local, loc)
+
+	loc, err = LoadLocation("UTC")
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	loc, err = LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", loc.String())
+
+	_, err = LoadLocation("Mars/Olympus_Mons")
+	assert.Error(t, err)
+}
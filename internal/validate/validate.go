@@ -10,6 +10,9 @@ var (
 	channelIDRegex = regexp.MustCompile(`^[CG][A-Z0-9]+$`)
 	userIDRegex    = regexp.MustCompile(`^[UW][A-Z0-9]+$`)
 	timestampRegex = regexp.MustCompile(`^\d+\.\d+$`)
+	fileIDRegex    = regexp.MustCompile(`^F[A-Z0-9]+$`)
+
+	channelNameRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
 )
 
 // ChannelID validates that the given string is a valid Slack channel ID.
@@ -39,6 +42,31 @@ func Timestamp(ts string) error {
 	return nil
 }
 
+// FileID validates that the given string is a valid Slack file ID.
+// File IDs start with F.
+func FileID(id string) error {
+	if !fileIDRegex.MatchString(id) {
+		return fmt.Errorf("invalid file ID %q: must start with F (e.g., F01234ABCDE)", id)
+	}
+	return nil
+}
+
+// ChannelName validates a new channel name the way Slack does: at most 80
+// lowercase letters, numbers, hyphens and underscores, not all punctuation.
+func ChannelName(name string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("channel name cannot be empty")
+	case len(name) > 80:
+		return fmt.Errorf("invalid channel name %q: must be at most 80 characters", name)
+	case !channelNameRegex.MatchString(name):
+		return fmt.Errorf("invalid channel name %q: use lowercase letters, numbers, hyphens and underscores only", name)
+	case strings.Trim(name, "_-") == "":
+		return fmt.Errorf("invalid channel name %q: must contain a letter or number", name)
+	}
+	return nil
+}
+
 // Emoji normalizes an emoji name by stripping surrounding colons.
 // Returns the cleaned emoji name.
 func Emoji(emoji string) string {
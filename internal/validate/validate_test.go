@@ -1,6 +1,7 @@
 package validate
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -79,6 +80,32 @@ func TestTimestamp(t *testing.T) {
 	}
 }
 
+func TestChannelName(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+	}{
+		{"valid", "inc-42_db", false},
+		{"valid max length", strings.Repeat("a", 80), false},
+		{"empty string", "", true},
+		{"too long", strings.Repeat("a", 81), true},
+		{"uppercase", "Incidents", true},
+		{"space", "my channel", true},
+		{"leading hash", "#general", true},
+		{"only punctuation", "-_-", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ChannelName(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChannelName(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestEmoji(t *testing.T) {
 	tests := []struct {
 		input string
@@ -125,3 +152,25 @@ func TestLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestFileID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr bool
+	}{
+		{"valid file", "F01234ABCDE", false},
+		{"channel ID", "C01234ABCDE", true},
+		{"lowercase", "f01234abcde", true},
+		{"empty string", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FileID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FileID(%q) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Bookmark is a link bookmarked in a channel's header
+type Bookmark struct {
+	ID                  string `json:"id"`
+	ChannelID           string `json:"channel_id"`
+	Title               string `json:"title"`
+	Link                string `json:"link"`
+	Emoji               string `json:"emoji,omitempty"`
+	IconURL             string `json:"icon_url,omitempty"`
+	Type                string `json:"type"`
+	DateCreated         int64  `json:"date_created"`
+	DateUpdated         int64  `json:"date_updated"`
+	Rank                string `json:"rank,omitempty"`
+	LastUpdatedByUserID string `json:"last_updated_by_user_id,omitempty"`
+}
+
+// BookmarkUpdate lists the bookmark fields to change; nil fields are left
+// as they are
+type BookmarkUpdate struct {
+	Title *string
+	Link  *string
+	Emoji *string
+}
+
+// AddBookmark adds a link bookmark to a channel
+func (c *Client) AddBookmark(channel, title, link, emoji string) (*Bookmark, error) {
+	return c.AddBookmarkContext(context.Background(), channel, title, link, emoji)
+}
+
+// AddBookmarkContext is AddBookmark with a context
+func (c *Client) AddBookmarkContext(ctx context.Context, channel, title, link, emoji string) (*Bookmark, error) {
+	data := map[string]interface{}{
+		"channel_id": channel,
+		"title":      title,
+		"type":       "link",
+		"link":       link,
+	}
+	if emoji != "" {
+		data["emoji"] = emoji
+	}
+
+	body, err := c.post(ctx, "bookmarks.add", data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBookmark(body)
+}
+
+// EditBookmark changes a bookmark's title, link or emoji
+func (c *Client) EditBookmark(channel, id string, update BookmarkUpdate) (*Bookmark, error) {
+	return c.EditBookmarkContext(context.Background(), channel, id, update)
+}
+
+// EditBookmarkContext is EditBookmark with a context
+func (c *Client) EditBookmarkContext(ctx context.Context, channel, id string, update BookmarkUpdate) (*Bookmark, error) {
+	data := map[string]interface{}{
+		"channel_id":  channel,
+		"bookmark_id": id,
+	}
+	if update.Title != nil {
+		data["title"] = *update.Title
+	}
+	if update.Link != nil {
+		data["link"] = *update.Link
+	}
+	if update.Emoji != nil {
+		data["emoji"] = *update.Emoji
+	}
+
+	body, err := c.post(ctx, "bookmarks.edit", data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBookmark(body)
+}
+
+// RemoveBookmark removes a bookmark from a channel
+func (c *Client) RemoveBookmark(channel, id string) error {
+	return c.RemoveBookmarkContext(context.Background(), channel, id)
+}
+
+// RemoveBookmarkContext is RemoveBookmark with a context
+func (c *Client) RemoveBookmarkContext(ctx context.Context, channel, id string) error {
+	data := map[string]interface{}{
+		"channel_id":  channel,
+		"bookmark_id": id,
+	}
+	_, err := c.post(ctx, "bookmarks.remove", data)
+	return err
+}
+
+// ListBookmarks returns a channel's bookmarks in display order
+func (c *Client) ListBookmarks(channel string) ([]Bookmark, error) {
+	return c.ListBookmarksContext(context.Background(), channel)
+}
+
+// ListBookmarksContext is ListBookmarks with a context
+func (c *Client) ListBookmarksContext(ctx context.Context, channel string) ([]Bookmark, error) {
+	params := url.Values{}
+	params.Set("channel_id", channel)
+
+	body, err := c.get(ctx, "bookmarks.list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Bookmarks []Bookmark `json:"bookmarks"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result.Bookmarks, nil
+}
+
+func decodeBookmark(body []byte) (*Bookmark, error) {
+	var result struct {
+		Bookmark Bookmark `json:"bookmark"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result.Bookmark, nil
+}
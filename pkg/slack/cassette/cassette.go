@@ -0,0 +1,183 @@
+// Package cassette records Slack API traffic to disk and replays it, so a
+// problem seen against a real workspace can be reproduced offline and
+// captured payloads can back golden-file tests.
+//
+// A cassette is a directory holding one JSON file per request/response pair,
+// named in the order the requests were made (0001-chat.postMessage.json).
+// Tokens, client secrets and OAuth codes are redacted before anything is
+// written, so cassettes can be attached to bug reports. Only JSON and
+// form-encoded bodies are recorded; file uploads and downloads are stored as
+// their content type and length, and replay as that placeholder.
+//
+//	rec, err := cassette.NewRecorder("testdata/send", nil)
+//	c := slack.New(slack.WithHTTPClient(&http.Client{Transport: rec}), slack.WithToken(token))
+//
+//	rep, err := cassette.Load("testdata/send")
+//	c := slack.New(slack.WithHTTPClient(&http.Client{Transport: rep}), slack.WithToken("xoxb-replay"))
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Redacted replaces secrets in recorded traffic
+const Redacted = "[REDACTED]"
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request
+type Request struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response
+type Response struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    Body        `json:"body"`
+}
+
+// Body is a request or response body. JSON bodies are stored as JSON so
+// cassettes stay readable; anything else is stored as a string.
+type Body []byte
+
+// MarshalJSON implements json.Marshaler
+func (b Body) MarshalJSON() ([]byte, error) {
+	if len(b) > 0 && json.Valid(b) {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, b); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return json.Marshal(string(b))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (b *Body) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*b = Body(s)
+		return nil
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+// recordable reports whether a body of this content type is recorded. Other
+// bodies, such as file content, are left to stream.
+func recordable(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/json") ||
+		strings.HasPrefix(contentType, "application/x-www-form-urlencoded")
+}
+
+// omitted stands in for a body that is not recorded
+func omitted(contentType string, length int64) Body {
+	if contentType == "" {
+		contentType = "unknown content type"
+	}
+	if length < 0 {
+		return Body(fmt.Sprintf("[omitted: %s, unknown length]", contentType))
+	}
+	return Body(fmt.Sprintf("[omitted: %s, %d bytes]", contentType, length))
+}
+
+// droppedHeaders are never recorded. Content-Length is dropped because
+// bodies are re-encoded.
+var droppedHeaders = []string{"Cookie", "Set-Cookie", "Content-Length"}
+
+func redactURL(u *url.URL) string {
+	c := *u
+	if c.RawQuery != "" {
+		c.RawQuery = slack.RedactValues(c.Query()).Encode()
+	}
+	return c.String()
+}
+
+func redactHeaders(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for key, vs := range h {
+		out[key] = append([]string(nil), vs...)
+	}
+	for _, key := range droppedHeaders {
+		out.Del(key)
+	}
+	for key, vs := range out {
+		for i, v := range vs {
+			if key == "Authorization" {
+				if scheme, _, ok := strings.Cut(v, " "); ok {
+					vs[i] = scheme + " " + Redacted
+				} else {
+					vs[i] = Redacted
+				}
+				continue
+			}
+			vs[i] = slack.RedactSecrets(v)
+		}
+	}
+	return out
+}
+
+// fileName returns the cassette file name for the n-th interaction
+func fileName(n int, u *url.URL) string {
+	method := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' {
+			return '_'
+		}
+		return r
+	}, path.Base(u.Path))
+	return fmt.Sprintf("%04d-%s.json", n, method)
+}
+
+// interactionFiles lists a cassette's files in recording order
+func interactionFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "[0-9][0-9][0-9][0-9]-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReadDir loads every interaction in a cassette directory
+func ReadDir(dir string) ([]Interaction, error) {
+	files, err := interactionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	interactions := make([]Interaction, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var it Interaction
+		if err := json.Unmarshal(data, &it); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", f, err)
+		}
+		interactions = append(interactions, it)
+	}
+	return interactions, nil
+}
@@ -0,0 +1,116 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Recorder is an http.RoundTripper that passes requests to another
+// transport and writes each request/response pair, redacted, to a cassette
+// directory. It is safe for concurrent use.
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+
+	mu sync.Mutex
+	n  int
+}
+
+// NewRecorder creates dir if needed and returns a Recorder that sends
+// requests through next (http.DefaultTransport if nil). Recording into an
+// existing cassette appends after its last interaction.
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating cassette directory: %w", err)
+	}
+	files, err := interactionFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{dir: dir, next: next, n: len(files)}, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqType := req.Header.Get("Content-Type")
+	var reqBody Body
+	if req.Body != nil && req.Body != http.NoBody {
+		if recordable(reqType) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			_ = req.Body.Close()
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			reqBody = slack.RedactBody(body, reqType)
+		} else {
+			reqBody = omitted(reqType, req.ContentLength)
+		}
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respType := resp.Header.Get("Content-Type")
+	var respBody Body
+	if recordable(respType) {
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		respBody = slack.RedactBody(body, respType)
+	} else {
+		respBody = omitted(respType, resp.ContentLength)
+	}
+
+	it := Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     redactURL(req.URL),
+			Headers: redactHeaders(req.Header),
+			Body:    reqBody,
+		},
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: redactHeaders(resp.Header),
+			Body:    respBody,
+		},
+	}
+	if err := r.write(req, it); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) write(req *http.Request, it Interaction) error {
+	data, err := json.MarshalIndent(it, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.n++
+	name := filepath.Join(r.dir, fileName(r.n, req.URL))
+	if err := os.WriteFile(name, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("writing cassette: %w", err)
+	}
+	return nil
+}
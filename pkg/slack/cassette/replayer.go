@@ -0,0 +1,144 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Replayer is an http.RoundTripper that answers requests from a recorded
+// cassette without touching the network. It is safe for concurrent use.
+//
+// A request is matched to the first unused interaction for the same API
+// method with the same query and body (after redaction). If none matches
+// exactly, the first unused interaction for the same API method is used, so
+// replays tolerate changed timestamps or cursors. Only the last path segment
+// is compared, so a cassette recorded against slack.com replays against any
+// base URL.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// Load reads the cassette in dir for replay
+func Load(dir string) (*Replayer, error) {
+	interactions, err := ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(interactions) == 0 {
+		return nil, fmt.Errorf("no recorded interactions in %s", dir)
+	}
+	return NewReplayer(interactions), nil
+}
+
+// NewReplayer replays the given interactions
+func NewReplayer(interactions []Interaction) *Replayer {
+	return &Replayer{interactions: interactions, used: make([]bool, len(interactions))}
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Bodies that were not recorded can't be compared, so only the path and
+	// query pick the interaction
+	contentType := req.Header.Get("Content-Type")
+	var body []byte
+	compareBody := req.Body == nil || req.Body == http.NoBody || recordable(contentType)
+	if req.Body != nil {
+		var err error
+		if compareBody {
+			if body, err = io.ReadAll(req.Body); err != nil {
+				return nil, err
+			}
+		}
+		_ = req.Body.Close()
+	}
+	body = slack.RedactBody(body, contentType)
+	query := slack.RedactValues(req.URL.Query())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, it := range r.interactions {
+		if r.used[i] || !samePath(it.Request, req) {
+			continue
+		}
+		if match < 0 {
+			match = i
+		}
+		if sameQuery(it.Request, query) && (!compareBody || sameBody(it.Request.Body, body)) {
+			match = i
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("cassette: no recorded response for %s %s", req.Method, path.Base(req.URL.Path))
+	}
+	r.used[match] = true
+
+	rec := r.interactions[match].Response
+	header := rec.Headers.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.Status, http.StatusText(rec.Status)),
+		StatusCode:    rec.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(rec.Body)),
+		ContentLength: int64(len(rec.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Remaining returns how many recorded interactions have not been replayed
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, used := range r.used {
+		if !used {
+			n++
+		}
+	}
+	return n
+}
+
+func samePath(rec Request, req *http.Request) bool {
+	u, err := url.Parse(rec.URL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(rec.Method, req.Method) && path.Base(u.Path) == path.Base(req.URL.Path)
+}
+
+func sameQuery(rec Request, query url.Values) bool {
+	u, err := url.Parse(rec.URL)
+	if err != nil {
+		return false
+	}
+	return u.Query().Encode() == query.Encode()
+}
+
+func sameBody(recorded Body, body []byte) bool {
+	if json.Valid(recorded) && json.Valid(body) {
+		var a, b bytes.Buffer
+		if json.Compact(&a, recorded) == nil && json.Compact(&b, body) == nil {
+			return bytes.Equal(a.Bytes(), b.Bytes())
+		}
+	}
+	return bytes.Equal(recorded, body)
+}
@@ -0,0 +1,1183 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Slack Web API base URL
+const DefaultBaseURL = "https://slack.com/api"
+
+// Client handles Slack API interactions
+type Client struct {
+	httpClient *http.Client
+	tokens     TokenSource
+	baseURL    string
+	logger     *slog.Logger
+	retry      RetryPolicy
+	userAgent  string
+	stall      time.Duration
+}
+
+// New creates a Slack client configured by opts. Without WithToken or
+// WithTokenSource every request fails.
+func New(opts ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    DefaultBaseURL,
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BaseURL returns the Web API base URL the client sends requests to
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// HTTPClient returns the underlying HTTP client
+func (c *Client) HTTPClient() *http.Client {
+	return c.httpClient
+}
+
+// SlackResponse represents a generic Slack API response
+type SlackResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+
+	// Set on missing_scope errors
+	Needed   string `json:"needed,omitempty"`
+	Provided string `json:"provided,omitempty"`
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values) ([]byte, error) {
+	reqURL := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+	if params != nil {
+		reqURL += "?" + params.Encode()
+	}
+
+	body, _, err := c.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	})
+	return body, err
+}
+
+func (c *Client) post(ctx context.Context, endpoint string, data interface{}) ([]byte, error) {
+	body, _, err := c.postWithHeader(ctx, endpoint, data)
+	return body, err
+}
+
+// postWithHeader is post for callers that also need the response headers
+func (c *Client) postWithHeader(ctx context.Context, endpoint string, data interface{}) ([]byte, http.Header, error) {
+	reqURL := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.do(ctx, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "POST", reqURL, bytes.NewReader(jsonData))
+	})
+}
+
+// do sends the request built by newRequest, retrying according to the retry
+// policy and, if the token source supports it, refreshing the token once when
+// Slack reports token_expired. newRequest must bind its request to ctx.
+func (c *Client) do(ctx context.Context, newRequest func() (*http.Request, error)) ([]byte, http.Header, error) {
+	if c.tokens == nil {
+		return nil, nil, errors.New("slack: no token configured")
+	}
+	token, err := sourceToken(ctx, c.tokens)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, header, err := c.sendWithRetry(ctx, newRequest, token)
+
+	var apiErr *APIError
+	if refresher, ok := c.tokens.(Refresher); ok && errors.As(err, &apiErr) && apiErr.Code == "token_expired" {
+		c.logger.Info("slack token expired, refreshing")
+		if token, err = refreshToken(ctx, refresher, token); err != nil {
+			return nil, nil, err
+		}
+		return c.sendWithRetry(ctx, newRequest, token)
+	}
+
+	return body, header, err
+}
+
+// sendWithRetry sends the request, retrying rate-limited (HTTP 429) and,
+// if the policy allows, server error responses.
+func (c *Client) sendWithRetry(ctx context.Context, newRequest func() (*http.Request, error), token string) ([]byte, http.Header, error) {
+	for attempt := 0; ; attempt++ {
+		body, header, status, err := c.send(newRequest, token)
+		if err == nil || !c.retry.shouldRetry(status, attempt) {
+			return body, header, err
+		}
+
+		delay := c.retry.delay(attempt, header.Get("Retry-After"))
+		c.logger.Info("slack request retrying", "status", status, "attempt", attempt+1, "delay", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, header, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send performs a single request. The HTTP status is returned alongside any
+// error so the caller can decide whether to retry.
+func (c *Client) send(newRequest func() (*http.Request, error), token string) (result []byte, header http.Header, status int, err error) {
+	req, err := newRequest()
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.Header, resp.StatusCode, err
+	}
+
+	var slackResp SlackResponse
+	if err := json.Unmarshal(body, &slackResp); err != nil {
+		return nil, resp.Header, resp.StatusCode, err
+	}
+
+	if !slackResp.OK {
+		return nil, resp.Header, resp.StatusCode, &APIError{
+			Code:           slackResp.Error,
+			Needed:         slackResp.Needed,
+			Provided:       slackResp.Provided,
+			AcceptedScopes: parseScopes(resp.Header.Get("X-Accepted-OAuth-Scopes")),
+		}
+	}
+
+	return body, resp.Header, resp.StatusCode, nil
+}
+
+// parseScopes splits a comma-separated scope header into its scopes
+func parseScopes(header string) []string {
+	var scopes []string
+	for _, s := range strings.Split(header, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+// Channel represents a Slack channel
+type Channel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	IsPrivate   bool   `json:"is_private"`
+	IsArchived  bool   `json:"is_archived"`
+	IsGeneral   bool   `json:"is_general"`
+	IsMember    bool   `json:"is_member"`
+	IsShared    bool   `json:"is_shared"`
+	IsExtShared bool   `json:"is_ext_shared"`
+	Created     int64  `json:"created"`
+	Creator     string `json:"creator"`
+	LastRead    string `json:"last_read,omitempty"`
+	Topic       struct {
+		Value string `json:"value"`
+	} `json:"topic"`
+	Purpose struct {
+		Value string `json:"value"`
+	} `json:"purpose"`
+	NumMembers int `json:"num_members"`
+}
+
+// User represents a Slack user. Guests are restricted (multi-channel) or
+// ultra restricted (single-channel). Has2FA is only sent to admins and
+// owners, so it is nil when not visible.
+type User struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	RealName          string `json:"real_name"`
+	Deleted           bool   `json:"deleted"`
+	IsAdmin           bool   `json:"is_admin"`
+	IsOwner           bool   `json:"is_owner"`
+	IsBot             bool   `json:"is_bot"`
+	IsRestricted      bool   `json:"is_restricted"`
+	IsUltraRestricted bool   `json:"is_ultra_restricted"`
+	Has2FA            *bool  `json:"has_2fa,omitempty"`
+	TZ                string `json:"tz,omitempty"`
+	Updated           int64  `json:"updated,omitempty"`
+	Profile           struct {
+		Email            string `json:"email"`
+		DisplayName      string `json:"display_name"`
+		Title            string `json:"title,omitempty"`
+		Phone            string `json:"phone,omitempty"`
+		StatusText       string `json:"status_text"`
+		StatusEmoji      string `json:"status_emoji"`
+		StatusExpiration int64  `json:"status_expiration,omitempty"`
+	} `json:"profile"`
+}
+
+// IsGuest reports whether the user is a multi- or single-channel guest
+func (u *User) IsGuest() bool {
+	return u.IsRestricted || u.IsUltraRestricted
+}
+
+// Team represents workspace info
+type Team struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Domain string `json:"domain"`
+}
+
+// SearchMatch represents a message match from search
+type SearchMatch struct {
+	Type    string `json:"type"`
+	Channel struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"channel"`
+	User      string `json:"user"`
+	Username  string `json:"username"`
+	Text      string `json:"text"`
+	TS        string `json:"ts"`
+	Permalink string `json:"permalink"`
+}
+
+// FileMatch represents a file match from search
+type FileMatch struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Title              string `json:"title"`
+	Filetype           string `json:"filetype"`
+	User               string `json:"user"`
+	Size               int64  `json:"size,omitempty"`
+	Created            int64  `json:"created"`
+	Permalink          string `json:"permalink"`
+	PermalinkPublic    string `json:"permalink_public,omitempty"`
+	URLPrivate         string `json:"url_private,omitempty"`
+	URLPrivateDownload string `json:"url_private_download,omitempty"`
+}
+
+// SearchPaging contains pagination info
+type SearchPaging struct {
+	Count int `json:"count"`
+	Total int `json:"total"`
+	Page  int `json:"page"`
+	Pages int `json:"pages"`
+}
+
+// MessageGroup contains message search results
+type MessageGroup struct {
+	Total   int           `json:"total"`
+	Paging  SearchPaging  `json:"paging"`
+	Matches []SearchMatch `json:"matches"`
+}
+
+// FileGroup contains file search results
+type FileGroup struct {
+	Total   int          `json:"total"`
+	Paging  SearchPaging `json:"paging"`
+	Matches []FileMatch  `json:"matches"`
+}
+
+// SearchResult contains search results
+type SearchResult struct {
+	Query    string        `json:"query"`
+	Messages *MessageGroup `json:"messages,omitempty"`
+	Files    *FileGroup    `json:"files,omitempty"`
+}
+
+// ListChannels returns channels up to the specified limit (handles pagination automatically)
+func (c *Client) ListChannels(types string, excludeArchived bool, limit int) ([]Channel, error) {
+	return c.ListChannelsContext(context.Background(), types, excludeArchived, limit)
+}
+
+// ListChannelsContext is ListChannels with a context
+func (c *Client) ListChannelsContext(ctx context.Context, types string, excludeArchived bool, limit int) ([]Channel, error) {
+	return c.listConversations(ctx, "conversations.list", types, excludeArchived, limit)
+}
+
+// ListMemberChannels returns the channels the token's user is a member of,
+// up to the specified limit (handles pagination automatically)
+func (c *Client) ListMemberChannels(types string, excludeArchived bool, limit int) ([]Channel, error) {
+	return c.ListMemberChannelsContext(context.Background(), types, excludeArchived, limit)
+}
+
+// ListMemberChannelsContext is ListMemberChannels with a context
+func (c *Client) ListMemberChannelsContext(ctx context.Context, types string, excludeArchived bool, limit int) ([]Channel, error) {
+	return c.listConversations(ctx, "users.conversations", types, excludeArchived, limit)
+}
+
+func (c *Client) listConversations(ctx context.Context, method, types string, excludeArchived bool, limit int) ([]Channel, error) {
+	var allChannels []Channel
+	cursor := ""
+	remaining := limit
+
+	for remaining > 0 {
+		params := url.Values{}
+		params.Set("exclude_archived", fmt.Sprintf("%t", excludeArchived))
+		// Request up to 200 at a time (Slack recommended max), or remaining if smaller
+		batchSize := remaining
+		if batchSize > 200 {
+			batchSize = 200
+		}
+		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		if types != "" {
+			params.Set("types", types)
+		}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.get(ctx, method, params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Channels         []Channel `json:"channels"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		allChannels = append(allChannels, result.Channels...)
+		remaining -= len(result.Channels)
+
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	// Trim to exact limit if we got more
+	if len(allChannels) > limit {
+		allChannels = allChannels[:limit]
+	}
+
+	return allChannels, nil
+}
+
+// GetChannelInfo returns channel details
+func (c *Client) GetChannelInfo(channelID string) (*Channel, error) {
+	return c.GetChannelInfoContext(context.Background(), channelID)
+}
+
+// GetChannelInfoContext is GetChannelInfo with a context
+func (c *Client) GetChannelInfoContext(ctx context.Context, channelID string) (*Channel, error) {
+	params := url.Values{}
+	params.Set("channel", channelID)
+
+	body, err := c.get(ctx, "conversations.info", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Channel Channel `json:"channel"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Channel, nil
+}
+
+// ListUsers returns users up to the specified limit (handles pagination automatically)
+func (c *Client) ListUsers(limit int) ([]User, error) {
+	return c.ListUsersContext(context.Background(), limit)
+}
+
+// ListUsersContext is ListUsers with a context
+func (c *Client) ListUsersContext(ctx context.Context, limit int) ([]User, error) {
+	var allUsers []User
+	cursor := ""
+	remaining := limit
+
+	for remaining > 0 {
+		params := url.Values{}
+		// Request up to 200 at a time (Slack recommended max), or remaining if smaller
+		batchSize := remaining
+		if batchSize > 200 {
+			batchSize = 200
+		}
+		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.get(ctx, "users.list", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Members          []User `json:"members"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		allUsers = append(allUsers, result.Members...)
+		remaining -= len(result.Members)
+
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	// Trim to exact limit if we got more
+	if len(allUsers) > limit {
+		allUsers = allUsers[:limit]
+	}
+
+	return allUsers, nil
+}
+
+// GetUserInfo returns user details
+func (c *Client) GetUserInfo(userID string) (*User, error) {
+	return c.GetUserInfoContext(context.Background(), userID)
+}
+
+// GetUserInfoContext is GetUserInfo with a context
+func (c *Client) GetUserInfoContext(ctx context.Context, userID string) (*User, error) {
+	params := url.Values{}
+	params.Set("user", userID)
+
+	body, err := c.get(ctx, "users.info", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		User User `json:"user"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.User, nil
+}
+
+// SendMessage sends a message to a channel.
+// Text can be empty if blocks are provided (Slack API allows this).
+func (c *Client) SendMessage(channel, text, threadTS string, blocks []interface{}) (*Message, error) {
+	return c.SendMessageContext(context.Background(), channel, text, threadTS, blocks)
+}
+
+// SendMessageContext is SendMessage with a context
+func (c *Client) SendMessageContext(ctx context.Context, channel, text, threadTS string, blocks []interface{}) (*Message, error) {
+	data := map[string]interface{}{
+		"channel": channel,
+	}
+	// Only include text if non-empty (Slack allows omitting text when blocks are provided)
+	if text != "" {
+		data["text"] = text
+	}
+	if threadTS != "" {
+		data["thread_ts"] = threadTS
+	}
+	if len(blocks) > 0 {
+		data["blocks"] = blocks
+	}
+
+	body, err := c.post(ctx, "chat.postMessage", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Message Message `json:"message"`
+		TS      string  `json:"ts"`
+		Channel string  `json:"channel"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	result.Message.TS = result.TS
+	return &result.Message, nil
+}
+
+// SendEphemeral posts a message only the given user can see, in a channel
+// they belong to. Ephemeral messages are not stored, so Slack returns no
+// message, only the timestamp it was shown at.
+func (c *Client) SendEphemeral(channel, user, text, threadTS string, blocks []interface{}) (string, error) {
+	return c.SendEphemeralContext(context.Background(), channel, user, text, threadTS, blocks)
+}
+
+// SendEphemeralContext is SendEphemeral with a context
+func (c *Client) SendEphemeralContext(ctx context.Context, channel, user, text, threadTS string, blocks []interface{}) (string, error) {
+	data := map[string]interface{}{
+		"channel": channel,
+		"user":    user,
+	}
+	if text != "" {
+		data["text"] = text
+	}
+	if threadTS != "" {
+		data["thread_ts"] = threadTS
+	}
+	if len(blocks) > 0 {
+		data["blocks"] = blocks
+	}
+
+	body, err := c.post(ctx, "chat.postEphemeral", data)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		MessageTS string `json:"message_ts"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	return result.MessageTS, nil
+}
+
+// SendMeMessage posts a /me message, shown in italics as an action by the
+// sender. Slack accepts text only for these.
+func (c *Client) SendMeMessage(channel, text string) (*Message, error) {
+	return c.SendMeMessageContext(context.Background(), channel, text)
+}
+
+// SendMeMessageContext is SendMeMessage with a context
+func (c *Client) SendMeMessageContext(ctx context.Context, channel, text string) (*Message, error) {
+	data := map[string]interface{}{
+		"channel": channel,
+		"text":    text,
+	}
+
+	body, err := c.post(ctx, "chat.meMessage", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Channel string `json:"channel"`
+		TS      string `json:"ts"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &Message{Type: "message", Text: text, TS: result.TS}, nil
+}
+
+// UpdateMessage updates an existing message
+func (c *Client) UpdateMessage(channel, ts, text string, blocks []interface{}) error {
+	return c.UpdateMessageContext(context.Background(), channel, ts, text, blocks)
+}
+
+// UpdateMessageContext is UpdateMessage with a context
+func (c *Client) UpdateMessageContext(ctx context.Context, channel, ts, text string, blocks []interface{}) error {
+	data := map[string]interface{}{
+		"channel": channel,
+		"ts":      ts,
+		"text":    text,
+	}
+	if len(blocks) > 0 {
+		data["blocks"] = blocks
+	}
+
+	_, err := c.post(ctx, "chat.update", data)
+	return err
+}
+
+// DeleteMessage deletes a message
+func (c *Client) DeleteMessage(channel, ts string) error {
+	return c.DeleteMessageContext(context.Background(), channel, ts)
+}
+
+// DeleteMessageContext is DeleteMessage with a context
+func (c *Client) DeleteMessageContext(ctx context.Context, channel, ts string) error {
+	data := map[string]interface{}{
+		"channel": channel,
+		"ts":      ts,
+	}
+
+	_, err := c.post(ctx, "chat.delete", data)
+	return err
+}
+
+// GetChannelHistory returns message history (handles pagination to reach requested limit)
+func (c *Client) GetChannelHistory(channel string, limit int, oldest, latest string) ([]Message, error) {
+	return c.GetChannelHistoryContext(context.Background(), channel, limit, oldest, latest)
+}
+
+// GetChannelHistoryContext is GetChannelHistory with a context
+func (c *Client) GetChannelHistoryContext(ctx context.Context, channel string, limit int, oldest, latest string) ([]Message, error) {
+	var allMessages []Message
+	cursor := ""
+	remaining := limit
+
+	for remaining > 0 {
+		params := url.Values{}
+		params.Set("channel", channel)
+		// Request up to 200 at a time (Slack recommended max)
+		batchSize := remaining
+		if batchSize > 200 {
+			batchSize = 200
+		}
+		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		if oldest != "" {
+			params.Set("oldest", oldest)
+		}
+		if latest != "" {
+			params.Set("latest", latest)
+		}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.get(ctx, "conversations.history", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Messages         []Message `json:"messages"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		allMessages = append(allMessages, result.Messages...)
+		remaining -= len(result.Messages)
+
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	// Trim to exact limit if we got more
+	if len(allMessages) > limit {
+		allMessages = allMessages[:limit]
+	}
+
+	return allMessages, nil
+}
+
+// GetThreadReplies returns replies to a thread (handles pagination to reach requested limit)
+func (c *Client) GetThreadReplies(channel, threadTS string, limit int) ([]Message, error) {
+	return c.GetThreadRepliesContext(context.Background(), channel, threadTS, limit)
+}
+
+// GetThreadRepliesContext is GetThreadReplies with a context
+func (c *Client) GetThreadRepliesContext(ctx context.Context, channel, threadTS string, limit int) ([]Message, error) {
+	var allMessages []Message
+	cursor := ""
+	remaining := limit
+
+	for remaining > 0 {
+		params := url.Values{}
+		params.Set("channel", channel)
+		params.Set("ts", threadTS)
+		// Request up to 200 at a time (Slack recommended max)
+		batchSize := remaining
+		if batchSize > 200 {
+			batchSize = 200
+		}
+		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.get(ctx, "conversations.replies", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Messages         []Message `json:"messages"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		allMessages = append(allMessages, result.Messages...)
+		remaining -= len(result.Messages)
+
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	// Trim to exact limit if we got more
+	if len(allMessages) > limit {
+		allMessages = allMessages[:limit]
+	}
+
+	return allMessages, nil
+}
+
+// AddReaction adds an emoji reaction
+func (c *Client) AddReaction(channel, timestamp, name string) error {
+	return c.AddReactionContext(context.Background(), channel, timestamp, name)
+}
+
+// AddReactionContext is AddReaction with a context
+func (c *Client) AddReactionContext(ctx context.Context, channel, timestamp, name string) error {
+	data := map[string]interface{}{
+		"channel":   channel,
+		"timestamp": timestamp,
+		"name":      name,
+	}
+
+	_, err := c.post(ctx, "reactions.add", data)
+	return err
+}
+
+// RemoveReaction removes an emoji reaction
+func (c *Client) RemoveReaction(channel, timestamp, name string) error {
+	return c.RemoveReactionContext(context.Background(), channel, timestamp, name)
+}
+
+// RemoveReactionContext is RemoveReaction with a context
+func (c *Client) RemoveReactionContext(ctx context.Context, channel, timestamp, name string) error {
+	data := map[string]interface{}{
+		"channel":   channel,
+		"timestamp": timestamp,
+		"name":      name,
+	}
+
+	_, err := c.post(ctx, "reactions.remove", data)
+	return err
+}
+
+// GetTeamInfo returns workspace info
+func (c *Client) GetTeamInfo() (*Team, error) {
+	return c.GetTeamInfoContext(context.Background())
+}
+
+// GetTeamInfoContext is GetTeamInfo with a context
+func (c *Client) GetTeamInfoContext(ctx context.Context) (*Team, error) {
+	body, err := c.get(ctx, "team.info", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Team Team `json:"team"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Team, nil
+}
+
+// AuthTestResponse represents the response from auth.test API
+type AuthTestResponse struct {
+	Team   string `json:"team"`
+	User   string `json:"user"`
+	TeamID string `json:"team_id"`
+	UserID string `json:"user_id"`
+	BotID  string `json:"bot_id,omitempty"`
+
+	// Scopes granted to the token, from the X-OAuth-Scopes response header
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// AuthTest verifies authentication and returns identity info
+func (c *Client) AuthTest() (*AuthTestResponse, error) {
+	return c.AuthTestContext(context.Background())
+}
+
+// AuthTestContext is AuthTest with a context
+func (c *Client) AuthTestContext(ctx context.Context) (*AuthTestResponse, error) {
+	body, header, err := c.postWithHeader(ctx, "auth.test", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result AuthTestResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	result.Scopes = parseScopes(header.Get("X-OAuth-Scopes"))
+
+	return &result, nil
+}
+
+// CreateChannel creates a new channel
+func (c *Client) CreateChannel(name string, isPrivate bool) (*Channel, error) {
+	return c.CreateChannelContext(context.Background(), name, isPrivate)
+}
+
+// CreateChannelContext is CreateChannel with a context
+func (c *Client) CreateChannelContext(ctx context.Context, name string, isPrivate bool) (*Channel, error) {
+	data := map[string]interface{}{
+		"name":       name,
+		"is_private": isPrivate,
+	}
+
+	body, err := c.post(ctx, "conversations.create", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Channel Channel `json:"channel"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Channel, nil
+}
+
+// RenameChannel renames a channel
+func (c *Client) RenameChannel(channel, name string) (*Channel, error) {
+	return c.RenameChannelContext(context.Background(), channel, name)
+}
+
+// RenameChannelContext is RenameChannel with a context
+func (c *Client) RenameChannelContext(ctx context.Context, channel, name string) (*Channel, error) {
+	data := map[string]interface{}{
+		"channel": channel,
+		"name":    name,
+	}
+
+	body, err := c.post(ctx, "conversations.rename", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Channel Channel `json:"channel"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Channel, nil
+}
+
+// ArchiveChannel archives a channel
+func (c *Client) ArchiveChannel(channel string) error {
+	return c.ArchiveChannelContext(context.Background(), channel)
+}
+
+// ArchiveChannelContext is ArchiveChannel with a context
+func (c *Client) ArchiveChannelContext(ctx context.Context, channel string) error {
+	data := map[string]interface{}{
+		"channel": channel,
+	}
+	_, err := c.post(ctx, "conversations.archive", data)
+	return err
+}
+
+// UnarchiveChannel unarchives a channel
+func (c *Client) UnarchiveChannel(channel string) error {
+	return c.UnarchiveChannelContext(context.Background(), channel)
+}
+
+// UnarchiveChannelContext is UnarchiveChannel with a context
+func (c *Client) UnarchiveChannelContext(ctx context.Context, channel string) error {
+	data := map[string]interface{}{
+		"channel": channel,
+	}
+	_, err := c.post(ctx, "conversations.unarchive", data)
+	return err
+}
+
+// SetChannelTopic sets the channel topic
+func (c *Client) SetChannelTopic(channel, topic string) error {
+	return c.SetChannelTopicContext(context.Background(), channel, topic)
+}
+
+// SetChannelTopicContext is SetChannelTopic with a context
+func (c *Client) SetChannelTopicContext(ctx context.Context, channel, topic string) error {
+	data := map[string]interface{}{
+		"channel": channel,
+		"topic":   topic,
+	}
+	_, err := c.post(ctx, "conversations.setTopic", data)
+	return err
+}
+
+// SetChannelPurpose sets the channel purpose
+func (c *Client) SetChannelPurpose(channel, purpose string) error {
+	return c.SetChannelPurposeContext(context.Background(), channel, purpose)
+}
+
+// SetChannelPurposeContext is SetChannelPurpose with a context
+func (c *Client) SetChannelPurposeContext(ctx context.Context, channel, purpose string) error {
+	data := map[string]interface{}{
+		"channel": channel,
+		"purpose": purpose,
+	}
+	_, err := c.post(ctx, "conversations.setPurpose", data)
+	return err
+}
+
+// InviteToChannel invites users to a channel
+func (c *Client) InviteToChannel(channel string, users []string) error {
+	return c.InviteToChannelContext(context.Background(), channel, users)
+}
+
+// InviteToChannelContext is InviteToChannel with a context
+func (c *Client) InviteToChannelContext(ctx context.Context, channel string, users []string) error {
+	usersStr := ""
+	for i, u := range users {
+		if i > 0 {
+			usersStr += ","
+		}
+		usersStr += u
+	}
+
+	data := map[string]interface{}{
+		"channel": channel,
+		"users":   usersStr,
+	}
+	_, err := c.post(ctx, "conversations.invite", data)
+	return err
+}
+
+// ListChannelMembers returns the IDs of a channel's members up to the
+// specified limit (handles pagination automatically)
+func (c *Client) ListChannelMembers(channel string, limit int) ([]string, error) {
+	return c.ListChannelMembersContext(context.Background(), channel, limit)
+}
+
+// ListChannelMembersContext is ListChannelMembers with a context
+func (c *Client) ListChannelMembersContext(ctx context.Context, channel string, limit int) ([]string, error) {
+	var allMembers []string
+	cursor := ""
+	remaining := limit
+
+	for remaining > 0 {
+		params := url.Values{}
+		params.Set("channel", channel)
+		batchSize := remaining
+		if batchSize > 200 {
+			batchSize = 200
+		}
+		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.get(ctx, "conversations.members", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Members          []string `json:"members"`
+			ResponseMetadata struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		allMembers = append(allMembers, result.Members...)
+		remaining -= len(result.Members)
+
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	if len(allMembers) > limit {
+		allMembers = allMembers[:limit]
+	}
+
+	return allMembers, nil
+}
+
+// KickFromChannel removes a user from a channel
+func (c *Client) KickFromChannel(channel, user string) error {
+	return c.KickFromChannelContext(context.Background(), channel, user)
+}
+
+// KickFromChannelContext is KickFromChannel with a context
+func (c *Client) KickFromChannelContext(ctx context.Context, channel, user string) error {
+	data := map[string]interface{}{
+		"channel": channel,
+		"user":    user,
+	}
+	_, err := c.post(ctx, "conversations.kick", data)
+	return err
+}
+
+// JoinChannel joins a public channel as the token's user
+func (c *Client) JoinChannel(channel string) (*Channel, error) {
+	return c.JoinChannelContext(context.Background(), channel)
+}
+
+// JoinChannelContext is JoinChannel with a context
+func (c *Client) JoinChannelContext(ctx context.Context, channel string) (*Channel, error) {
+	data := map[string]interface{}{
+		"channel": channel,
+	}
+
+	body, err := c.post(ctx, "conversations.join", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Channel Channel `json:"channel"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Channel, nil
+}
+
+// LeaveChannel leaves a channel as the token's user
+func (c *Client) LeaveChannel(channel string) error {
+	return c.LeaveChannelContext(context.Background(), channel)
+}
+
+// LeaveChannelContext is LeaveChannel with a context
+func (c *Client) LeaveChannelContext(ctx context.Context, channel string) error {
+	data := map[string]interface{}{
+		"channel": channel,
+	}
+	_, err := c.post(ctx, "conversations.leave", data)
+	return err
+}
+
+// --- Search Methods (require user token) ---
+
+// SearchMessages searches for messages matching a query
+func (c *Client) SearchMessages(query string, count, page int, sort, sortDir string, highlight bool) (*SearchResult, error) {
+	return c.SearchMessagesContext(context.Background(), query, count, page, sort, sortDir, highlight)
+}
+
+// SearchMessagesContext is SearchMessages with a context
+func (c *Client) SearchMessagesContext(ctx context.Context, query string, count, page int, sort, sortDir string, highlight bool) (*SearchResult, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("count", fmt.Sprintf("%d", count))
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("sort", sort)
+	params.Set("sort_dir", sortDir)
+	if highlight {
+		params.Set("highlight", "true")
+	}
+
+	body, err := c.get(ctx, "search.messages", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SearchResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	result.Query = query
+	return &result, nil
+}
+
+// SearchFiles searches for files matching a query
+func (c *Client) SearchFiles(query string, count, page int, sort, sortDir string, highlight bool) (*SearchResult, error) {
+	return c.SearchFilesContext(context.Background(), query, count, page, sort, sortDir, highlight)
+}
+
+// SearchFilesContext is SearchFiles with a context
+func (c *Client) SearchFilesContext(ctx context.Context, query string, count, page int, sort, sortDir string, highlight bool) (*SearchResult, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("count", fmt.Sprintf("%d", count))
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("sort", sort)
+	params.Set("sort_dir", sortDir)
+	if highlight {
+		params.Set("highlight", "true")
+	}
+
+	body, err := c.get(ctx, "search.files", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SearchResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	result.Query = query
+	return &result, nil
+}
+
+// SearchAll searches for both messages and files matching a query
+func (c *Client) SearchAll(query string, count, page int, sort, sortDir string, highlight bool) (*SearchResult, error) {
+	return c.SearchAllContext(context.Background(), query, count, page, sort, sortDir, highlight)
+}
+
+// SearchAllContext is SearchAll with a context
+func (c *Client) SearchAllContext(ctx context.Context, query string, count, page int, sort, sortDir string, highlight bool) (*SearchResult, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("count", fmt.Sprintf("%d", count))
+	params.Set("page", fmt.Sprintf("%d", page))
+	params.Set("sort", sort)
+	params.Set("sort_dir", sortDir)
+	if highlight {
+		params.Set("highlight", "true")
+	}
+
+	body, err := c.get(ctx, "search.all", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result SearchResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	result.Query = query
+	return &result, nil
+}
@@ -0,0 +1,157 @@
+package slack
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultMaxLoggedBody is how much of each body LoggingTransport logs when
+// MaxBody is zero
+const DefaultMaxLoggedBody = 2048
+
+// LoggingTransport is an http.RoundTripper that logs each Slack API call at
+// debug level: the method, parameters, HTTP status, latency, rate-limit
+// headers and a truncated JSON response body. Tokens, client secrets and
+// OAuth codes are redacted from everything it logs, including the
+// Authorization header.
+//
+//	httpClient := &http.Client{Transport: &slack.LoggingTransport{Logger: logger}}
+//	c := slack.New(slack.WithHTTPClient(httpClient), slack.WithToken(token))
+type LoggingTransport struct {
+	// Next carries the request; http.DefaultTransport if nil
+	Next http.RoundTripper
+	// Logger receives the records; slog.Default() if nil
+	Logger *slog.Logger
+	// MaxBody truncates logged bodies; DefaultMaxLoggedBody if zero, no
+	// limit if negative
+	MaxBody int
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	logger := t.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	ctx := req.Context()
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return next.RoundTrip(req)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", path.Base(req.URL.Path)),
+		slog.String("http_method", req.Method),
+	}
+	if req.URL.RawQuery != "" {
+		attrs = append(attrs, slog.String("params", t.truncate(RedactValues(req.URL.Query()).Encode())))
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		// As with responses, file uploads are not buffered
+		if contentType := req.Header.Get("Content-Type"); loggable(contentType) {
+			body, err := io.ReadAll(req.Body)
+			_ = req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			attrs = append(attrs, slog.String("body", t.truncate(string(RedactBody(body, contentType)))))
+		} else {
+			attrs = append(attrs, slog.String("body_content_type", contentType), slog.Int64("body_content_length", req.ContentLength))
+		}
+	}
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		attrs = append(attrs, slog.String("authorization", redactAuthorization(auth)))
+	}
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(ctx, slog.LevelDebug, "slack request failed", attrs...)
+		return nil, err
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	var limitHeaders []string
+	for key := range resp.Header {
+		if isRateLimitHeader(key) {
+			limitHeaders = append(limitHeaders, key)
+		}
+	}
+	sort.Strings(limitHeaders)
+	for _, key := range limitHeaders {
+		attrs = append(attrs, slog.String(strings.ToLower(key), resp.Header.Get(key)))
+	}
+	if id := resp.Header.Get("X-Slack-Req-Id"); id != "" {
+		attrs = append(attrs, slog.String("request_id", id))
+	}
+
+	// Only JSON bodies are buffered; file downloads keep streaming
+	if contentType := resp.Header.Get("Content-Type"); loggable(contentType) {
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		attrs = append(attrs, slog.String("response", t.truncate(RedactSecrets(string(body)))))
+	} else {
+		attrs = append(attrs, slog.String("content_type", contentType), slog.Int64("content_length", resp.ContentLength))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "slack request", attrs...)
+	return resp, nil
+}
+
+func (t *LoggingTransport) truncate(s string) string {
+	max := t.MaxBody
+	if max == 0 {
+		max = DefaultMaxLoggedBody
+	}
+	s = strings.TrimSpace(s)
+	if max < 0 || len(s) <= max {
+		return s
+	}
+	// Cut on a rune boundary so the log stays valid UTF-8
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max] + "...(truncated)"
+}
+
+// loggable reports whether a body of this content type is logged: JSON and
+// form-encoded API calls, but not file content
+func loggable(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/json") ||
+		strings.HasPrefix(contentType, "application/x-www-form-urlencoded")
+}
+
+// redactAuthorization keeps the scheme and token type (xoxb, xoxp) of an
+// Authorization header and drops the secret
+func redactAuthorization(auth string) string {
+	scheme, token, ok := strings.Cut(auth, " ")
+	if !ok {
+		return "[REDACTED]"
+	}
+	if prefix, _, ok := strings.Cut(token, "-"); ok && len(prefix) <= 10 {
+		return scheme + " " + prefix + "-[REDACTED]"
+	}
+	return scheme + " [REDACTED]"
+}
+
+func isRateLimitHeader(key string) bool {
+	key = strings.ToLower(key)
+	return key == "retry-after" || strings.HasPrefix(key, "x-ratelimit") || strings.HasPrefix(key, "x-rate-limit")
+}
@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// DNDStatus is a user's Do Not Disturb state: their scheduled DND window
+// and any snooze they started by hand. Times are Unix seconds.
+type DNDStatus struct {
+	DNDEnabled      bool  `json:"dnd_enabled"`
+	NextDNDStartTS  int64 `json:"next_dnd_start_ts,omitempty"`
+	NextDNDEndTS    int64 `json:"next_dnd_end_ts,omitempty"`
+	SnoozeEnabled   bool  `json:"snooze_enabled"`
+	SnoozeEndTime   int64 `json:"snooze_endtime,omitempty"`
+	SnoozeRemaining int64 `json:"snooze_remaining,omitempty"`
+}
+
+// GetDNDInfo returns a user's Do Not Disturb state. An empty user means the
+// token's owner.
+func (c *Client) GetDNDInfo(user string) (*DNDStatus, error) {
+	return c.GetDNDInfoContext(context.Background(), user)
+}
+
+// GetDNDInfoContext is GetDNDInfo with a context
+func (c *Client) GetDNDInfoContext(ctx context.Context, user string) (*DNDStatus, error) {
+	params := url.Values{}
+	if user != "" {
+		params.Set("user", user)
+	}
+
+	body, err := c.get(ctx, "dnd.info", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result DNDStatus
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetSnooze pauses notifications for the token's owner for the given number
+// of minutes
+func (c *Client) SetSnooze(minutes int) (*DNDStatus, error) {
+	return c.SetSnoozeContext(context.Background(), minutes)
+}
+
+// SetSnoozeContext is SetSnooze with a context
+func (c *Client) SetSnoozeContext(ctx context.Context, minutes int) (*DNDStatus, error) {
+	body, err := c.post(ctx, "dnd.setSnooze", map[string]interface{}{"num_minutes": minutes})
+	if err != nil {
+		return nil, err
+	}
+
+	var result DNDStatus
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// EndSnooze ends the token owner's snooze early
+func (c *Client) EndSnooze() error {
+	return c.EndSnoozeContext(context.Background())
+}
+
+// EndSnoozeContext is EndSnooze with a context
+func (c *Client) EndSnoozeContext(ctx context.Context) error {
+	_, err := c.post(ctx, "dnd.endSnooze", map[string]interface{}{})
+	return err
+}
@@ -0,0 +1,27 @@
+// Package slack is a Go client for the Slack Web API, used by the
+// slack-chat-api CLI and importable by other tools.
+//
+// Create a client with New and configure it with options:
+//
+//	c := slack.New(
+//		slack.WithToken(os.Getenv("SLACK_API_TOKEN")),
+//		slack.WithLogger(logger),
+//	)
+//	msg, err := c.SendMessage("C01234567", "Hello", "", nil)
+//
+// List methods follow Slack's cursor pagination until the requested limit is
+// reached. Rate-limited requests are retried according to the client's
+// RetryPolicy, and Slack errors are returned as *APIError.
+//
+// Every API method has a Context variant, such as SendMessageContext, that
+// takes a context.Context. Cancelling it aborts the request, any wait
+// before a retry, and any token refresh; the plain methods use
+// context.Background().
+//
+// # Compatibility
+//
+// The package follows semantic versioning with the module: exported
+// identifiers are not removed or changed incompatibly within a major
+// version. New methods, options and struct fields may be added in minor
+// releases.
+package slack
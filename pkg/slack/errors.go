@@ -0,0 +1,23 @@
+package slack
+
+import "fmt"
+
+// APIError is returned when Slack responds with ok=false
+type APIError struct {
+	Code string
+
+	// For missing_scope: the scope Slack wanted and the scopes the token has
+	Needed   string
+	Provided string
+
+	// AcceptedScopes lists any of the scopes that would have satisfied the
+	// method, from the X-Accepted-OAuth-Scopes response header
+	AcceptedScopes []string
+}
+
+func (e *APIError) Error() string {
+	if e.Needed != "" {
+		return fmt.Sprintf("slack API error: %s (needed: %s)", e.Code, e.Needed)
+	}
+	return "slack API error: " + e.Code
+}
@@ -0,0 +1,90 @@
+package slack_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// newExampleServer stands in for the Slack API in these examples
+func newExampleServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			fmt.Fprint(w, `{"ok":true,"channel":"C123","ts":"1700000000.000100","message":{"text":"Hello"}}`)
+		case "/conversations.list":
+			fmt.Fprint(w, `{"ok":true,"channels":[{"id":"C123","name":"general"},{"id":"C456","name":"random"}]}`)
+		default:
+			fmt.Fprint(w, `{"ok":false,"error":"channel_not_found"}`)
+		}
+	}))
+}
+
+func ExampleNew() {
+	server := newExampleServer()
+	defer server.Close()
+
+	c := slack.New(
+		slack.WithBaseURL(server.URL), // omit to use https://slack.com/api
+		slack.WithToken("xoxb-example"),
+	)
+
+	msg, err := c.SendMessage("C123", "Hello", "", nil)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(msg.TS)
+	// Output: 1700000000.000100
+}
+
+func ExampleClient_ListChannels() {
+	server := newExampleServer()
+	defer server.Close()
+
+	c := slack.New(slack.WithBaseURL(server.URL), slack.WithToken("xoxb-example"))
+
+	channels, err := c.ListChannels("public_channel", true, 100)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, ch := range channels {
+		fmt.Println(ch.ID, ch.Name)
+	}
+	// Output:
+	// C123 general
+	// C456 random
+}
+
+func ExampleAPIError() {
+	server := newExampleServer()
+	defer server.Close()
+
+	c := slack.New(slack.WithBaseURL(server.URL), slack.WithToken("xoxb-example"))
+
+	_, err := c.GetChannelInfo("C999")
+	var apiErr *slack.APIError
+	if errors.As(err, &apiErr) {
+		fmt.Println(apiErr.Code)
+	}
+	// Output: channel_not_found
+}
+
+func ExampleWithRetryPolicy() {
+	c := slack.New(
+		slack.WithToken("xoxb-example"),
+		slack.WithRetryPolicy(slack.RetryPolicy{
+			MaxRetries:        5,
+			BaseDelay:         500 * time.Millisecond,
+			MaxDelay:          time.Minute,
+			RetryServerErrors: true,
+		}),
+	)
+	fmt.Println(c.BaseURL())
+	// Output: https://slack.com/api
+}
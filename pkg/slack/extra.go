@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Message, File, BotProfile and Reaction keep the fields Slack sends that they have no field for,
+// so exports encode back to JSON with everything Slack returned. These
+// helpers do the bookkeeping.
+
+// jsonFields returns the JSON names of the fields of struct v
+func jsonFields(v interface{}) map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+// extraFields returns the fields of the JSON object data that are not in
+// known, or nil if there are none
+func extraFields(data []byte, known map[string]bool) (map[string]json.RawMessage, error) {
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for name := range all {
+		if known[name] {
+			delete(all, name)
+		}
+	}
+	if len(all) == 0 {
+		return nil, nil
+	}
+	return all, nil
+}
+
+// appendExtra adds the extra fields not in known to the encoded JSON
+// object data, after its own fields and in name order
+func appendExtra(data []byte, extra map[string]json.RawMessage, known map[string]bool) ([]byte, error) {
+	names := make([]string, 0, len(extra))
+	for name := range extra {
+		if !known[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return data, nil
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, name := range names {
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(extra[name])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
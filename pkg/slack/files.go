@@ -0,0 +1,374 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// File represents a file shared in Slack. Fields Slack sends that have no
+// field here are kept in Extra, as for Message.
+type File struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Title              string   `json:"title"`
+	Mimetype           string   `json:"mimetype"`
+	Filetype           string   `json:"filetype"`
+	PrettyType         string   `json:"pretty_type,omitempty"`
+	User               string   `json:"user"`
+	Size               int64    `json:"size"`
+	Created            int64    `json:"created"`
+	IsPublic           bool     `json:"is_public"`
+	URLPrivate         string   `json:"url_private,omitempty"`
+	URLPrivateDownload string   `json:"url_private_download,omitempty"`
+	Permalink          string   `json:"permalink,omitempty"`
+	Channels           []string `json:"channels,omitempty"`
+	Groups             []string `json:"groups,omitempty"`
+	IMs                []string `json:"ims,omitempty"`
+
+	// Extra holds the fields not covered above, as Slack sent them
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// file has File's fields without its JSON methods
+type file File
+
+// fileFields are the JSON names of File's fields
+var fileFields = jsonFields(file{})
+
+// UnmarshalJSON decodes a file, keeping unknown fields in Extra
+func (f *File) UnmarshalJSON(data []byte) error {
+	var known file
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, fileFields)
+	if err != nil {
+		return err
+	}
+	known.Extra = extra
+	*f = File(known)
+	return nil
+}
+
+// MarshalJSON encodes a file with its Extra fields after the known ones
+func (f File) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(file(f))
+	if err != nil || len(f.Extra) == 0 {
+		return data, err
+	}
+	return appendExtra(data, f.Extra, fileFields)
+}
+
+// FileUpload is a file to upload with UploadFiles
+type FileUpload struct {
+	// Name is the file name shown in Slack
+	Name string
+	// Title defaults to Name
+	Title string
+	// Content is read once; Size must be its exact length in bytes
+	Content io.Reader
+	Size    int64
+}
+
+// UploadOptions controls where uploaded files are shared
+type UploadOptions struct {
+	// Channel to share the files in; empty uploads them privately
+	Channel string
+	// InitialComment is posted as a message with the files
+	InitialComment string
+	// ThreadTS shares the files as a reply in a thread
+	ThreadTS string
+}
+
+// UploadFiles uploads files using files.getUploadURLExternal and
+// files.completeUploadExternal, sharing them together in one message when
+// opts.Channel is set.
+func (c *Client) UploadFiles(files []FileUpload, opts UploadOptions) ([]File, error) {
+	return c.UploadFilesContext(context.Background(), files, opts)
+}
+
+// UploadFilesContext is UploadFiles with a context
+func (c *Client) UploadFilesContext(ctx context.Context, files []FileUpload, opts UploadOptions) ([]File, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no files to upload")
+	}
+
+	completed := make([]map[string]string, 0, len(files))
+	for _, f := range files {
+		uploadURL, fileID, err := c.GetUploadURLExternalContext(ctx, f.Name, f.Size)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.uploadContent(ctx, uploadURL, f.Content, f.Size); err != nil {
+			return nil, fmt.Errorf("uploading %s: %w", f.Name, err)
+		}
+		title := f.Title
+		if title == "" {
+			title = f.Name
+		}
+		completed = append(completed, map[string]string{"id": fileID, "title": title})
+	}
+
+	data := map[string]interface{}{
+		"files": completed,
+	}
+	if opts.Channel != "" {
+		data["channel_id"] = opts.Channel
+	}
+	if opts.InitialComment != "" {
+		data["initial_comment"] = opts.InitialComment
+	}
+	if opts.ThreadTS != "" {
+		data["thread_ts"] = opts.ThreadTS
+	}
+
+	body, err := c.post(ctx, "files.completeUploadExternal", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Files []File `json:"files"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result.Files, nil
+}
+
+// GetUploadURLExternal reserves a file upload, returning the URL to send the
+// content to and the new file's ID
+func (c *Client) GetUploadURLExternal(filename string, length int64) (uploadURL, fileID string, err error) {
+	return c.GetUploadURLExternalContext(context.Background(), filename, length)
+}
+
+// GetUploadURLExternalContext is GetUploadURLExternal with a context
+func (c *Client) GetUploadURLExternalContext(ctx context.Context, filename string, length int64) (uploadURL, fileID string, err error) {
+	params := url.Values{}
+	params.Set("filename", filename)
+	params.Set("length", fmt.Sprintf("%d", length))
+
+	body, err := c.get(ctx, "files.getUploadURLExternal", params)
+	if err != nil {
+		return "", "", err
+	}
+
+	var result struct {
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", "", err
+	}
+	return result.UploadURL, result.FileID, nil
+}
+
+// uploadContent sends file content to an upload URL from
+// files.getUploadURLExternal
+func (c *Client) uploadContent(ctx context.Context, uploadURL string, content io.Reader, size int64) (err error) {
+	t := c.newTransfer(ctx)
+	defer t.done()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", uploadURL, t.reader(content))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := t.do(c, req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("upload failed: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// GetFileInfo returns file details
+func (c *Client) GetFileInfo(fileID string) (*File, error) {
+	return c.GetFileInfoContext(context.Background(), fileID)
+}
+
+// GetFileInfoContext is GetFileInfo with a context
+func (c *Client) GetFileInfoContext(ctx context.Context, fileID string) (*File, error) {
+	params := url.Values{}
+	params.Set("file", fileID)
+
+	body, err := c.get(ctx, "files.info", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		File File `json:"file"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result.File, nil
+}
+
+// ListFiles returns files up to the specified limit, newest first (handles
+// pagination automatically). channel, user and types filter the results
+// when set.
+func (c *Client) ListFiles(channel, user, types string, limit int) ([]File, error) {
+	return c.ListFilesContext(context.Background(), channel, user, types, limit)
+}
+
+// ListFilesContext is ListFiles with a context
+func (c *Client) ListFilesContext(ctx context.Context, channel, user, types string, limit int) ([]File, error) {
+	var allFiles []File
+	page := 1
+
+	for len(allFiles) < limit {
+		params := url.Values{}
+		count := limit - len(allFiles)
+		if count > 200 {
+			count = 200
+		}
+		params.Set("count", fmt.Sprintf("%d", count))
+		params.Set("page", fmt.Sprintf("%d", page))
+		if channel != "" {
+			params.Set("channel", channel)
+		}
+		if user != "" {
+			params.Set("user", user)
+		}
+		if types != "" {
+			params.Set("types", types)
+		}
+
+		body, err := c.get(ctx, "files.list", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Files  []File       `json:"files"`
+			Paging SearchPaging `json:"paging"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		allFiles = append(allFiles, result.Files...)
+		if len(result.Files) == 0 || result.Paging.Page >= result.Paging.Pages {
+			break
+		}
+		page++
+	}
+
+	if len(allFiles) > limit {
+		allFiles = allFiles[:limit]
+	}
+	return allFiles, nil
+}
+
+// DeleteFile deletes a file
+func (c *Client) DeleteFile(fileID string) error {
+	return c.DeleteFileContext(context.Background(), fileID)
+}
+
+// DeleteFileContext is DeleteFile with a context
+func (c *Client) DeleteFileContext(ctx context.Context, fileID string) error {
+	data := map[string]interface{}{
+		"file": fileID,
+	}
+	_, err := c.post(ctx, "files.delete", data)
+	return err
+}
+
+// DownloadFile streams a file's url_private or url_private_download to w,
+// authenticating with the client's token, and returns the bytes written
+func (c *Client) DownloadFile(fileURL string, w io.Writer) (int64, error) {
+	return c.DownloadFileContext(context.Background(), fileURL, w)
+}
+
+// DownloadFileContext is DownloadFile with a context
+func (c *Client) DownloadFileContext(ctx context.Context, fileURL string, w io.Writer) (int64, error) {
+	return c.DownloadFileRangeContext(ctx, fileURL, 0, w)
+}
+
+// DownloadFileRange is DownloadFile starting offset bytes into the file, for
+// resuming an interrupted download. w receives the file from offset on even
+// if the server ignores the range request.
+func (c *Client) DownloadFileRange(fileURL string, offset int64, w io.Writer) (n int64, err error) {
+	return c.DownloadFileRangeContext(context.Background(), fileURL, offset, w)
+}
+
+// DownloadFileRangeContext is DownloadFileRange with a context
+func (c *Client) DownloadFileRangeContext(ctx context.Context, fileURL string, offset int64, w io.Writer) (n int64, err error) {
+	if c.tokens == nil {
+		return 0, errors.New("slack: no token configured")
+	}
+	token, err := sourceToken(ctx, c.tokens)
+	if err != nil {
+		return 0, err
+	}
+
+	t := c.newTransfer(ctx)
+	defer t.done()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fileURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := t.do(c, req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}()
+
+	switch {
+	case offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// Nothing left to download
+		return 0, nil
+	case offset > 0 && resp.StatusCode == http.StatusPartialContent:
+	case resp.StatusCode != http.StatusOK:
+		return 0, fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
+	}
+	// Without files:read Slack serves its sign-in page instead of the file.
+	// Real downloads are always sent as attachments.
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") && resp.Header.Get("Content-Disposition") == "" {
+		return 0, errors.New("download failed: Slack returned a web page instead of the file (the token may lack the files:read scope)")
+	}
+
+	body := t.reader(resp.Body)
+	if offset > 0 && resp.StatusCode == http.StatusOK {
+		// The range was ignored; skip what the caller already has
+		if _, err := io.CopyN(io.Discard, body, offset); err != nil {
+			return 0, t.err(err)
+		}
+	}
+	n, err = io.Copy(w, body)
+	return n, t.err(err)
+}
@@ -0,0 +1,112 @@
+package slack
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_DownloadFileRange(t *testing.T) {
+	content := "0123456789"
+	honorRange := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("expected bearer auth, got %q", got)
+		}
+		w.Header().Set("Content-Disposition", `attachment; filename="digits.txt"`)
+		if !honorRange {
+			_, _ = w.Write([]byte(content))
+			return
+		}
+		http.ServeContent(w, r, "digits.txt", time.Time{}, strings.NewReader(content))
+	}))
+	defer server.Close()
+	client := New(WithBaseURL(server.URL), WithToken("test-token"))
+
+	for _, honor := range []bool{true, false} {
+		honorRange = honor
+		var buf bytes.Buffer
+		n, err := client.DownloadFileRange(server.URL+"/files-pri/T1-F1/digits.txt", 4, &buf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if buf.String() != "456789" || n != 6 {
+			t.Errorf("honorRange=%v: expected the rest of the file, got %q (%d bytes)", honor, buf.String(), n)
+		}
+	}
+
+	// A complete partial download leaves nothing to fetch
+	honorRange = true
+	n, err := client.DownloadFileRange(server.URL+"/files-pri/T1-F1/digits.txt", 10, &bytes.Buffer{})
+	if err != nil || n != 0 {
+		t.Errorf("expected nothing to download, got %d bytes, err %v", n, err)
+	}
+}
+
+func TestClient_DownloadFile_SignInPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html>Sign in</html>"))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithToken("test-token"))
+	_, err := client.DownloadFile(server.URL+"/files-pri/T1-F1/a.txt", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "files:read") {
+		t.Errorf("expected a files:read hint, got %v", err)
+	}
+}
+
+func TestClient_DownloadFile_OutlastsClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="slow.txt"`)
+		for i := 0; i < 8; i++ {
+			_, _ = w.Write([]byte("chunk\n"))
+			w.(http.Flusher).Flush()
+			time.Sleep(25 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	// The transfer takes ~200ms, twice the client's overall timeout, but
+	// never stalls
+	client := New(WithBaseURL(server.URL), WithToken("test-token"),
+		WithHTTPClient(&http.Client{Timeout: 100 * time.Millisecond}),
+		WithStallTimeout(150*time.Millisecond))
+	var buf bytes.Buffer
+	n, err := client.DownloadFile(server.URL+"/files-pri/T1-F1/slow.txt", &buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 48 {
+		t.Errorf("expected 48 bytes, got %d", n)
+	}
+}
+
+func TestClient_DownloadFile_Stalled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Disposition", `attachment; filename="stuck.txt"`)
+		_, _ = w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := New(WithBaseURL(server.URL), WithToken("test-token"), WithStallTimeout(50*time.Millisecond))
+	var buf bytes.Buffer
+	_, err := client.DownloadFile(server.URL+"/files-pri/T1-F1/stuck.txt", &buf)
+	if err == nil || !strings.Contains(err.Error(), "stalled") {
+		t.Errorf("expected a stall error, got %v", err)
+	}
+	if buf.String() != "partial" {
+		t.Errorf("expected the data received before the stall, got %q", buf.String())
+	}
+}
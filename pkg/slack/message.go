@@ -0,0 +1,174 @@
+package slack
+
+import "encoding/json"
+
+// Message represents a Slack message. Fields Slack sends that have no field
+// here are kept in Extra, so a message encodes back to JSON with everything
+// Slack returned.
+type Message struct {
+	Type            string          `json:"type"`
+	Subtype         string          `json:"subtype,omitempty"`
+	User            string          `json:"user"`
+	BotID           string          `json:"bot_id,omitempty"`
+	Username        string          `json:"username,omitempty"`
+	BotProfile      *BotProfile     `json:"bot_profile,omitempty"`
+	Text            string          `json:"text"`
+	TS              string          `json:"ts"`
+	Team            string          `json:"team,omitempty"`
+	ThreadTS        string          `json:"thread_ts,omitempty"`
+	ParentUserID    string          `json:"parent_user_id,omitempty"`
+	ReplyCount      int             `json:"reply_count,omitempty"`
+	ReplyUsersCount int             `json:"reply_users_count,omitempty"`
+	ReplyUsers      []string        `json:"reply_users,omitempty"`
+	LatestReply     string          `json:"latest_reply,omitempty"`
+	Blocks          json.RawMessage `json:"blocks,omitempty"`
+	Attachments     json.RawMessage `json:"attachments,omitempty"`
+	Files           []File          `json:"files,omitempty"`
+	Reactions       []Reaction      `json:"reactions,omitempty"`
+	Edited          *Edited         `json:"edited,omitempty"`
+	Metadata        json.RawMessage `json:"metadata,omitempty"`
+
+	// Extra holds the fields not covered above, as Slack sent them
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// BotProfile describes the app that posted a bot message. Like Message, it
+// keeps unknown fields in Extra.
+type BotProfile struct {
+	ID      string `json:"id"`
+	AppID   string `json:"app_id,omitempty"`
+	Name    string `json:"name"`
+	Deleted bool   `json:"deleted,omitempty"`
+
+	// Extra holds the fields not covered above, such as icons and team_id
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Reaction is an emoji reaction on a message. Like Message, it keeps unknown
+// fields in Extra.
+type Reaction struct {
+	Name  string   `json:"name"`
+	Users []string `json:"users"`
+	Count int      `json:"count"`
+
+	// Extra holds the fields not covered above, such as a custom emoji's url
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// Edited records who last edited a message and when
+type Edited struct {
+	User string `json:"user"`
+	TS   string `json:"ts"`
+}
+
+// IsBot reports whether an app or integration posted the message
+func (m *Message) IsBot() bool {
+	return m.BotID != "" || m.Subtype == "bot_message"
+}
+
+// Sender names who posted the message: the user ID, or for bots the bot's
+// display name when there is one
+func (m *Message) Sender() string {
+	switch {
+	case m.User != "" && !m.IsBot():
+		return m.User
+	case m.Username != "":
+		return m.Username
+	case m.BotProfile != nil && m.BotProfile.Name != "":
+		return m.BotProfile.Name
+	case m.User != "":
+		return m.User
+	}
+	return m.BotID
+}
+
+// message has Message's fields without its JSON methods
+type message Message
+
+// messageFields are the JSON names of Message's fields
+var messageFields = jsonFields(message{})
+
+// UnmarshalJSON decodes a message, keeping unknown fields in Extra
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var known message
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, messageFields)
+	if err != nil {
+		return err
+	}
+	known.Extra = extra
+	*m = Message(known)
+	return nil
+}
+
+// MarshalJSON encodes a message with its Extra fields after the known ones
+func (m Message) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(message(m))
+	if err != nil || len(m.Extra) == 0 {
+		return data, err
+	}
+	return appendExtra(data, m.Extra, messageFields)
+}
+
+// botProfile has BotProfile's fields without its JSON methods
+type botProfile BotProfile
+
+// botProfileFields are the JSON names of BotProfile's fields
+var botProfileFields = jsonFields(botProfile{})
+
+// UnmarshalJSON decodes a bot profile, keeping unknown fields in Extra
+func (b *BotProfile) UnmarshalJSON(data []byte) error {
+	var known botProfile
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, botProfileFields)
+	if err != nil {
+		return err
+	}
+	known.Extra = extra
+	*b = BotProfile(known)
+	return nil
+}
+
+// MarshalJSON encodes a bot profile with its Extra fields after the known
+// ones
+func (b BotProfile) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(botProfile(b))
+	if err != nil || len(b.Extra) == 0 {
+		return data, err
+	}
+	return appendExtra(data, b.Extra, botProfileFields)
+}
+
+// reaction has Reaction's fields without its JSON methods
+type reaction Reaction
+
+// reactionFields are the JSON names of Reaction's fields
+var reactionFields = jsonFields(reaction{})
+
+// UnmarshalJSON decodes a reaction, keeping unknown fields in Extra
+func (r *Reaction) UnmarshalJSON(data []byte) error {
+	var known reaction
+	if err := json.Unmarshal(data, &known); err != nil {
+		return err
+	}
+	extra, err := extraFields(data, reactionFields)
+	if err != nil {
+		return err
+	}
+	known.Extra = extra
+	*r = Reaction(known)
+	return nil
+}
+
+// MarshalJSON encodes a reaction with its Extra fields after the known ones
+func (r Reaction) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(reaction(r))
+	if err != nil || len(r.Extra) == 0 {
+		return data, err
+	}
+	return appendExtra(data, r.Extra, reactionFields)
+}
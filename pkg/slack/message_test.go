@@ -0,0 +1,125 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMessage_KeepsUnknownFields(t *testing.T) {
+	in := `{"type":"message","subtype":"bot_message","user":"","bot_id":"B1","username":"deploybot","text":"hi","ts":"1.2",` +
+		`"edited":{"user":"U1","ts":"1.3"},"reactions":[{"name":"tada","users":["U1"],"count":1}],` +
+		`"client_msg_id":"abc","pinned_to":["C1"],"x_future":{"nested":true}}`
+
+	var m Message
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.Edited == nil || m.Edited.TS != "1.3" || len(m.Reactions) != 1 {
+		t.Errorf("known fields not decoded: %+v", m)
+	}
+	if len(m.Extra) != 3 || string(m.Extra["client_msg_id"]) != `"abc"` {
+		t.Errorf("expected the 3 unknown fields in Extra, got %v", m.Extra)
+	}
+
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var before, after map[string]interface{}
+	_ = json.Unmarshal([]byte(in), &before)
+	_ = json.Unmarshal(out, &after)
+	for k, v := range before {
+		got, _ := json.Marshal(after[k])
+		want, _ := json.Marshal(v)
+		if string(got) != string(want) {
+			t.Errorf("field %s: got %s, want %s", k, got, want)
+		}
+	}
+}
+
+func TestMessage_KeepsUnknownFileFields(t *testing.T) {
+	in := `{"type":"message","user":"U1","text":"","ts":"1.2","files":[{"id":"F1","name":"a.png","title":"a","mimetype":"image/png",` +
+		`"filetype":"png","user":"U1","size":10,"created":1,"is_public":false,"thumb_360":"https://files.example.com/a_360.png",` +
+		`"original_w":800,"shares":{"public":{"C1":[{"ts":"1.2"}]}}}]}`
+
+	var m Message
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.Files) != 1 || m.Files[0].ID != "F1" || m.Files[0].Size != 10 {
+		t.Fatalf("known file fields not decoded: %+v", m.Files)
+	}
+	if len(m.Files[0].Extra) != 3 {
+		t.Errorf("expected the 3 unknown file fields in Extra, got %v", m.Files[0].Extra)
+	}
+
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var before, after map[string]interface{}
+	_ = json.Unmarshal([]byte(in), &before)
+	_ = json.Unmarshal(out, &after)
+	got, _ := json.Marshal(after["files"])
+	want, _ := json.Marshal(before["files"])
+	if string(got) != string(want) {
+		t.Errorf("files: got %s, want %s", got, want)
+	}
+}
+
+func TestMessage_KeepsNestedUnknownFields(t *testing.T) {
+	in := `{"type":"message","subtype":"bot_message","user":"","bot_id":"B1","text":"hi","ts":"1.2",` +
+		`"bot_profile":{"id":"B1","app_id":"A1","name":"CI","icons":{"image_36":"https://a.example.com/36.png"},"team_id":"T1","updated":1700000000},` +
+		`"reactions":[{"name":"partyparrot","users":["U1"],"count":1,"url":"https://emoji.example.com/partyparrot.gif"}]}`
+
+	var m Message
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.BotProfile == nil || m.BotProfile.Name != "CI" || len(m.BotProfile.Extra) != 3 {
+		t.Errorf("expected the bot profile with 3 unknown fields, got %+v", m.BotProfile)
+	}
+	if len(m.Reactions) != 1 || string(m.Reactions[0].Extra["url"]) != `"https://emoji.example.com/partyparrot.gif"` {
+		t.Errorf("expected the reaction url in Extra, got %+v", m.Reactions)
+	}
+
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var before, after map[string]interface{}
+	_ = json.Unmarshal([]byte(in), &before)
+	_ = json.Unmarshal(out, &after)
+	for _, k := range []string{"bot_profile", "reactions"} {
+		got, _ := json.Marshal(after[k])
+		want, _ := json.Marshal(before[k])
+		if string(got) != string(want) {
+			t.Errorf("%s: got %s, want %s", k, got, want)
+		}
+	}
+}
+
+func TestMessage_Sender(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   Message
+		want  string
+		isBot bool
+	}{
+		{"user", Message{User: "U1"}, "U1", false},
+		{"bot with username", Message{BotID: "B1", Username: "deploybot"}, "deploybot", true},
+		{"bot with profile", Message{User: "U2", BotID: "B1", BotProfile: &BotProfile{Name: "CI"}}, "CI", true},
+		{"bot user", Message{User: "U2", BotID: "B1"}, "U2", true},
+		{"bare bot", Message{Subtype: "bot_message", BotID: "B1"}, "B1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.Sender(); got != tt.want {
+				t.Errorf("Sender() = %q, want %q", got, tt.want)
+			}
+			if got := tt.msg.IsBot(); got != tt.isBot {
+				t.Errorf("IsBot() = %v, want %v", got, tt.isBot)
+			}
+		})
+	}
+}
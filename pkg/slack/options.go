@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithBaseURL sets the Web API base URL, e.g. an httptest server's URL
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests. A nil client is
+// ignored.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// WithToken authenticates every request with a fixed token
+func WithToken(token string) Option {
+	return WithTokenSource(StaticToken(token))
+}
+
+// WithTokenSource authenticates requests with tokens from ts. If ts also
+// implements Refresher, the token is refreshed once when Slack reports
+// token_expired.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.tokens = ts
+	}
+}
+
+// WithLogger sets the logger for retry and token refresh events. To log
+// every request, wrap the HTTP client's transport in a LoggingTransport.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		if logger != nil {
+			c.logger = logger
+		}
+	}
+}
+
+// WithRetryPolicy sets how rate-limited and failed requests are retried
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithStallTimeout sets how long a file upload or download may go without
+// progress before it is abandoned (DefaultStallTimeout if zero). File
+// transfers are not bound by the HTTP client's overall timeout.
+func WithStallTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.stall = timeout
+	}
+}
@@ -0,0 +1,105 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// MessageRef identifies a message. ThreadTS is set for thread replies.
+type MessageRef struct {
+	Channel  string `json:"channel"`
+	TS       string `json:"ts"`
+	ThreadTS string `json:"thread_ts,omitempty"`
+}
+
+var permalinkPath = regexp.MustCompile(`^/archives/([CGD][A-Z0-9]+)/p(\d{10})(\d{6})$`)
+
+// ParsePermalink extracts the message a Slack permalink points to, such as
+// https://acme.slack.com/archives/C0123ABCD/p1700000000123456 or a thread
+// reply link carrying ?thread_ts=.
+func ParsePermalink(link string) (MessageRef, error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || u.Host == "" {
+		return MessageRef{}, fmt.Errorf("invalid permalink %q", link)
+	}
+	m := permalinkPath.FindStringSubmatch(strings.TrimSuffix(u.Path, "/"))
+	if m == nil {
+		return MessageRef{}, fmt.Errorf("invalid permalink %q: expected https://<workspace>.slack.com/archives/<channel>/p<timestamp>", link)
+	}
+	ref := MessageRef{Channel: m[1], TS: m[2] + "." + m[3]}
+	if threadTS := u.Query().Get("thread_ts"); threadTS != "" && threadTS != ref.TS {
+		ref.ThreadTS = threadTS
+	}
+	return ref, nil
+}
+
+// GetPermalink returns a permanent link to a message
+func (c *Client) GetPermalink(channel, ts string) (string, error) {
+	return c.GetPermalinkContext(context.Background(), channel, ts)
+}
+
+// GetPermalinkContext is GetPermalink with a context
+func (c *Client) GetPermalinkContext(ctx context.Context, channel, ts string) (string, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("message_ts", ts)
+
+	body, err := c.get(ctx, "chat.getPermalink", params)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Permalink string `json:"permalink"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", err
+	}
+	return result.Permalink, nil
+}
+
+// GetMessage returns a single message. Thread replies are not in channel
+// history, so for a reply threadTS must name the thread it belongs to.
+func (c *Client) GetMessage(channel, ts, threadTS string) (*Message, error) {
+	return c.GetMessageContext(context.Background(), channel, ts, threadTS)
+}
+
+// GetMessageContext is GetMessage with a context
+func (c *Client) GetMessageContext(ctx context.Context, channel, ts, threadTS string) (*Message, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("oldest", ts)
+	params.Set("latest", ts)
+	params.Set("inclusive", "true")
+	params.Set("limit", "1")
+
+	method := "conversations.history"
+	if threadTS != "" && threadTS != ts {
+		method = "conversations.replies"
+		params.Set("ts", threadTS)
+		// The thread parent always comes first
+		params.Set("limit", "2")
+	}
+
+	body, err := c.get(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Messages []Message `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	for i := range result.Messages {
+		if result.Messages[i].TS == ts {
+			return &result.Messages[i], nil
+		}
+	}
+	return nil, &APIError{Code: "message_not_found"}
+}
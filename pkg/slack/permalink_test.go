@@ -0,0 +1,49 @@
+package slack
+
+import "testing"
+
+func TestParsePermalink(t *testing.T) {
+	tests := []struct {
+		name    string
+		link    string
+		want    MessageRef
+		wantErr bool
+	}{
+		{
+			name: "message",
+			link: "https://acme.slack.com/archives/C0123ABCD/p1700000000123456",
+			want: MessageRef{Channel: "C0123ABCD", TS: "1700000000.123456"},
+		},
+		{
+			name: "thread reply",
+			link: "https://acme.slack.com/archives/C0123ABCD/p1700000100000200?thread_ts=1700000000.123456&cid=C0123ABCD",
+			want: MessageRef{Channel: "C0123ABCD", TS: "1700000100.000200", ThreadTS: "1700000000.123456"},
+		},
+		{
+			name: "thread parent",
+			link: "https://acme.slack.com/archives/G0123ABCD/p1700000000123456?thread_ts=1700000000.123456",
+			want: MessageRef{Channel: "G0123ABCD", TS: "1700000000.123456"},
+		},
+		{name: "channel link", link: "https://acme.slack.com/archives/C0123ABCD", wantErr: true},
+		{name: "file link", link: "https://acme.slack.com/files/U0123/F0123/report.pdf", wantErr: true},
+		{name: "not a URL", link: "C0123ABCD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePermalink(tt.link)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// PinnedItem is a message or file pinned to a channel
+type PinnedItem struct {
+	Type      string   `json:"type"`
+	Channel   string   `json:"channel"`
+	Created   int64    `json:"created"`
+	CreatedBy string   `json:"created_by"`
+	Message   *Message `json:"message,omitempty"`
+	File      *File    `json:"file,omitempty"`
+}
+
+// AddPin pins a message to its channel
+func (c *Client) AddPin(channel, ts string) error {
+	return c.AddPinContext(context.Background(), channel, ts)
+}
+
+// AddPinContext is AddPin with a context
+func (c *Client) AddPinContext(ctx context.Context, channel, ts string) error {
+	data := map[string]interface{}{
+		"channel":   channel,
+		"timestamp": ts,
+	}
+	_, err := c.post(ctx, "pins.add", data)
+	return err
+}
+
+// RemovePin unpins a message
+func (c *Client) RemovePin(channel, ts string) error {
+	return c.RemovePinContext(context.Background(), channel, ts)
+}
+
+// RemovePinContext is RemovePin with a context
+func (c *Client) RemovePinContext(ctx context.Context, channel, ts string) error {
+	data := map[string]interface{}{
+		"channel":   channel,
+		"timestamp": ts,
+	}
+	_, err := c.post(ctx, "pins.remove", data)
+	return err
+}
+
+// ListPins returns the items pinned to a channel
+func (c *Client) ListPins(channel string) ([]PinnedItem, error) {
+	return c.ListPinsContext(context.Background(), channel)
+}
+
+// ListPinsContext is ListPins with a context
+func (c *Client) ListPinsContext(ctx context.Context, channel string) ([]PinnedItem, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+
+	body, err := c.get(ctx, "pins.list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []PinnedItem `json:"items"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
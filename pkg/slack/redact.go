@@ -0,0 +1,59 @@
+package slack
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	// tokenPattern matches Slack bot, user, refresh, app and config tokens
+	tokenPattern = regexp.MustCompile(`(xoxe\.)?xox[a-z]-[A-Za-z0-9-]+|xoxe-\d-[A-Za-z0-9-]+|xapp-[A-Za-z0-9-]+`)
+
+	// secretFieldPattern matches JSON fields holding secrets that don't look
+	// like tokens
+	secretFieldPattern = regexp.MustCompile(`("(?:client_secret|code|code_verifier)"\s*:\s*)"[^"]*"`)
+
+	// secretParams are form and query parameters that are always redacted
+	secretParams = []string{"token", "client_secret", "code", "code_verifier", "refresh_token"}
+)
+
+// RedactTokens replaces every Slack token in s with "[REDACTED]"
+func RedactTokens(s string) string {
+	return tokenPattern.ReplaceAllString(s, "[REDACTED]")
+}
+
+// RedactSecrets replaces Slack tokens, and the values of JSON fields such
+// as client_secret and code, with "[REDACTED]"
+func RedactSecrets(s string) string {
+	s = RedactTokens(s)
+	return secretFieldPattern.ReplaceAllString(s, `$1"[REDACTED]"`)
+}
+
+// RedactValues redacts form or query parameters in place: secret parameters
+// such as client_secret, code and refresh_token entirely, and tokens
+// anywhere else. It returns values.
+func RedactValues(values url.Values) url.Values {
+	for key, vs := range values {
+		for i, v := range vs {
+			vs[i] = RedactSecrets(v)
+		}
+		for _, secret := range secretParams {
+			if key == secret {
+				values[key] = []string{"[REDACTED]"}
+			}
+		}
+	}
+	return values
+}
+
+// RedactBody removes secrets from a request or response body, treating
+// form-encoded bodies parameter by parameter
+func RedactBody(b []byte, contentType string) []byte {
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if values, err := url.ParseQuery(string(b)); err == nil {
+			return []byte(RedactValues(values).Encode())
+		}
+	}
+	return []byte(RedactSecrets(string(b)))
+}
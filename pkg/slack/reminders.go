@@ -0,0 +1,93 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+)
+
+// Reminder is a Slack reminder. Reminders belong to users, so these methods
+// need a user token.
+type Reminder struct {
+	ID         string `json:"id"`
+	Creator    string `json:"creator"`
+	User       string `json:"user"`
+	Text       string `json:"text"`
+	Recurring  bool   `json:"recurring"`
+	Time       int64  `json:"time,omitempty"`
+	CompleteTS int64  `json:"complete_ts,omitempty"`
+}
+
+// AddReminder creates a reminder. when is a Unix timestamp, or a phrase
+// Slack understands such as "every weekday at 9am" for recurring reminders.
+// An empty user reminds the token's owner.
+func (c *Client) AddReminder(text, when, user string) (*Reminder, error) {
+	return c.AddReminderContext(context.Background(), text, when, user)
+}
+
+// AddReminderContext is AddReminder with a context
+func (c *Client) AddReminderContext(ctx context.Context, text, when, user string) (*Reminder, error) {
+	data := map[string]interface{}{
+		"text": text,
+		"time": when,
+	}
+	if user != "" {
+		data["user"] = user
+	}
+
+	body, err := c.post(ctx, "reminders.add", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Reminder Reminder `json:"reminder"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result.Reminder, nil
+}
+
+// ListReminders returns the reminders created by or for the token's owner
+func (c *Client) ListReminders() ([]Reminder, error) {
+	return c.ListRemindersContext(context.Background())
+}
+
+// ListRemindersContext is ListReminders with a context
+func (c *Client) ListRemindersContext(ctx context.Context) ([]Reminder, error) {
+	body, err := c.get(ctx, "reminders.list", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Reminders []Reminder `json:"reminders"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result.Reminders, nil
+}
+
+// CompleteReminder marks a reminder as complete
+func (c *Client) CompleteReminder(id string) error {
+	return c.CompleteReminderContext(context.Background(), id)
+}
+
+// CompleteReminderContext is CompleteReminder with a context
+func (c *Client) CompleteReminderContext(ctx context.Context, id string) error {
+	_, err := c.post(ctx, "reminders.complete", map[string]interface{}{"reminder": id})
+	return err
+}
+
+// DeleteReminder deletes a reminder
+func (c *Client) DeleteReminder(id string) error {
+	return c.DeleteReminderContext(context.Background(), id)
+}
+
+// DeleteReminderContext is DeleteReminder with a context
+func (c *Client) DeleteReminderContext(ctx context.Context, id string) error {
+	_, err := c.post(ctx, "reminders.delete", map[string]interface{}{"reminder": id})
+	return err
+}
@@ -0,0 +1,59 @@
+package slack
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries rate-limited (HTTP 429) and
+// server error responses. Network errors and Slack API errors are never
+// retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// BaseDelay is the first backoff delay; it doubles on each retry
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff and any Retry-After value from Slack
+	MaxDelay time.Duration
+	// RetryServerErrors also retries HTTP 5xx responses
+	RetryServerErrors bool
+}
+
+// DefaultRetryPolicy retries rate-limited requests up to three times,
+// honouring Slack's Retry-After header.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  time.Second,
+	MaxDelay:   30 * time.Second,
+}
+
+// NoRetry disables retries
+var NoRetry = RetryPolicy{}
+
+// shouldRetry reports whether a response with the given HTTP status should
+// be retried after attempt (zero-based) attempts.
+func (p RetryPolicy) shouldRetry(status, attempt int) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return p.RetryServerErrors && status >= 500 && status <= 599
+}
+
+// delay returns how long to wait before the next retry. A Retry-After value
+// in seconds takes precedence over exponential backoff.
+func (p RetryPolicy) delay(attempt int, retryAfter string) time.Duration {
+	var d time.Duration
+	if secs, err := strconv.Atoi(retryAfter); err == nil && secs >= 0 {
+		d = time.Duration(secs) * time.Second
+	} else {
+		d = p.BaseDelay << attempt
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
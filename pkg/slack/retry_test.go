@@ -0,0 +1,143 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_RetriesRateLimited(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"team":{"id":"T1","name":"Team"}}`))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithToken("test-token"))
+	team, err := client.GetTeamInfo()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if team.ID != "T1" {
+		t.Errorf("expected team T1, got %s", team.ID)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestClient_NoRetry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithToken("test-token"), WithRetryPolicy(NoRetry))
+	if _, err := client.GetTeamInfo(); err == nil {
+		t.Fatal("expected ratelimited error")
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestClient_RetriesServerErrorsWhenEnabled(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, RetryServerErrors: true}
+	client := New(WithBaseURL(server.URL), WithToken("test-token"), WithRetryPolicy(policy))
+	if _, err := client.GetTeamInfo(); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+
+	calls = 0
+	client = New(WithBaseURL(server.URL), WithToken("test-token"))
+	_, _ = client.GetTeamInfo()
+	if calls != 1 {
+		t.Errorf("expected server errors not to be retried by default, got %d calls", calls)
+	}
+}
+
+func TestClient_ContextCancelsRetryWait(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithToken("test-token"))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetTeamInfoContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the deadline to end the wait, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the retry wait to stop with the context, took %s", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestClient_ContextCancelsRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := New(WithBaseURL(server.URL), WithToken("test-token"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.SendMessageContext(ctx, "C1", "hi", "", nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+
+	tests := []struct {
+		attempt    int
+		retryAfter string
+		want       time.Duration
+	}{
+		{0, "", time.Second},
+		{1, "", 2 * time.Second},
+		{3, "", 5 * time.Second},
+		{0, "3", 3 * time.Second},
+		{0, "60", 5 * time.Second},
+		{1, "soon", 2 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.delay(tt.attempt, tt.retryAfter); got != tt.want {
+			t.Errorf("delay(%d, %q) = %s, want %s", tt.attempt, tt.retryAfter, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ScheduledMessage is a message waiting to be posted by Slack
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channel_id"`
+	PostAt      int64  `json:"post_at"`
+	DateCreated int64  `json:"date_created"`
+	Text        string `json:"text"`
+}
+
+// ScheduleMessage schedules a message to be posted at postAt, which must be
+// in the future and no more than 120 days away. It returns the scheduled
+// message's ID.
+func (c *Client) ScheduleMessage(channel, text string, postAt time.Time, threadTS string, blocks []interface{}) (*ScheduledMessage, error) {
+	return c.ScheduleMessageContext(context.Background(), channel, text, postAt, threadTS, blocks)
+}
+
+// ScheduleMessageContext is ScheduleMessage with a context
+func (c *Client) ScheduleMessageContext(ctx context.Context, channel, text string, postAt time.Time, threadTS string, blocks []interface{}) (*ScheduledMessage, error) {
+	data := map[string]interface{}{
+		"channel": channel,
+		"text":    text,
+		"post_at": postAt.Unix(),
+	}
+	if threadTS != "" {
+		data["thread_ts"] = threadTS
+	}
+	if len(blocks) > 0 {
+		data["blocks"] = blocks
+	}
+
+	body, err := c.post(ctx, "chat.scheduleMessage", data)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Channel            string `json:"channel"`
+		ScheduledMessageID string `json:"scheduled_message_id"`
+		PostAt             int64  `json:"post_at"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &ScheduledMessage{
+		ID:        result.ScheduledMessageID,
+		ChannelID: result.Channel,
+		PostAt:    result.PostAt,
+		Text:      text,
+	}, nil
+}
+
+// ListScheduledMessages returns scheduled messages up to the specified limit,
+// soonest first (handles pagination automatically). An empty channel lists
+// them for every channel.
+func (c *Client) ListScheduledMessages(channel string, limit int) ([]ScheduledMessage, error) {
+	return c.ListScheduledMessagesContext(context.Background(), channel, limit)
+}
+
+// ListScheduledMessagesContext is ListScheduledMessages with a context
+func (c *Client) ListScheduledMessagesContext(ctx context.Context, channel string, limit int) ([]ScheduledMessage, error) {
+	var all []ScheduledMessage
+	cursor := ""
+
+	for len(all) < limit {
+		params := url.Values{}
+		batchSize := limit - len(all)
+		if batchSize > 100 {
+			batchSize = 100
+		}
+		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		if channel != "" {
+			params.Set("channel", channel)
+		}
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.get(ctx, "chat.scheduledMessages.list", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			ScheduledMessages []ScheduledMessage `json:"scheduled_messages"`
+			ResponseMetadata  struct {
+				NextCursor string `json:"next_cursor"`
+			} `json:"response_metadata"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, err
+		}
+
+		all = append(all, result.ScheduledMessages...)
+		if result.ResponseMetadata.NextCursor == "" {
+			break
+		}
+		cursor = result.ResponseMetadata.NextCursor
+	}
+
+	if len(all) > limit {
+		all = all[:limit]
+	}
+	return all, nil
+}
+
+// DeleteScheduledMessage cancels a scheduled message before it is posted
+func (c *Client) DeleteScheduledMessage(channel, id string) error {
+	return c.DeleteScheduledMessageContext(context.Background(), channel, id)
+}
+
+// DeleteScheduledMessageContext is DeleteScheduledMessage with a context
+func (c *Client) DeleteScheduledMessageContext(ctx context.Context, channel, id string) error {
+	data := map[string]interface{}{
+		"channel":              channel,
+		"scheduled_message_id": id,
+	}
+	_, err := c.post(ctx, "chat.deleteScheduledMessage", data)
+	return err
+}
@@ -0,0 +1,269 @@
+package slacktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// upload is a file reserved by files.getUploadURLExternal
+type upload struct {
+	name     string
+	length   int64
+	content  []byte
+	received bool
+}
+
+const (
+	uploadPath   = "/upload/"
+	downloadPath = "/files-pri/"
+)
+
+// serveUpload accepts content for a reserved upload, like the upload URLs
+// Slack hands out (which need no token)
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, uploadPath)
+	content, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls["upload"]++
+	up, ok := s.uploads[id]
+	if !ok || r.Method != http.MethodPost {
+		http.Error(w, "unknown upload", http.StatusNotFound)
+		return
+	}
+	up.content = content
+	up.received = true
+	_, _ = w.Write([]byte("OK - " + strconv.Itoa(len(content))))
+}
+
+// serveDownload serves url_private_download. Like Slack, a request without
+// a token that can read files gets an HTML sign-in page.
+func (s *Server) serveDownload(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls["download"]++
+
+	info, ok := s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	if !ok || !info.hasScope("files:read") {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><body>Sign in to Slack</body></html>"))
+		return
+	}
+
+	// /files-pri/<team>-<file id>/download/<name>
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, downloadPath), "/")
+	id := parts[0][strings.LastIndex(parts[0], "-")+1:]
+	f := s.file(id)
+	if f == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", fileMimetype(f))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+f.Name+`"`)
+	// ServeContent answers Range requests, so downloads can resume
+	http.ServeContent(w, r, "", time.Unix(f.Created, 0), bytes.NewReader(f.Content))
+}
+
+func (t tokenInfo) hasScope(scope string) bool {
+	if t.scopes == nil {
+		return true
+	}
+	for _, s := range t.scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) file(id string) *File {
+	for _, f := range s.files {
+		if f.ID == id {
+			return f
+		}
+	}
+	return nil
+}
+
+func fileMimetype(f *File) string {
+	if t := mime.TypeByExtension(filepath.Ext(f.Name)); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// fileJSON renders a file as the files.* methods return it
+func (s *Server) fileJSON(f *File) map[string]interface{} {
+	filetype := f.Filetype
+	if filetype == "" {
+		filetype = strings.TrimPrefix(filepath.Ext(f.Name), ".")
+	}
+	title := f.Title
+	if title == "" {
+		title = f.Name
+	}
+	channels := f.Channels
+	if channels == nil {
+		channels = []string{}
+	}
+	return map[string]interface{}{
+		"id":                   f.ID,
+		"name":                 f.Name,
+		"title":                title,
+		"mimetype":             fileMimetype(f),
+		"filetype":             filetype,
+		"user":                 f.User,
+		"size":                 len(f.Content),
+		"created":              f.Created,
+		"is_public":            len(channels) > 0,
+		"url_private":          s.URL + downloadPath + TeamID + "-" + f.ID + "/" + f.Name,
+		"url_private_download": s.URL + downloadPath + TeamID + "-" + f.ID + "/download/" + f.Name,
+		"permalink":            s.URL + "/files/" + f.User + "/" + f.ID + "/" + f.Name,
+		"channels":             channels,
+	}
+}
+
+func (s *Server) filesGetUploadURLExternal(r *request) (response, error) {
+	name := r.get("filename")
+	length, err := strconv.ParseInt(r.get("length"), 10, 64)
+	if name == "" || err != nil {
+		return nil, errCode("invalid_arguments")
+	}
+
+	s.seq++
+	id := idFor("F", s.seq)
+	s.uploads[id] = &upload{name: name, length: length}
+	return response{"upload_url": s.URL + uploadPath + id, "file_id": id}, nil
+}
+
+func (s *Server) filesCompleteUploadExternal(r *request) (response, error) {
+	var files []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	}
+	raw := []byte(r.get("files"))
+	if err := json.Unmarshal(raw, &files); err != nil || len(files) == 0 {
+		return nil, errCode("invalid_arguments")
+	}
+
+	var ch *channel
+	if r.get("channel_id") != "" {
+		r.values.Set("channel", r.get("channel_id"))
+		var err error
+		if ch, err = s.writableChannel(r); err != nil {
+			return nil, err
+		}
+	}
+
+	completed := make([]*File, 0, len(files))
+	for _, f := range files {
+		up, ok := s.uploads[f.ID]
+		if !ok || !up.received {
+			return nil, errCode("file_not_found")
+		}
+		if int64(len(up.content)) != up.length {
+			return nil, errCode("file_upload_size_mismatch")
+		}
+		completed = append(completed, &File{
+			ID:      f.ID,
+			Name:    up.name,
+			Title:   f.Title,
+			User:    r.token.userID,
+			Created: s.epoch,
+			Content: up.content,
+		})
+		delete(s.uploads, f.ID)
+	}
+
+	var ids []string
+	for _, f := range completed {
+		if ch != nil {
+			f.Channels = []string{ch.ID}
+		}
+		s.files = append(s.files, f)
+		ids = append(ids, f.ID)
+	}
+
+	if ch != nil {
+		msg := &Message{User: r.token.userID, Text: r.get("initial_comment"), TS: s.nextTS(), Files: ids}
+		if threadTS := r.get("thread_ts"); threadTS != "" {
+			if ch.message(threadTS) == nil {
+				return nil, errCode("thread_not_found")
+			}
+			msg.ThreadTS = threadTS
+		}
+		ch.messages = append(ch.messages, msg)
+	}
+
+	out := make([]map[string]interface{}, 0, len(completed))
+	for _, f := range completed {
+		out = append(out, map[string]interface{}{"id": f.ID, "title": s.fileJSON(f)["title"]})
+	}
+	return response{"files": out}, nil
+}
+
+func (s *Server) filesInfo(r *request) (response, error) {
+	f := s.file(r.get("file"))
+	if f == nil {
+		return nil, errCode("file_not_found")
+	}
+	return response{"file": s.fileJSON(f)}, nil
+}
+
+func (s *Server) filesList(r *request) (response, error) {
+	channelID, userID := r.get("channel"), r.get("user")
+	types := r.get("types")
+
+	var matched []*File
+	for _, f := range s.files {
+		if channelID != "" && !s.fileInChannel(f, channelID) {
+			continue
+		}
+		if userID != "" && f.User != userID {
+			continue
+		}
+		if types != "" && types != "all" && !strings.Contains(types, s.fileJSON(f)["filetype"].(string)) {
+			continue
+		}
+		matched = append(matched, f)
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[j].Created < matched[i].Created })
+
+	start, end, paging := searchPage(r, len(matched))
+	files := make([]map[string]interface{}, 0, end-start)
+	for _, f := range matched[start:end] {
+		files = append(files, s.fileJSON(f))
+	}
+	return response{"files": files, "paging": paging}, nil
+}
+
+func (s *Server) filesDelete(r *request) (response, error) {
+	f := s.file(r.get("file"))
+	if f == nil {
+		return nil, errCode("file_not_found")
+	}
+	if f.User != r.token.userID {
+		return nil, errCode("cant_delete_file")
+	}
+	for i, existing := range s.files {
+		if existing == f {
+			s.files = append(s.files[:i], s.files[i+1:]...)
+			break
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,1054 @@
+package slacktest
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// methodScopes lists the scopes each method accepts; a token needs any one
+var methodScopes = map[string][]string{
+	"auth.test":                {},
+	"team.info":                {"team:read"},
+	"users.list":               {"users:read"},
+	"users.info":               {"users:read"},
+	"conversations.list":       {"channels:read", "groups:read", "im:read", "mpim:read"},
+	"conversations.info":       {"channels:read", "groups:read", "im:read", "mpim:read"},
+	"conversations.history":    {"channels:history", "groups:history", "im:history", "mpim:history"},
+	"conversations.replies":    {"channels:history", "groups:history", "im:history", "mpim:history"},
+	"conversations.create":     {"channels:manage"},
+	"conversations.archive":    {"channels:manage"},
+	"conversations.unarchive":  {"channels:manage"},
+	"conversations.setTopic":   {"channels:manage", "channels:write.topic"},
+	"conversations.setPurpose": {"channels:manage", "channels:write.topic"},
+	"conversations.invite":     {"channels:manage", "channels:write.invites"},
+	"chat.postMessage":         {"chat:write"},
+	"chat.update":              {"chat:write"},
+	"chat.delete":              {"chat:write"},
+	"reactions.add":            {"reactions:write"},
+	"reactions.remove":         {"reactions:write"},
+	"search.messages":          {"search:read"},
+	"search.files":             {"search:read"},
+	"search.all":               {"search:read"},
+
+	"files.getUploadURLExternal":   {"files:write"},
+	"files.completeUploadExternal": {"files:write"},
+	"files.info":                   {"files:read"},
+	"files.list":                   {"files:read"},
+	"files.delete":                 {"files:write"},
+
+	"chat.scheduleMessage":        {"chat:write"},
+	"chat.scheduledMessages.list": {"chat:write"},
+	"chat.deleteScheduledMessage": {"chat:write"},
+	"chat.postEphemeral":          {"chat:write"},
+	"chat.meMessage":              {"chat:write"},
+	"chat.getPermalink":           {},
+	"pins.add":                    {"pins:write"},
+	"pins.remove":                 {"pins:write"},
+	"pins.list":                   {"pins:read"},
+	"bookmarks.add":               {"bookmarks:write"},
+	"bookmarks.edit":              {"bookmarks:write"},
+	"bookmarks.remove":            {"bookmarks:write"},
+	"bookmarks.list":              {"bookmarks:read"},
+	"reminders.add":               {"reminders:write"},
+	"reminders.list":              {"reminders:read"},
+	"reminders.complete":          {"reminders:write"},
+	"reminders.delete":            {"reminders:write"},
+	"users.profile.set":           {"users.profile:write"},
+	"users.getPresence":           {"users:read"},
+	"users.setPresence":           {"users:write"},
+	"dnd.info":                    {"dnd:read"},
+	"dnd.setSnooze":               {"dnd:write"},
+	"dnd.endSnooze":               {"dnd:write"},
+	"conversations.members":       {"channels:read", "groups:read", "im:read", "mpim:read"},
+	"conversations.kick":          {"channels:manage"},
+	"conversations.join":          {"channels:join", "channels:write"},
+	"conversations.leave":         {"channels:manage", "im:write", "mpim:write"},
+	"conversations.rename":        {"channels:manage"},
+	"users.conversations":         {"channels:read", "groups:read", "im:read", "mpim:read"},
+	"usergroups.list":             {"usergroups:read"},
+	"usergroups.create":           {"usergroups:write"},
+	"usergroups.update":           {"usergroups:write"},
+	"usergroups.disable":          {"usergroups:write"},
+	"usergroups.enable":           {"usergroups:write"},
+	"usergroups.users.list":       {"usergroups:read"},
+	"usergroups.users.update":     {"usergroups:write"},
+}
+
+// privateScopes lists the scopes methods that change a conversation accept
+// when it is a private channel, in place of methodScopes
+var privateScopes = map[string][]string{
+	"conversations.create":     {"groups:write"},
+	"conversations.archive":    {"groups:write"},
+	"conversations.unarchive":  {"groups:write"},
+	"conversations.setTopic":   {"groups:write", "groups:write.topic"},
+	"conversations.setPurpose": {"groups:write", "groups:write.topic"},
+	"conversations.invite":     {"groups:write", "groups:write.invites"},
+	"conversations.kick":       {"groups:write"},
+	"conversations.rename":     {"groups:write"},
+	"conversations.leave":      {"groups:write"},
+}
+
+// userOnlyMethods reject bot tokens
+var userOnlyMethods = map[string]bool{
+	"search.messages": true,
+	"search.files":    true,
+	"search.all":      true,
+
+	"reminders.add":      true,
+	"reminders.list":     true,
+	"reminders.complete": true,
+	"reminders.delete":   true,
+
+	"users.profile.set": true,
+	"users.setPresence": true,
+	"dnd.setSnooze":     true,
+	"dnd.endSnooze":     true,
+}
+
+var handlers map[string]handler
+
+func init() {
+	handlers = map[string]handler{
+		"auth.test":                (*Server).authTest,
+		"team.info":                (*Server).teamInfo,
+		"users.list":               (*Server).usersList,
+		"users.info":               (*Server).usersInfo,
+		"conversations.list":       (*Server).conversationsList,
+		"conversations.info":       (*Server).conversationsInfo,
+		"conversations.history":    (*Server).conversationsHistory,
+		"conversations.replies":    (*Server).conversationsReplies,
+		"conversations.create":     (*Server).conversationsCreate,
+		"conversations.archive":    (*Server).conversationsArchive,
+		"conversations.unarchive":  (*Server).conversationsUnarchive,
+		"conversations.setTopic":   (*Server).conversationsSetTopic,
+		"conversations.setPurpose": (*Server).conversationsSetPurpose,
+		"conversations.invite":     (*Server).conversationsInvite,
+		"chat.postMessage":         (*Server).chatPostMessage,
+		"chat.update":              (*Server).chatUpdate,
+		"chat.delete":              (*Server).chatDelete,
+		"reactions.add":            (*Server).reactionsAdd,
+		"reactions.remove":         (*Server).reactionsRemove,
+		"search.messages":          (*Server).searchMessages,
+		"search.files":             (*Server).searchFiles,
+		"search.all":               (*Server).searchAll,
+
+		"files.getUploadURLExternal":   (*Server).filesGetUploadURLExternal,
+		"files.completeUploadExternal": (*Server).filesCompleteUploadExternal,
+		"files.info":                   (*Server).filesInfo,
+		"files.list":                   (*Server).filesList,
+		"files.delete":                 (*Server).filesDelete,
+
+		"chat.scheduleMessage":        (*Server).chatScheduleMessage,
+		"chat.scheduledMessages.list": (*Server).chatScheduledMessagesList,
+		"chat.deleteScheduledMessage": (*Server).chatDeleteScheduledMessage,
+		"chat.postEphemeral":          (*Server).chatPostEphemeral,
+		"chat.meMessage":              (*Server).chatMeMessage,
+		"chat.getPermalink":           (*Server).chatGetPermalink,
+		"pins.add":                    (*Server).pinsAdd,
+		"pins.remove":                 (*Server).pinsRemove,
+		"pins.list":                   (*Server).pinsList,
+		"bookmarks.add":               (*Server).bookmarksAdd,
+		"bookmarks.edit":              (*Server).bookmarksEdit,
+		"bookmarks.remove":            (*Server).bookmarksRemove,
+		"bookmarks.list":              (*Server).bookmarksList,
+		"reminders.add":               (*Server).remindersAdd,
+		"reminders.list":              (*Server).remindersList,
+		"reminders.complete":          (*Server).remindersComplete,
+		"reminders.delete":            (*Server).remindersDelete,
+		"users.profile.set":           (*Server).usersProfileSet,
+		"users.getPresence":           (*Server).usersGetPresence,
+		"users.setPresence":           (*Server).usersSetPresence,
+		"dnd.info":                    (*Server).dndInfo,
+		"dnd.setSnooze":               (*Server).dndSetSnooze,
+		"dnd.endSnooze":               (*Server).dndEndSnooze,
+		"conversations.members":       (*Server).conversationsMembers,
+		"conversations.kick":          (*Server).conversationsKick,
+		"conversations.join":          (*Server).conversationsJoin,
+		"conversations.leave":         (*Server).conversationsLeave,
+		"conversations.rename":        (*Server).conversationsRename,
+		"users.conversations":         (*Server).usersConversations,
+		"usergroups.list":             (*Server).usergroupsList,
+		"usergroups.create":           (*Server).usergroupsCreate,
+		"usergroups.update":           (*Server).usergroupsUpdate,
+		"usergroups.disable":          (*Server).usergroupsDisable,
+		"usergroups.enable":           (*Server).usergroupsEnable,
+		"usergroups.users.list":       (*Server).usergroupsUsersList,
+		"usergroups.users.update":     (*Server).usergroupsUsersUpdate,
+	}
+}
+
+func (s *Server) authTest(r *request) (response, error) {
+	resp := response{
+		"url":     s.URL + "/",
+		"team":    TeamName,
+		"team_id": TeamID,
+		"user_id": r.token.userID,
+	}
+	if u := s.user(r.token.userID); u != nil {
+		resp["user"] = u.Name
+	}
+	if r.token.isBot {
+		resp["bot_id"] = BotID
+	}
+	return resp, nil
+}
+
+func (s *Server) teamInfo(r *request) (response, error) {
+	return response{"team": map[string]string{"id": TeamID, "name": TeamName, "domain": TeamDomain}}, nil
+}
+
+func (s *Server) usersList(r *request) (response, error) {
+	start, end, next, err := paginate(r, len(s.users))
+	if err != nil {
+		return nil, err
+	}
+	members := make([]slack.User, 0, end-start)
+	for _, u := range s.users[start:end] {
+		members = append(members, userJSON(u, r.token))
+	}
+	return response{
+		"members":           members,
+		"response_metadata": nextCursor(next),
+	}, nil
+}
+
+func (s *Server) usersInfo(r *request) (response, error) {
+	u := s.user(r.get("user"))
+	if u == nil {
+		return nil, errCode("user_not_found")
+	}
+	return response{"user": userJSON(*u, r.token)}, nil
+}
+
+// userJSON renders a user as the users.* methods return it. As with Slack,
+// emails are only shown to tokens with users:read.email.
+func userJSON(u slack.User, t tokenInfo) slack.User {
+	if !t.hasScope("users:read.email") {
+		u.Profile.Email = ""
+	}
+	return u
+}
+
+// --- Conversations ---
+
+// channelJSON renders a channel as the conversations.* methods return it
+// to userID
+func channelJSON(ch *channel, userID string) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":            ch.ID,
+		"name":          ch.Name,
+		"is_channel":    !ch.IsPrivate,
+		"is_group":      ch.IsPrivate,
+		"is_private":    ch.IsPrivate,
+		"is_archived":   ch.IsArchived,
+		"is_general":    ch.IsGeneral,
+		"is_shared":     ch.IsShared || ch.IsExtShared,
+		"is_ext_shared": ch.IsExtShared,
+		"is_member":     ch.isMember(userID),
+		"created":       ch.Created,
+		"creator":       ch.Creator,
+		"topic":         map[string]string{"value": ch.Topic},
+		"purpose":       map[string]string{"value": ch.Purpose},
+		"num_members":   len(ch.Members),
+	}
+	// Members have read up to the latest message
+	if ch.isMember(userID) {
+		lastRead := "0000000000.000000"
+		if n := len(ch.messages); n > 0 {
+			lastRead = ch.messages[n-1].TS
+		}
+		out["last_read"] = lastRead
+	}
+	return out
+}
+
+// lookupChannel returns the channel named by the "channel" parameter
+func (s *Server) lookupChannel(r *request) (*channel, error) {
+	return s.lookupChannelParam(r, "channel")
+}
+
+// lookupChannelParam is lookupChannel for methods that name the channel
+// with another parameter, such as channel_id
+func (s *Server) lookupChannelParam(r *request, param string) (*channel, error) {
+	ch := s.channel(r.get(param))
+	if ch == nil {
+		return nil, errCode("channel_not_found")
+	}
+	// Private channels are invisible to non-members
+	if ch.IsPrivate && !ch.isMember(r.token.userID) {
+		return nil, errCode("channel_not_found")
+	}
+	return ch, nil
+}
+
+func (s *Server) conversationsList(r *request) (response, error) {
+	return s.listChannels(r, "")
+}
+
+func (s *Server) usersConversations(r *request) (response, error) {
+	user := r.get("user")
+	if user == "" {
+		user = r.token.userID
+	} else if s.user(user) == nil {
+		return nil, errCode("user_not_found")
+	}
+	return s.listChannels(r, user)
+}
+
+// listChannels lists the channels of the types requested, only those
+// member belongs to if it is set
+func (s *Server) listChannels(r *request, member string) (response, error) {
+	types := r.get("types")
+	if types == "" {
+		types = "public_channel"
+	}
+	wantPublic := strings.Contains(types, "public_channel")
+	wantPrivate := strings.Contains(types, "private_channel")
+	excludeArchived := r.bool("exclude_archived")
+
+	matched := []map[string]interface{}{}
+	for _, ch := range s.channels {
+		if excludeArchived && ch.IsArchived {
+			continue
+		}
+		if ch.IsPrivate && (!wantPrivate || !ch.isMember(r.token.userID)) {
+			continue
+		}
+		if !ch.IsPrivate && !wantPublic {
+			continue
+		}
+		if member != "" && !ch.isMember(member) {
+			continue
+		}
+		matched = append(matched, channelJSON(ch, r.token.userID))
+	}
+
+	start, end, next, err := paginate(r, len(matched))
+	if err != nil {
+		return nil, err
+	}
+	return response{
+		"channels":          matched[start:end],
+		"response_metadata": nextCursor(next),
+	}, nil
+}
+
+func (s *Server) conversationsInfo(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	return response{"channel": channelJSON(ch, r.token.userID)}, nil
+}
+
+// messageJSON renders a message as the history methods return it
+func (s *Server) messageJSON(ch *channel, m *Message) map[string]interface{} {
+	out := map[string]interface{}{
+		"type": "message",
+		"user": m.User,
+		"text": m.Text,
+		"ts":   m.TS,
+	}
+	if m.BotID != "" {
+		out["bot_id"] = m.BotID
+		profile := map[string]interface{}{"id": m.BotID, "name": m.BotID}
+		if u := s.user(m.User); u != nil {
+			profile["name"] = u.Name
+		}
+		out["bot_profile"] = profile
+	}
+	if m.Subtype != "" {
+		out["subtype"] = m.Subtype
+	}
+	if len(m.Blocks) > 0 {
+		out["blocks"] = m.Blocks
+	}
+	if m.ThreadTS != "" {
+		out["thread_ts"] = m.ThreadTS
+	}
+	if ch.pinned(m.TS) != nil {
+		out["pinned_to"] = []string{ch.ID}
+	}
+	if m.EditedTS != "" {
+		out["edited"] = map[string]string{"user": m.User, "ts": m.EditedTS}
+	}
+	if replies := ch.replies(m.TS); len(replies) > 0 {
+		out["thread_ts"] = m.TS
+		out["reply_count"] = len(replies)
+		out["latest_reply"] = replies[len(replies)-1].TS
+	}
+	if len(m.Reactions) > 0 {
+		names := make([]string, 0, len(m.Reactions))
+		for name := range m.Reactions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var reactions []map[string]interface{}
+		for _, name := range names {
+			users := m.Reactions[name]
+			reactions = append(reactions, map[string]interface{}{"name": name, "users": users, "count": len(users)})
+		}
+		out["reactions"] = reactions
+	}
+	if len(m.Files) > 0 {
+		var files []map[string]interface{}
+		for _, id := range m.Files {
+			if f := s.file(id); f != nil {
+				files = append(files, s.fileJSON(f))
+			}
+		}
+		out["files"] = files
+	}
+	return out
+}
+
+func (s *Server) conversationsHistory(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	if !ch.isMember(r.token.userID) {
+		return nil, errCode("not_in_channel")
+	}
+
+	matched := []map[string]interface{}{}
+	// Newest first, top-level messages only
+	for i := len(ch.messages) - 1; i >= 0; i-- {
+		m := ch.messages[i]
+		if m.ThreadTS != "" && m.ThreadTS != m.TS {
+			continue
+		}
+		if !inRange(r, m.TS) {
+			continue
+		}
+		matched = append(matched, s.messageJSON(ch, m))
+	}
+
+	start, end, next, err := paginate(r, len(matched))
+	if err != nil {
+		return nil, err
+	}
+	return response{
+		"messages":          matched[start:end],
+		"has_more":          next != "",
+		"response_metadata": nextCursor(next),
+	}, nil
+}
+
+// inRange applies the oldest, latest and inclusive parameters to ts
+func inRange(r *request, ts string) bool {
+	oldest, latest := r.get("oldest"), r.get("latest")
+	if r.bool("inclusive") {
+		return (oldest == "" || !tsLess(ts, oldest)) && (latest == "" || !tsLess(latest, ts))
+	}
+	return (oldest == "" || tsLess(oldest, ts)) && (latest == "" || tsLess(ts, latest))
+}
+
+func (s *Server) conversationsReplies(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	if !ch.isMember(r.token.userID) {
+		return nil, errCode("not_in_channel")
+	}
+	parent := ch.message(r.get("ts"))
+	if parent == nil {
+		return nil, errCode("thread_not_found")
+	}
+
+	matched := []map[string]interface{}{s.messageJSON(ch, parent)}
+	for _, m := range ch.replies(parent.TS) {
+		if inRange(r, m.TS) {
+			matched = append(matched, s.messageJSON(ch, m))
+		}
+	}
+
+	start, end, next, err := paginate(r, len(matched))
+	if err != nil {
+		return nil, err
+	}
+	return response{
+		"messages":          matched[start:end],
+		"has_more":          next != "",
+		"response_metadata": nextCursor(next),
+	}, nil
+}
+
+var channelNamePattern = regexp.MustCompile(`^[a-z0-9_-]{1,80}$`)
+
+func (s *Server) conversationsCreate(r *request) (response, error) {
+	name := r.get("name")
+	if !channelNamePattern.MatchString(name) {
+		return nil, errCode("invalid_name_specials")
+	}
+	for _, ch := range s.channels {
+		if ch.Name == name {
+			return nil, errCode("name_taken")
+		}
+	}
+
+	s.seq++
+	ch := &channel{Channel: Channel{
+		ID:        idFor("C", s.seq),
+		Name:      name,
+		IsPrivate: r.bool("is_private"),
+		Creator:   r.token.userID,
+		Created:   s.epoch,
+		Members:   []string{r.token.userID},
+	}}
+	s.channels = append(s.channels, ch)
+	return response{"channel": channelJSON(ch, r.token.userID)}, nil
+}
+
+func (s *Server) conversationsArchive(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	if ch.IsArchived {
+		return nil, errCode("already_archived")
+	}
+	ch.IsArchived = true
+	return nil, nil
+}
+
+func (s *Server) conversationsUnarchive(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	if !ch.IsArchived {
+		return nil, errCode("not_archived")
+	}
+	ch.IsArchived = false
+	return nil, nil
+}
+
+// writableChannel returns the request's channel if the caller may change it
+func (s *Server) writableChannel(r *request) (*channel, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	if ch.IsArchived {
+		return nil, errCode("is_archived")
+	}
+	if !ch.isMember(r.token.userID) {
+		return nil, errCode("not_in_channel")
+	}
+	return ch, nil
+}
+
+func (s *Server) conversationsRename(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	name := r.get("name")
+	if !channelNamePattern.MatchString(name) {
+		return nil, errCode("invalid_name_specials")
+	}
+	for _, other := range s.channels {
+		if other != ch && other.Name == name {
+			return nil, errCode("name_taken")
+		}
+	}
+	ch.Name = name
+	return response{"channel": channelJSON(ch, r.token.userID)}, nil
+}
+
+func (s *Server) conversationsSetTopic(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	ch.Topic = r.get("topic")
+	return response{"channel": channelJSON(ch, r.token.userID)}, nil
+}
+
+func (s *Server) conversationsSetPurpose(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	ch.Purpose = r.get("purpose")
+	return response{"channel": channelJSON(ch, r.token.userID)}, nil
+}
+
+func (s *Server) conversationsInvite(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+
+	users := strings.Split(r.get("users"), ",")
+	for _, id := range users {
+		id = strings.TrimSpace(id)
+		if s.user(id) == nil {
+			return nil, errCode("user_not_found")
+		}
+		if ch.isMember(id) {
+			return nil, errCode("already_in_channel")
+		}
+		if id == r.token.userID {
+			return nil, errCode("cant_invite_self")
+		}
+	}
+	for _, id := range users {
+		ch.Members = append(ch.Members, strings.TrimSpace(id))
+	}
+	return response{"channel": channelJSON(ch, r.token.userID)}, nil
+}
+
+func (s *Server) conversationsMembers(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	start, end, next, err := paginate(r, len(ch.Members))
+	if err != nil {
+		return nil, err
+	}
+	return response{
+		"members":           ch.Members[start:end],
+		"response_metadata": nextCursor(next),
+	}, nil
+}
+
+func (s *Server) conversationsKick(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	id := r.get("user")
+	switch {
+	case s.user(id) == nil:
+		return nil, errCode("user_not_found")
+	case id == r.token.userID:
+		return nil, errCode("cant_kick_self")
+	case !ch.isMember(id):
+		return nil, errCode("not_in_channel")
+	}
+	ch.removeMember(id)
+	return nil, nil
+}
+
+func (s *Server) conversationsJoin(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	if ch.IsPrivate {
+		return nil, errCode("method_not_supported_for_channel_type")
+	}
+	if ch.IsArchived {
+		return nil, errCode("is_archived")
+	}
+	resp := response{}
+	if ch.isMember(r.token.userID) {
+		resp["already_in_channel"] = true
+	} else {
+		ch.Members = append(ch.Members, r.token.userID)
+	}
+	resp["channel"] = channelJSON(ch, r.token.userID)
+	return resp, nil
+}
+
+func (s *Server) conversationsLeave(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	if ch.IsArchived {
+		return nil, errCode("is_archived")
+	}
+	// Like Slack, leaving a channel you are not in succeeds with a flag
+	if !ch.isMember(r.token.userID) {
+		return response{"not_in_channel": true}, nil
+	}
+	ch.removeMember(r.token.userID)
+	return nil, nil
+}
+
+// --- Chat ---
+
+func (s *Server) chatPostMessage(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+
+	text := r.get("text")
+	blocks := r.json["blocks"]
+	if text == "" && len(blocks) == 0 {
+		return nil, errCode("no_text")
+	}
+
+	msg := &Message{User: r.token.userID, Text: text, TS: s.nextTS(), Blocks: blocks}
+	if r.token.isBot {
+		msg.BotID = BotID
+	}
+	if threadTS := r.get("thread_ts"); threadTS != "" {
+		if ch.message(threadTS) == nil {
+			return nil, errCode("thread_not_found")
+		}
+		msg.ThreadTS = threadTS
+	}
+	ch.messages = append(ch.messages, msg)
+
+	return response{
+		"channel": ch.ID,
+		"ts":      msg.TS,
+		"message": s.messageJSON(ch, msg),
+	}, nil
+}
+
+func (s *Server) chatPostEphemeral(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	user := r.get("user")
+	if s.user(user) == nil {
+		return nil, errCode("user_not_found")
+	}
+	if !ch.isMember(user) {
+		return nil, errCode("user_not_in_channel")
+	}
+
+	text := r.get("text")
+	blocks := r.json["blocks"]
+	if text == "" && len(blocks) == 0 {
+		return nil, errCode("no_text")
+	}
+	threadTS := r.get("thread_ts")
+	if threadTS != "" && ch.message(threadTS) == nil {
+		return nil, errCode("thread_not_found")
+	}
+
+	msg := Ephemeral{Channel: ch.ID, To: user, Message: Message{User: r.token.userID, Text: text, TS: s.nextTS(), ThreadTS: threadTS, Blocks: blocks}}
+	if r.token.isBot {
+		msg.BotID = BotID
+	}
+	s.ephemeral = append(s.ephemeral, msg)
+	return response{"message_ts": msg.TS}, nil
+}
+
+func (s *Server) chatMeMessage(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	text := r.get("text")
+	if text == "" {
+		return nil, errCode("no_text")
+	}
+	msg := &Message{User: r.token.userID, Text: text, TS: s.nextTS(), Subtype: "me_message"}
+	ch.messages = append(ch.messages, msg)
+	return response{"channel": ch.ID, "ts": msg.TS}, nil
+}
+
+// ownMessage returns the message named by channel and ts if the caller
+// posted it; otherwise it fails with denied
+func (s *Server) ownMessage(r *request, denied string) (*channel, *Message, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := ch.message(r.get("ts"))
+	if m == nil {
+		return nil, nil, errCode("message_not_found")
+	}
+	if m.User != r.token.userID {
+		return nil, nil, errCode(denied)
+	}
+	return ch, m, nil
+}
+
+func (s *Server) chatUpdate(r *request) (response, error) {
+	ch, m, err := s.ownMessage(r, "cant_update_message")
+	if err != nil {
+		return nil, err
+	}
+	m.Text = r.get("text")
+	if blocks, ok := r.json["blocks"]; ok {
+		m.Blocks = blocks
+	}
+	m.EditedTS = s.nextTS()
+	return response{"channel": ch.ID, "ts": m.TS, "text": m.Text}, nil
+}
+
+func (s *Server) chatDelete(r *request) (response, error) {
+	ch, m, err := s.ownMessage(r, "cant_delete_message")
+	if err != nil {
+		return nil, err
+	}
+	for i, existing := range ch.messages {
+		if existing == m {
+			ch.messages = append(ch.messages[:i], ch.messages[i+1:]...)
+			break
+		}
+	}
+	return response{"channel": ch.ID, "ts": m.TS}, nil
+}
+
+// --- Reactions ---
+
+// reactionTarget returns the message named by channel and timestamp
+func (s *Server) reactionTarget(r *request) (*Message, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	m := ch.message(r.get("timestamp"))
+	if m == nil {
+		return nil, errCode("message_not_found")
+	}
+	if r.get("name") == "" {
+		return nil, errCode("invalid_name")
+	}
+	return m, nil
+}
+
+func (s *Server) reactionsAdd(r *request) (response, error) {
+	m, err := s.reactionTarget(r)
+	if err != nil {
+		return nil, err
+	}
+	name := r.get("name")
+	for _, u := range m.Reactions[name] {
+		if u == r.token.userID {
+			return nil, errCode("already_reacted")
+		}
+	}
+	if m.Reactions == nil {
+		m.Reactions = make(map[string][]string)
+	}
+	m.Reactions[name] = append(m.Reactions[name], r.token.userID)
+	return nil, nil
+}
+
+func (s *Server) reactionsRemove(r *request) (response, error) {
+	m, err := s.reactionTarget(r)
+	if err != nil {
+		return nil, err
+	}
+	name := r.get("name")
+	users := m.Reactions[name]
+	for i, u := range users {
+		if u == r.token.userID {
+			users = append(users[:i], users[i+1:]...)
+			if len(users) == 0 {
+				delete(m.Reactions, name)
+			} else {
+				m.Reactions[name] = users
+			}
+			return nil, nil
+		}
+	}
+	return nil, errCode("no_reaction")
+}
+
+// --- Search ---
+
+// searchQuery is a parsed search query: plain terms plus in: and from:
+// modifiers
+type searchQuery struct {
+	terms []string
+	in    string
+	from  string
+}
+
+func parseQuery(q string) searchQuery {
+	var sq searchQuery
+	for _, f := range strings.Fields(q) {
+		switch {
+		case strings.HasPrefix(f, "in:"):
+			sq.in = strings.TrimLeft(strings.TrimPrefix(f, "in:"), "#")
+		case strings.HasPrefix(f, "from:"):
+			sq.from = strings.TrimLeft(strings.TrimPrefix(f, "from:"), "@")
+		default:
+			sq.terms = append(sq.terms, strings.ToLower(f))
+		}
+	}
+	return sq
+}
+
+func (sq searchQuery) matchesText(text string) bool {
+	text = strings.ToLower(text)
+	for _, t := range sq.terms {
+		if !strings.Contains(text, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) userMatches(id, name string) bool {
+	if id == name {
+		return true
+	}
+	u := s.user(id)
+	return u != nil && u.Name == name
+}
+
+func (s *Server) permalink(ch *channel, ts string) string {
+	return s.URL + "/archives/" + ch.ID + "/p" + strings.Replace(ts, ".", "", 1)
+}
+
+// searchPage slices n results by the count and page parameters
+func searchPage(r *request, n int) (start, end int, paging map[string]int) {
+	count := r.int("count", 20)
+	page := r.int("page", 1)
+	pages := (n + count - 1) / count
+	start = (page - 1) * count
+	if start > n {
+		start = n
+	}
+	end = start + count
+	if end > n {
+		end = n
+	}
+	return start, end, map[string]int{"count": count, "total": n, "page": page, "pages": pages}
+}
+
+func (s *Server) messageMatches(r *request) map[string]interface{} {
+	sq := parseQuery(r.get("query"))
+
+	type hit struct {
+		ch *channel
+		m  *Message
+	}
+	var hits []hit
+	for _, ch := range s.channels {
+		if ch.IsPrivate && !ch.isMember(r.token.userID) {
+			continue
+		}
+		if sq.in != "" && ch.Name != sq.in && ch.ID != sq.in {
+			continue
+		}
+		for _, m := range ch.messages {
+			if sq.from != "" && !s.userMatches(m.User, sq.from) {
+				continue
+			}
+			if sq.matchesText(m.Text) {
+				hits = append(hits, hit{ch, m})
+			}
+		}
+	}
+
+	asc := r.get("sort_dir") == "asc"
+	sort.SliceStable(hits, func(i, j int) bool {
+		if asc {
+			return tsLess(hits[i].m.TS, hits[j].m.TS)
+		}
+		return tsLess(hits[j].m.TS, hits[i].m.TS)
+	})
+
+	start, end, paging := searchPage(r, len(hits))
+	matches := make([]map[string]interface{}, 0, end-start)
+	for _, h := range hits[start:end] {
+		match := map[string]interface{}{
+			"type":      "message",
+			"channel":   map[string]string{"id": h.ch.ID, "name": h.ch.Name},
+			"user":      h.m.User,
+			"text":      h.m.Text,
+			"ts":        h.m.TS,
+			"permalink": s.permalink(h.ch, h.m.TS),
+		}
+		if u := s.user(h.m.User); u != nil {
+			match["username"] = u.Name
+		}
+		matches = append(matches, match)
+	}
+	return map[string]interface{}{"total": len(hits), "paging": paging, "matches": matches}
+}
+
+func (s *Server) fileMatches(r *request) map[string]interface{} {
+	sq := parseQuery(r.get("query"))
+
+	var hits []*File
+	for _, f := range s.files {
+		if sq.from != "" && !s.userMatches(f.User, sq.from) {
+			continue
+		}
+		if sq.in != "" && !s.fileInChannel(f, sq.in) {
+			continue
+		}
+		if sq.matchesText(f.Name + " " + f.Title) {
+			hits = append(hits, f)
+		}
+	}
+
+	asc := r.get("sort_dir") == "asc"
+	sort.SliceStable(hits, func(i, j int) bool {
+		if asc {
+			return hits[i].Created < hits[j].Created
+		}
+		return hits[j].Created < hits[i].Created
+	})
+
+	start, end, paging := searchPage(r, len(hits))
+	matches := make([]map[string]interface{}, 0, end-start)
+	for _, f := range hits[start:end] {
+		matches = append(matches, s.fileJSON(f))
+	}
+	return map[string]interface{}{"total": len(hits), "paging": paging, "matches": matches}
+}
+
+func (s *Server) fileInChannel(f *File, nameOrID string) bool {
+	for _, id := range f.Channels {
+		if id == nameOrID {
+			return true
+		}
+		if ch := s.channel(id); ch != nil && ch.Name == nameOrID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) searchMessages(r *request) (response, error) {
+	if r.get("query") == "" {
+		return nil, errCode("no_query")
+	}
+	return response{"query": r.get("query"), "messages": s.messageMatches(r)}, nil
+}
+
+func (s *Server) searchFiles(r *request) (response, error) {
+	if r.get("query") == "" {
+		return nil, errCode("no_query")
+	}
+	return response{"query": r.get("query"), "files": s.fileMatches(r)}, nil
+}
+
+func (s *Server) searchAll(r *request) (response, error) {
+	if r.get("query") == "" {
+		return nil, errCode("no_query")
+	}
+	return response{
+		"query":    r.get("query"),
+		"messages": s.messageMatches(r),
+		"files":    s.fileMatches(r),
+	}, nil
+}
+
+func (s *Server) chatGetPermalink(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	m := ch.message(r.get("message_ts"))
+	if m == nil {
+		return nil, errCode("message_not_found")
+	}
+	link := s.URL + "/archives/" + ch.ID + "/p" + strings.Replace(m.TS, ".", "", 1)
+	if m.ThreadTS != "" && m.ThreadTS != m.TS {
+		link += "?thread_ts=" + m.ThreadTS + "&cid=" + ch.ID
+	}
+	return response{"channel": ch.ID, "permalink": link}, nil
+}
@@ -0,0 +1,196 @@
+package slacktest
+
+import (
+	"time"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Pins returns the timestamps of the messages pinned in a channel, most
+// recently pinned first
+func (s *Server) Pins(channelID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.channel(channelID)
+	if ch == nil {
+		return nil
+	}
+	out := make([]string, 0, len(ch.pins))
+	for i := len(ch.pins) - 1; i >= 0; i-- {
+		out = append(out, ch.pins[i].ts)
+	}
+	return out
+}
+
+// Bookmarks returns a channel's bookmarks in display order
+func (s *Server) Bookmarks(channelID string) []slack.Bookmark {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.channel(channelID)
+	if ch == nil {
+		return nil
+	}
+	out := make([]slack.Bookmark, 0, len(ch.bookmarks))
+	for _, b := range ch.bookmarks {
+		out = append(out, *b)
+	}
+	return out
+}
+
+// pinnedMessage returns the channel and message named by the channel and
+// timestamp parameters, for a caller allowed to pin in the channel
+func (s *Server) pinnedMessage(r *request) (*channel, *Message, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := ch.message(r.get("timestamp"))
+	if m == nil {
+		return nil, nil, errCode("message_not_found")
+	}
+	return ch, m, nil
+}
+
+func (s *Server) pinsAdd(r *request) (response, error) {
+	ch, m, err := s.pinnedMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	if ch.pinned(m.TS) != nil {
+		return nil, errCode("already_pinned")
+	}
+	ch.pins = append(ch.pins, pin{ts: m.TS, by: r.token.userID, created: time.Now().Unix()})
+	return nil, nil
+}
+
+func (s *Server) pinsRemove(r *request) (response, error) {
+	ch, m, err := s.pinnedMessage(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range ch.pins {
+		if p.ts == m.TS {
+			ch.pins = append(ch.pins[:i], ch.pins[i+1:]...)
+			return nil, nil
+		}
+	}
+	return nil, errCode("no_pin")
+}
+
+func (s *Server) pinsList(r *request) (response, error) {
+	ch, err := s.lookupChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	items := []map[string]interface{}{}
+	for i := len(ch.pins) - 1; i >= 0; i-- {
+		p := ch.pins[i]
+		m := ch.message(p.ts)
+		if m == nil {
+			continue
+		}
+		items = append(items, map[string]interface{}{
+			"type":       "message",
+			"channel":    ch.ID,
+			"created":    p.created,
+			"created_by": p.by,
+			"message":    s.messageJSON(ch, m),
+		})
+	}
+	return response{"items": items}, nil
+}
+
+// bookmarkChannel returns the channel named by channel_id if the caller is
+// a member
+func (s *Server) bookmarkChannel(r *request) (*channel, error) {
+	ch, err := s.lookupChannelParam(r, "channel_id")
+	if err != nil {
+		return nil, err
+	}
+	if !ch.isMember(r.token.userID) {
+		return nil, errCode("not_in_channel")
+	}
+	return ch, nil
+}
+
+func (ch *channel) bookmark(id string) *slack.Bookmark {
+	for _, b := range ch.bookmarks {
+		if b.ID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+func (s *Server) bookmarksAdd(r *request) (response, error) {
+	ch, err := s.bookmarkChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	if r.get("title") == "" || r.get("type") != "link" || r.get("link") == "" {
+		return nil, errCode("invalid_arguments")
+	}
+
+	s.seq++
+	now := time.Now().Unix()
+	b := &slack.Bookmark{
+		ID:                  idFor("Bk", s.seq),
+		ChannelID:           ch.ID,
+		Title:               r.get("title"),
+		Link:                r.get("link"),
+		Emoji:               r.get("emoji"),
+		Type:                "link",
+		DateCreated:         now,
+		DateUpdated:         now,
+		LastUpdatedByUserID: r.token.userID,
+	}
+	ch.bookmarks = append(ch.bookmarks, b)
+	return response{"bookmark": b}, nil
+}
+
+func (s *Server) bookmarksEdit(r *request) (response, error) {
+	ch, err := s.bookmarkChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	b := ch.bookmark(r.get("bookmark_id"))
+	if b == nil {
+		return nil, errCode("not_found")
+	}
+	if r.has("title") {
+		b.Title = r.get("title")
+	}
+	if r.has("link") {
+		b.Link = r.get("link")
+	}
+	if r.has("emoji") {
+		b.Emoji = r.get("emoji")
+	}
+	b.DateUpdated = time.Now().Unix()
+	b.LastUpdatedByUserID = r.token.userID
+	return response{"bookmark": b}, nil
+}
+
+func (s *Server) bookmarksRemove(r *request) (response, error) {
+	ch, err := s.bookmarkChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, b := range ch.bookmarks {
+		if b.ID == r.get("bookmark_id") {
+			ch.bookmarks = append(ch.bookmarks[:i], ch.bookmarks[i+1:]...)
+			return nil, nil
+		}
+	}
+	return nil, errCode("not_found")
+}
+
+func (s *Server) bookmarksList(r *request) (response, error) {
+	ch, err := s.bookmarkChannel(r)
+	if err != nil {
+		return nil, err
+	}
+	bookmarks := make([]*slack.Bookmark, len(ch.bookmarks))
+	copy(bookmarks, ch.bookmarks)
+	return response{"bookmarks": bookmarks}, nil
+}
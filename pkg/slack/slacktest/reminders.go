@@ -0,0 +1,103 @@
+package slacktest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Reminders returns every reminder in the workspace, in creation order
+func (s *Server) Reminders() []slack.Reminder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]slack.Reminder, 0, len(s.reminders))
+	for _, rem := range s.reminders {
+		out = append(out, *rem)
+	}
+	return out
+}
+
+// reminder returns a reminder the caller created or is reminded by
+func (s *Server) reminder(r *request) (*slack.Reminder, error) {
+	id := r.get("reminder")
+	for _, rem := range s.reminders {
+		if rem.ID == id && (rem.Creator == r.token.userID || rem.User == r.token.userID) {
+			return rem, nil
+		}
+	}
+	return nil, errCode("not_found")
+}
+
+func (s *Server) remindersAdd(r *request) (response, error) {
+	text := r.get("text")
+	if text == "" {
+		return nil, errCode("no_text")
+	}
+	user := r.get("user")
+	if user == "" {
+		user = r.token.userID
+	} else if s.user(user) == nil {
+		return nil, errCode("user_not_found")
+	}
+
+	when := r.get("time")
+	rem := &slack.Reminder{Creator: r.token.userID, User: user, Text: text}
+	if strings.HasPrefix(when, "every ") {
+		rem.Recurring = true
+	} else {
+		t, err := strconv.ParseInt(when, 10, 64)
+		if err != nil {
+			return nil, errCode("cannot_parse")
+		}
+		if t <= time.Now().Unix() {
+			return nil, errCode("time_in_past")
+		}
+		rem.Time = t
+	}
+
+	s.seq++
+	rem.ID = idFor("Rm", s.seq)
+	s.reminders = append(s.reminders, rem)
+	return response{"reminder": rem}, nil
+}
+
+func (s *Server) remindersList(r *request) (response, error) {
+	out := []*slack.Reminder{}
+	for _, rem := range s.reminders {
+		if rem.Creator == r.token.userID || rem.User == r.token.userID {
+			out = append(out, rem)
+		}
+	}
+	return response{"reminders": out}, nil
+}
+
+func (s *Server) remindersComplete(r *request) (response, error) {
+	rem, err := s.reminder(r)
+	if err != nil {
+		return nil, err
+	}
+	if rem.Recurring {
+		return nil, errCode("cannot_complete_recurring")
+	}
+	if rem.CompleteTS != 0 {
+		return nil, errCode("already_complete")
+	}
+	rem.CompleteTS = time.Now().Unix()
+	return nil, nil
+}
+
+func (s *Server) remindersDelete(r *request) (response, error) {
+	rem, err := s.reminder(r)
+	if err != nil {
+		return nil, err
+	}
+	for i, existing := range s.reminders {
+		if existing == rem {
+			s.reminders = append(s.reminders[:i], s.reminders[i+1:]...)
+			break
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,126 @@
+package slacktest
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// maxScheduleAhead is how far ahead Slack accepts scheduled messages
+const maxScheduleAhead = 120 * 24 * time.Hour
+
+// ScheduledMessage is a message scheduled with chat.scheduleMessage. The
+// fake server never posts them; inspect them with ScheduledMessages.
+type ScheduledMessage struct {
+	ID       string
+	Channel  string
+	User     string
+	PostAt   int64
+	Created  int64
+	Text     string
+	ThreadTS string
+	Blocks   json.RawMessage
+}
+
+// ScheduledMessages returns the messages waiting to be posted, soonest first
+func (s *Server) ScheduledMessages() []ScheduledMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ScheduledMessage, 0, len(s.scheduled))
+	for _, m := range s.scheduled {
+		out = append(out, *m)
+	}
+	return out
+}
+
+func (s *Server) chatScheduleMessage(r *request) (response, error) {
+	ch, err := s.writableChannel(r)
+	if err != nil {
+		return nil, err
+	}
+
+	text := r.get("text")
+	blocks := r.json["blocks"]
+	if text == "" && len(blocks) == 0 {
+		return nil, errCode("no_text")
+	}
+	postAt, err := strconv.ParseInt(r.get("post_at"), 10, 64)
+	if err != nil {
+		return nil, errCode("invalid_time")
+	}
+	now := time.Now()
+	switch {
+	case postAt <= now.Unix():
+		return nil, errCode("time_in_past")
+	case postAt > now.Add(maxScheduleAhead).Unix():
+		return nil, errCode("time_too_far")
+	}
+	threadTS := r.get("thread_ts")
+	if threadTS != "" && ch.message(threadTS) == nil {
+		return nil, errCode("thread_not_found")
+	}
+
+	s.seq++
+	msg := &ScheduledMessage{
+		ID:       idFor("Q", s.seq),
+		Channel:  ch.ID,
+		User:     r.token.userID,
+		PostAt:   postAt,
+		Created:  now.Unix(),
+		Text:     text,
+		ThreadTS: threadTS,
+		Blocks:   blocks,
+	}
+	s.scheduled = append(s.scheduled, msg)
+	sort.SliceStable(s.scheduled, func(i, j int) bool { return s.scheduled[i].PostAt < s.scheduled[j].PostAt })
+
+	return response{
+		"channel":              ch.ID,
+		"scheduled_message_id": msg.ID,
+		"post_at":              msg.PostAt,
+		"message":              map[string]interface{}{"type": "message", "user": msg.User, "text": msg.Text},
+	}, nil
+}
+
+func (s *Server) chatScheduledMessagesList(r *request) (response, error) {
+	channelID := r.get("channel")
+	if channelID != "" && s.channel(channelID) == nil {
+		return nil, errCode("invalid_channel")
+	}
+
+	var matched []*ScheduledMessage
+	for _, m := range s.scheduled {
+		if m.User != r.token.userID || (channelID != "" && m.Channel != channelID) {
+			continue
+		}
+		matched = append(matched, m)
+	}
+
+	start, end, next, err := paginate(r, len(matched))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]map[string]interface{}, 0, end-start)
+	for _, m := range matched[start:end] {
+		out = append(out, map[string]interface{}{
+			"id":           m.ID,
+			"channel_id":   m.Channel,
+			"post_at":      m.PostAt,
+			"date_created": m.Created,
+			"text":         m.Text,
+		})
+	}
+	return response{"scheduled_messages": out, "response_metadata": nextCursor(next)}, nil
+}
+
+func (s *Server) chatDeleteScheduledMessage(r *request) (response, error) {
+	id := r.get("scheduled_message_id")
+	for i, m := range s.scheduled {
+		if m.ID == id && m.Channel == r.get("channel") && m.User == r.token.userID {
+			s.scheduled = append(s.scheduled[:i], s.scheduled[i+1:]...)
+			return nil, nil
+		}
+	}
+	return nil, errCode("invalid_scheduled_message_id")
+}
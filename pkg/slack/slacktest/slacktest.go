@@ -0,0 +1,376 @@
+// Package slacktest provides an in-memory fake of the Slack Web API for
+// tests. A Server keeps a workspace of channels, users, messages, threads,
+// reactions and files, and implements the methods pkg/slack calls with
+// Slack's cursor pagination, error codes and token scope checks.
+//
+//	srv := slacktest.NewServer()
+//	defer srv.Close()
+//	srv.AddChannel(slacktest.Channel{ID: "C1", Name: "general", Members: []string{slacktest.BotUserID}})
+//
+//	c := srv.Client(slacktest.BotToken)
+//	msg, err := c.SendMessage("C1", "hello", "", nil)
+package slacktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Default identities created by NewServer. Both tokens are granted every
+// scope; use AddToken for tokens with restricted scopes.
+const (
+	TeamID     = "T0000TEST"
+	TeamName   = "Test Workspace"
+	TeamDomain = "test-workspace"
+
+	BotToken  = "xoxb-slacktest"
+	BotUserID = "U0000BOT"
+	BotID     = "B0000BOT"
+
+	UserToken = "xoxp-slacktest"
+	UserID    = "U0000USER"
+)
+
+// Server is a fake Slack Web API server. It is safe for concurrent use.
+type Server struct {
+	// URL is the base URL to pass to slack.WithBaseURL
+	URL string
+
+	server *httptest.Server
+
+	mu        sync.Mutex
+	tokens    map[string]tokenInfo
+	channels  []*channel
+	users     []slack.User
+	files     []*File
+	uploads   map[string]*upload
+	scheduled []*ScheduledMessage
+	ephemeral []Ephemeral
+	reminders []*slack.Reminder
+	groups    []*slack.Usergroup
+	away      map[string]bool  // users who set themselves away
+	snoozes   map[string]int64 // DND snooze end time by user
+	injected  map[string][]string
+	calls     map[string]int
+	seq       int
+	epoch     int64
+}
+
+type tokenInfo struct {
+	userID string
+	isBot  bool
+	scopes []string // nil grants every scope
+}
+
+// NewServer starts a fake Slack server with a workspace containing a bot
+// user and a human user, authenticated by BotToken and UserToken.
+func NewServer() *Server {
+	s := &Server{
+		tokens:   make(map[string]tokenInfo),
+		uploads:  make(map[string]*upload),
+		injected: make(map[string][]string),
+		calls:    make(map[string]int),
+		away:     make(map[string]bool),
+		snoozes:  make(map[string]int64),
+		epoch:    time.Now().Unix(),
+	}
+
+	bot := slack.User{ID: BotUserID, Name: "slacktest-bot", RealName: "slacktest bot", IsBot: true}
+	human := slack.User{ID: UserID, Name: "tester", RealName: "Test User"}
+	human.Profile.Email = "tester@example.com"
+	s.users = append(s.users, bot, human)
+	s.tokens[BotToken] = tokenInfo{userID: BotUserID, isBot: true}
+	s.tokens[UserToken] = tokenInfo{userID: UserID}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Client returns a pkg/slack client for the server authenticated with token
+func (s *Server) Client(token string, opts ...slack.Option) *slack.Client {
+	return slack.New(append([]slack.Option{slack.WithBaseURL(s.URL), slack.WithToken(token)}, opts...)...)
+}
+
+// AddToken registers a token acting as userID. Requests fail with
+// missing_scope unless scopes include one the method accepts; with no scopes
+// the token is granted everything. Bot tokens cannot call user-only methods
+// such as search.messages.
+func (s *Server) AddToken(token, userID string, isBot bool, scopes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(scopes) == 0 {
+		scopes = nil
+	}
+	s.tokens[token] = tokenInfo{userID: userID, isBot: isBot, scopes: scopes}
+}
+
+// InjectError makes the next call to method fail with code. Calls queue, so
+// injecting twice fails the next two calls. "ratelimited" responds with
+// HTTP 429 and Retry-After: 0, as Slack does.
+func (s *Server) InjectError(method, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.injected[method] = append(s.injected[method], code)
+}
+
+// Calls returns how many times method has been called, including failed
+// calls
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// request is a parsed API call. Parameters may arrive in the query string,
+// a form body or a JSON body, as with the real API.
+type request struct {
+	method string
+	token  tokenInfo
+	values url.Values
+	json   map[string]json.RawMessage
+}
+
+// has reports whether a parameter was sent, even if empty
+func (r *request) has(key string) bool {
+	if _, ok := r.values[key]; ok {
+		return true
+	}
+	_, ok := r.json[key]
+	return ok
+}
+
+// get returns a parameter as a string. JSON strings are unquoted; other JSON
+// values are returned as their literal text.
+func (r *request) get(key string) string {
+	if v := r.values.Get(key); v != "" {
+		return v
+	}
+	raw, ok := r.json[key]
+	if !ok {
+		return ""
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str
+	}
+	return string(raw)
+}
+
+func (r *request) bool(key string) bool {
+	b, _ := strconv.ParseBool(r.get(key))
+	return b
+}
+
+func (r *request) int(key string, def int) int {
+	if n, err := strconv.Atoi(r.get(key)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// apiError is a Slack error response
+type apiError struct {
+	code   string
+	needed string
+}
+
+func (e *apiError) Error() string {
+	return e.code
+}
+
+func errCode(code string) error {
+	return &apiError{code: code}
+}
+
+type response map[string]interface{}
+
+type handler func(s *Server, r *request) (response, error)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case strings.HasPrefix(r.URL.Path, uploadPath):
+		s.serveUpload(w, r)
+		return
+	case strings.HasPrefix(r.URL.Path, downloadPath):
+		s.serveDownload(w, r)
+		return
+	}
+
+	method := strings.TrimPrefix(r.URL.Path, "/")
+	req := &request{method: method, values: r.URL.Query()}
+
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&req.json); err != nil && r.ContentLength != 0 {
+			writeError(w, &apiError{code: "invalid_json"})
+			return
+		}
+	} else if err := r.ParseForm(); err == nil {
+		for k, v := range r.PostForm {
+			req.values[k] = v
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[method]++
+
+	if queue := s.injected[method]; len(queue) > 0 {
+		s.injected[method] = queue[1:]
+		if queue[0] == "ratelimited" {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		writeError(w, errCode(queue[0]))
+		return
+	}
+
+	h, ok := handlers[method]
+	if !ok {
+		writeError(w, errCode("unknown_method"))
+		return
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = req.get("token")
+	}
+	if token == "" {
+		writeError(w, errCode("not_authed"))
+		return
+	}
+	info, ok := s.tokens[token]
+	if !ok {
+		writeError(w, errCode("invalid_auth"))
+		return
+	}
+	req.token = info
+
+	if info.scopes != nil {
+		w.Header().Set("X-OAuth-Scopes", strings.Join(info.scopes, ","))
+	}
+	if err := checkScopes(w, method, info, s.private(method, req)); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp, err := h(s, req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if resp == nil {
+		resp = response{}
+	}
+	resp["ok"] = true
+	writeJSON(w, resp)
+}
+
+// checkScopes fails the call unless the token holds one of the scopes the
+// method accepts, which for some methods depend on whether the conversation
+// is private
+func checkScopes(w http.ResponseWriter, method string, info tokenInfo, private bool) error {
+	if userOnlyMethods[method] && info.isBot {
+		return errCode("not_allowed_token_type")
+	}
+
+	accepted := methodScopes[method]
+	if scopes, ok := privateScopes[method]; ok && private {
+		accepted = scopes
+	}
+	if len(accepted) == 0 {
+		return nil
+	}
+	w.Header().Set("X-Accepted-OAuth-Scopes", strings.Join(accepted, ","))
+	if info.scopes == nil {
+		return nil
+	}
+	for _, a := range accepted {
+		for _, g := range info.scopes {
+			if a == g {
+				return nil
+			}
+		}
+	}
+	return &apiError{code: "missing_scope", needed: accepted[0]}
+}
+
+// private reports whether a call that has privateScopes targets a private
+// channel: the channel it creates, or the one it names
+func (s *Server) private(method string, r *request) bool {
+	if _, ok := privateScopes[method]; !ok {
+		return false
+	}
+	if method == "conversations.create" {
+		return r.bool("is_private")
+	}
+	ch := s.channel(r.get("channel"))
+	return ch != nil && ch.IsPrivate
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	resp := response{"ok": false}
+	if e, ok := err.(*apiError); ok {
+		resp["error"] = e.code
+		if e.needed != "" {
+			resp["needed"] = e.needed
+			resp["provided"] = w.Header().Get("X-OAuth-Scopes")
+		}
+	} else {
+		resp["error"] = err.Error()
+	}
+	writeJSON(w, resp)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// nextTS returns a unique, increasing message timestamp
+func (s *Server) nextTS() string {
+	s.seq++
+	return fmt.Sprintf("%d.%06d", s.epoch, s.seq)
+}
+
+// paginate returns the page of n items starting at the request's cursor, and
+// the cursor for the next page ("" on the last page). Cursors this server
+// did not issue fail with invalid_cursor, as they do on Slack.
+func paginate(r *request, n int) (start, end int, next string, err error) {
+	limit := r.int("limit", 100)
+	if limit > 1000 {
+		limit = 1000
+	}
+	if c := r.get("cursor"); c != "" {
+		offset, ok := strings.CutPrefix(c, "next:")
+		if start, err = strconv.Atoi(offset); !ok || err != nil || start < 0 {
+			return 0, 0, "", errCode("invalid_cursor")
+		}
+	}
+	if start > n {
+		start = n
+	}
+	end = start + limit
+	if end >= n {
+		return start, n, "", nil
+	}
+	return start, end, fmt.Sprintf("next:%d", end), nil
+}
+
+func nextCursor(next string) response {
+	return response{"next_cursor": next}
+}
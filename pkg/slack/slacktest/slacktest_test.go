@@ -0,0 +1,614 @@
+package slacktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+func newTestServer(t *testing.T) (*Server, string) {
+	t.Helper()
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+	id := srv.AddChannel(Channel{ID: "C0000GEN", Name: "general", Members: []string{BotUserID, UserID}})
+	return srv, id
+}
+
+func apiCode(err error) string {
+	var apiErr *slack.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return ""
+}
+
+func TestServer_MessagesThreadsAndReactions(t *testing.T) {
+	srv, general := newTestServer(t)
+	c := srv.Client(BotToken)
+
+	parent, err := c.SendMessage(general, "deploy started", "", nil)
+	require.NoError(t, err)
+	_, err = c.SendMessage(general, "step 1 done", parent.TS, nil)
+	require.NoError(t, err)
+	require.NoError(t, c.AddReaction(general, parent.TS, "rocket"))
+	assert.Equal(t, "already_reacted", apiCode(c.AddReaction(general, parent.TS, "rocket")))
+
+	history, err := c.GetChannelHistory(general, 10, "", "")
+	require.NoError(t, err)
+	require.Len(t, history, 1, "replies are not part of channel history")
+	assert.Equal(t, "deploy started", history[0].Text)
+	assert.Equal(t, 1, history[0].ReplyCount)
+
+	replies, err := c.GetThreadReplies(general, parent.TS, 10)
+	require.NoError(t, err)
+	require.Len(t, replies, 2)
+	assert.Equal(t, "step 1 done", replies[1].Text)
+
+	require.NoError(t, c.UpdateMessage(general, parent.TS, "deploy finished", nil))
+	msgs := srv.Messages(general)
+	assert.Equal(t, "deploy finished", msgs[0].Text)
+	assert.Equal(t, []string{BotUserID}, msgs[0].Reactions["rocket"])
+
+	require.NoError(t, c.RemoveReaction(general, parent.TS, "rocket"))
+	assert.Equal(t, "no_reaction", apiCode(c.RemoveReaction(general, parent.TS, "rocket")))
+
+	// Only the author may delete
+	userClient := srv.Client(UserToken)
+	assert.Equal(t, "cant_delete_message", apiCode(userClient.DeleteMessage(general, parent.TS)))
+	require.NoError(t, c.DeleteMessage(general, parent.TS))
+	assert.Len(t, srv.Messages(general), 1)
+}
+
+func TestServer_CursorPagination(t *testing.T) {
+	srv, general := newTestServer(t)
+	for i := 0; i < 450; i++ {
+		srv.AddMessage(general, Message{User: UserID, Text: fmt.Sprintf("message %d", i)})
+	}
+	c := srv.Client(BotToken)
+
+	history, err := c.GetChannelHistory(general, 1000, "", "")
+	require.NoError(t, err)
+	assert.Len(t, history, 450)
+	assert.Equal(t, "message 449", history[0].Text, "newest first")
+	assert.Equal(t, 3, srv.Calls("conversations.history"), "fetched in pages of 200")
+
+	limited, err := c.GetChannelHistory(general, 250, "", "")
+	require.NoError(t, err)
+	assert.Len(t, limited, 250)
+
+	for _, cursor := range []string{"bogus", "next:abc", "next:-5", "200"} {
+		req, err := http.NewRequest("GET", srv.URL+"/conversations.history?channel="+general+"&cursor="+url.QueryEscape(cursor), nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+BotToken)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		var body struct {
+			OK    bool   `json:"ok"`
+			Error string `json:"error"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		_ = resp.Body.Close()
+		assert.Equal(t, "invalid_cursor", body.Error, cursor)
+	}
+}
+
+func TestServer_Channels(t *testing.T) {
+	srv, general := newTestServer(t)
+	srv.AddChannel(Channel{ID: "C0000SEC", Name: "secret", IsPrivate: true, Members: []string{UserID}})
+	c := srv.Client(BotToken)
+
+	created, err := c.CreateChannel("incidents", false)
+	require.NoError(t, err)
+	_, err = c.CreateChannel("incidents", false)
+	assert.Equal(t, "name_taken", apiCode(err))
+
+	require.NoError(t, c.SetChannelTopic(created.ID, "On fire"))
+	require.NoError(t, c.InviteToChannel(created.ID, []string{UserID}))
+	assert.Equal(t, "already_in_channel", apiCode(c.InviteToChannel(created.ID, []string{UserID})))
+
+	info, err := c.GetChannelInfo(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "On fire", info.Topic.Value)
+	assert.Equal(t, 2, info.NumMembers)
+
+	require.NoError(t, c.ArchiveChannel(created.ID))
+	assert.Equal(t, "already_archived", apiCode(c.ArchiveChannel(created.ID)))
+	_, err = c.SendMessage(created.ID, "hello?", "", nil)
+	assert.Equal(t, "is_archived", apiCode(err))
+
+	channels, err := c.ListChannels("public_channel,private_channel", true, 100)
+	require.NoError(t, err)
+	require.Len(t, channels, 1, "archived and non-member private channels are hidden")
+	assert.Equal(t, general, channels[0].ID)
+
+	_, err = c.GetChannelInfo("C0000SEC")
+	assert.Equal(t, "channel_not_found", apiCode(err))
+}
+
+func TestServer_NotInChannel(t *testing.T) {
+	srv, _ := newTestServer(t)
+	random := srv.AddChannel(Channel{Name: "random", Members: []string{UserID}})
+	c := srv.Client(BotToken)
+
+	_, err := c.SendMessage(random, "hi", "", nil)
+	assert.Equal(t, "not_in_channel", apiCode(err))
+	_, err = c.GetChannelHistory(random, 10, "", "")
+	assert.Equal(t, "not_in_channel", apiCode(err))
+}
+
+func TestServer_InjectError(t *testing.T) {
+	srv, general := newTestServer(t)
+	c := srv.Client(BotToken)
+
+	srv.InjectError("chat.postMessage", "ratelimited")
+	_, err := c.SendMessage(general, "retried", "", nil)
+	require.NoError(t, err, "the client retries rate-limited calls")
+	assert.Equal(t, 2, srv.Calls("chat.postMessage"))
+
+	srv.InjectError("chat.postMessage", "ratelimited")
+	_, err = srv.Client(BotToken, slack.WithRetryPolicy(slack.NoRetry)).SendMessage(general, "dropped", "", nil)
+	assert.Equal(t, "ratelimited", apiCode(err))
+
+	srv.InjectError("conversations.info", "not_in_channel")
+	_, err = c.GetChannelInfo(general)
+	assert.Equal(t, "not_in_channel", apiCode(err))
+	_, err = c.GetChannelInfo(general)
+	assert.NoError(t, err, "injected errors apply once")
+}
+
+func TestServer_TokensAndScopes(t *testing.T) {
+	srv, general := newTestServer(t)
+	srv.AddToken("xoxb-readonly", BotUserID, true, "channels:read", "channels:history")
+
+	_, err := srv.Client("xoxb-unknown").GetTeamInfo()
+	assert.Equal(t, "invalid_auth", apiCode(err))
+
+	readonly := srv.Client("xoxb-readonly")
+	_, err = readonly.GetChannelHistory(general, 10, "", "")
+	assert.NoError(t, err)
+
+	_, err = readonly.SendMessage(general, "hi", "", nil)
+	var apiErr *slack.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "missing_scope", apiErr.Code)
+	assert.Equal(t, "chat:write", apiErr.Needed)
+	assert.Equal(t, []string{"chat:write"}, apiErr.AcceptedScopes)
+
+	info, err := readonly.AuthTest()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"channels:read", "channels:history"}, info.Scopes)
+	assert.Equal(t, BotUserID, info.UserID)
+
+	_, err = srv.Client(BotToken).SearchMessages("hi", 20, 1, "timestamp", "desc", false)
+	assert.Equal(t, "not_allowed_token_type", apiCode(err))
+}
+
+func TestServer_PrivateChannelsNeedGroupsWrite(t *testing.T) {
+	srv, general := newTestServer(t)
+	secret := srv.AddChannel(Channel{Name: "secret", IsPrivate: true, Members: []string{BotUserID}})
+	srv.AddToken("xoxb-public", BotUserID, true, "channels:manage")
+	srv.AddToken("xoxb-private", BotUserID, true, "groups:write")
+
+	public := srv.Client("xoxb-public")
+	assert.NoError(t, public.SetChannelTopic(general, "hi"))
+	err := public.SetChannelTopic(secret, "hi")
+	assert.Equal(t, "missing_scope", apiCode(err))
+	_, err = public.CreateChannel("hidden", true)
+	assert.Equal(t, "missing_scope", apiCode(err))
+
+	private := srv.Client("xoxb-private")
+	assert.NoError(t, private.SetChannelTopic(secret, "hi"))
+	_, err = private.CreateChannel("hidden", true)
+	assert.NoError(t, err)
+	err = private.SetChannelTopic(general, "hi")
+	assert.Equal(t, "missing_scope", apiCode(err))
+}
+
+func TestServer_EmailsNeedScope(t *testing.T) {
+	srv, _ := newTestServer(t)
+	srv.AddToken("xoxb-noemail", BotUserID, true, "users:read")
+
+	users, err := srv.Client("xoxb-noemail").ListUsers(100)
+	require.NoError(t, err)
+	for _, u := range users {
+		assert.Empty(t, u.Profile.Email, u.ID)
+	}
+	u, err := srv.Client("xoxb-noemail").GetUserInfo(UserID)
+	require.NoError(t, err)
+	assert.Empty(t, u.Profile.Email)
+
+	u, err = srv.Client(BotToken).GetUserInfo(UserID)
+	require.NoError(t, err)
+	assert.Equal(t, "tester@example.com", u.Profile.Email)
+}
+
+func TestServer_Search(t *testing.T) {
+	srv, general := newTestServer(t)
+	random := srv.AddChannel(Channel{Name: "random", Members: []string{UserID}})
+	srv.AddMessage(general, Message{User: UserID, Text: "Deploy failed on staging"})
+	srv.AddMessage(general, Message{User: BotUserID, Text: "deploy succeeded"})
+	srv.AddMessage(random, Message{User: UserID, Text: "lunch?"})
+	srv.AddFile(File{Name: "deploy.log", Title: "Deploy log", Filetype: "text", User: UserID, Channels: []string{general}})
+
+	c := srv.Client(UserToken)
+
+	result, err := c.SearchMessages("deploy", 20, 1, "timestamp", "desc", false)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Messages.Total)
+	assert.Equal(t, "deploy succeeded", result.Messages.Matches[0].Text)
+	assert.Equal(t, "general", result.Messages.Matches[0].Channel.Name)
+	assert.NotEmpty(t, result.Messages.Matches[0].Permalink)
+
+	result, err = c.SearchMessages("deploy from:@tester in:#general", 20, 1, "timestamp", "desc", false)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Messages.Total)
+	assert.Equal(t, "Deploy failed on staging", result.Messages.Matches[0].Text)
+
+	result, err = c.SearchMessages("deploy", 1, 2, "timestamp", "desc", false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Messages.Paging.Pages)
+	require.Len(t, result.Messages.Matches, 1)
+	assert.Equal(t, "Deploy failed on staging", result.Messages.Matches[0].Text)
+
+	result, err = c.SearchAll("deploy in:general", 20, 1, "timestamp", "desc", false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Files.Total)
+	assert.Equal(t, "deploy.log", result.Files.Matches[0].Name)
+}
+
+func TestServer_FilesUploadDownloadDelete(t *testing.T) {
+	srv, general := newTestServer(t)
+	c := srv.Client(BotToken)
+
+	uploaded, err := c.UploadFiles([]slack.FileUpload{
+		{Name: "report.csv", Content: strings.NewReader("a,b\n1,2\n"), Size: 8},
+		{Name: "notes.txt", Title: "Notes", Content: strings.NewReader("hi"), Size: 2},
+	}, slack.UploadOptions{Channel: general, InitialComment: "weekly numbers"})
+	require.NoError(t, err)
+	require.Len(t, uploaded, 2)
+	assert.Equal(t, "report.csv", uploaded[0].Title)
+	assert.Equal(t, "Notes", uploaded[1].Title)
+
+	msgs := srv.Messages(general)
+	require.Len(t, msgs, 1, "files are shared together in one message")
+	assert.Equal(t, "weekly numbers", msgs[0].Text)
+	assert.Equal(t, []string{uploaded[0].ID, uploaded[1].ID}, msgs[0].Files)
+
+	info, err := c.GetFileInfo(uploaded[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), info.Size)
+	assert.Equal(t, "csv", info.Filetype)
+	assert.Equal(t, []string{general}, info.Channels)
+
+	var buf bytes.Buffer
+	n, err := c.DownloadFile(info.URLPrivateDownload, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), n)
+	assert.Equal(t, "a,b\n1,2\n", buf.String())
+
+	files, err := c.ListFiles(general, "", "csv", 10)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "report.csv", files[0].Name)
+
+	// Only the uploader may delete
+	assert.Equal(t, "cant_delete_file", apiCode(srv.Client(UserToken).DeleteFile(info.ID)))
+	require.NoError(t, c.DeleteFile(info.ID))
+	_, err = c.GetFileInfo(info.ID)
+	assert.Equal(t, "file_not_found", apiCode(err))
+}
+
+func TestServer_FilesRequireScopesAndMembership(t *testing.T) {
+	srv, _ := newTestServer(t)
+	random := srv.AddChannel(Channel{Name: "random", Members: []string{UserID}})
+	c := srv.Client(BotToken)
+
+	_, err := c.UploadFiles([]slack.FileUpload{{Name: "a.txt", Content: strings.NewReader("a"), Size: 1}},
+		slack.UploadOptions{Channel: random})
+	assert.Equal(t, "not_in_channel", apiCode(err))
+
+	_, err = c.UploadFiles([]slack.FileUpload{{Name: "a.txt", Content: strings.NewReader("abc"), Size: 1}},
+		slack.UploadOptions{})
+	assert.Error(t, err, "content longer than the reserved length is rejected")
+
+	srv.AddFile(File{ID: "F0000LOG", Name: "deploy.log", User: UserID, Content: []byte("ok")})
+	srv.AddToken("xoxb-nofiles", BotUserID, true, "chat:write")
+	info, err := c.GetFileInfo("F0000LOG")
+	require.NoError(t, err)
+
+	_, err = srv.Client("xoxb-nofiles").DownloadFile(info.URLPrivateDownload, io.Discard)
+	assert.ErrorContains(t, err, "files:read")
+}
+
+func TestServer_ScheduledMessages(t *testing.T) {
+	srv, general := newTestServer(t)
+	c := srv.Client(BotToken)
+
+	later := time.Now().Add(2 * time.Hour)
+	soon := time.Now().Add(time.Hour)
+	first, err := c.ScheduleMessage(general, "later", later, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, general, first.ChannelID)
+	assert.Equal(t, later.Unix(), first.PostAt)
+	_, err = c.ScheduleMessage(general, "soon", soon, "", nil)
+	require.NoError(t, err)
+
+	_, err = c.ScheduleMessage(general, "too late", time.Now().Add(-time.Minute), "", nil)
+	assert.Equal(t, "time_in_past", apiCode(err))
+	_, err = c.ScheduleMessage(general, "too far", time.Now().Add(200*24*time.Hour), "", nil)
+	assert.Equal(t, "time_too_far", apiCode(err))
+
+	list, err := c.ListScheduledMessages(general, 1)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "soon", list[0].Text, "soonest first")
+	list, err = c.ListScheduledMessages("", 10)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	// Other users see only their own
+	list, err = srv.Client(UserToken).ListScheduledMessages("", 10)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	require.NoError(t, c.DeleteScheduledMessage(general, first.ID))
+	assert.Equal(t, "invalid_scheduled_message_id", apiCode(c.DeleteScheduledMessage(general, first.ID)))
+	assert.Len(t, srv.ScheduledMessages(), 1)
+	assert.Empty(t, srv.Messages(general), "scheduled messages are never posted")
+}
+
+func TestServer_EphemeralAndMeMessages(t *testing.T) {
+	srv, general := newTestServer(t)
+	random := srv.AddChannel(Channel{Name: "random", Members: []string{BotUserID}})
+	c := srv.Client(BotToken)
+
+	ts, err := c.SendEphemeral(general, UserID, "only you can see this", "", nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, ts)
+	_, err = c.SendEphemeral(random, UserID, "hi", "", nil)
+	assert.Equal(t, "user_not_in_channel", apiCode(err))
+
+	eph := srv.EphemeralMessages()
+	require.Len(t, eph, 1)
+	assert.Equal(t, UserID, eph[0].To)
+	assert.Equal(t, "only you can see this", eph[0].Text)
+	assert.Empty(t, srv.Messages(general), "ephemeral messages are not in history")
+
+	msg, err := c.SendMeMessage(general, "is deploying")
+	require.NoError(t, err)
+	history, err := c.GetChannelHistory(general, 10, "", "")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Equal(t, msg.TS, history[0].TS)
+	assert.Equal(t, "me_message", srv.Messages(general)[0].Subtype)
+}
+
+func TestServer_PermalinkAndGetMessage(t *testing.T) {
+	srv, general := newTestServer(t)
+	c := srv.Client(BotToken)
+
+	parent, err := c.SendMessage(general, "deploy", "", nil)
+	require.NoError(t, err)
+	reply, err := c.SendMessage(general, "done", parent.TS, nil)
+	require.NoError(t, err)
+	require.NoError(t, c.AddReaction(general, parent.TS, "tada"))
+	require.NoError(t, c.UpdateMessage(general, parent.TS, "deploy v2", nil))
+
+	msg, err := c.GetMessage(general, parent.TS, "")
+	require.NoError(t, err)
+	assert.Equal(t, "deploy v2", msg.Text)
+	assert.Equal(t, 1, msg.ReplyCount)
+	require.NotNil(t, msg.Edited)
+	assert.Equal(t, BotUserID, msg.Edited.User)
+	require.Len(t, msg.Reactions, 1)
+	assert.Equal(t, "tada", msg.Reactions[0].Name)
+
+	_, err = c.GetMessage(general, reply.TS, "")
+	assert.Equal(t, "message_not_found", apiCode(err), "replies are not in history")
+
+	link, err := c.GetPermalink(general, reply.TS)
+	require.NoError(t, err)
+	ref, err := slack.ParsePermalink(link)
+	require.NoError(t, err)
+	assert.Equal(t, slack.MessageRef{Channel: general, TS: reply.TS, ThreadTS: parent.TS}, ref)
+
+	msg, err = c.GetMessage(ref.Channel, ref.TS, ref.ThreadTS)
+	require.NoError(t, err)
+	assert.Equal(t, "done", msg.Text)
+}
+
+func TestServer_PinsAndBookmarks(t *testing.T) {
+	srv, general := newTestServer(t)
+	c := srv.Client(BotToken)
+	ts := srv.AddMessage(general, Message{User: UserID, Text: "runbook"})
+
+	require.NoError(t, c.AddPin(general, ts))
+	assert.Equal(t, "already_pinned", apiCode(c.AddPin(general, ts)))
+	items, err := c.ListPins(general)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, BotUserID, items[0].CreatedBy)
+	require.NotNil(t, items[0].Message)
+	assert.Equal(t, "runbook", items[0].Message.Text)
+	require.NoError(t, c.RemovePin(general, ts))
+	assert.Equal(t, "no_pin", apiCode(c.RemovePin(general, ts)))
+
+	b, err := c.AddBookmark(general, "Runbook", "https://wiki.example.com", ":book:")
+	require.NoError(t, err)
+	title := "Runbook v2"
+	edited, err := c.EditBookmark(general, b.ID, slack.BookmarkUpdate{Title: &title})
+	require.NoError(t, err)
+	assert.Equal(t, "Runbook v2", edited.Title)
+	assert.Equal(t, ":book:", edited.Emoji)
+	list, err := c.ListBookmarks(general)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.NoError(t, c.RemoveBookmark(general, b.ID))
+	assert.Equal(t, "not_found", apiCode(c.RemoveBookmark(general, b.ID)))
+}
+
+func TestServer_Reminders(t *testing.T) {
+	srv, _ := newTestServer(t)
+	c := srv.Client(UserToken)
+	when := strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)
+
+	_, err := srv.Client(BotToken).AddReminder("stretch", when, "")
+	assert.Equal(t, "not_allowed_token_type", apiCode(err))
+
+	rem, err := c.AddReminder("stretch", when, "")
+	require.NoError(t, err)
+	assert.Equal(t, UserID, rem.User)
+	weekly, err := c.AddReminder("standup", "every monday at 9am", BotUserID)
+	require.NoError(t, err)
+	assert.True(t, weekly.Recurring)
+	_, err = c.AddReminder("late", "1", "")
+	assert.Equal(t, "time_in_past", apiCode(err))
+
+	require.NoError(t, c.CompleteReminder(rem.ID))
+	assert.Equal(t, "already_complete", apiCode(c.CompleteReminder(rem.ID)))
+	assert.Equal(t, "cannot_complete_recurring", apiCode(c.CompleteReminder(weekly.ID)))
+
+	list, err := c.ListReminders()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.NotZero(t, list[0].CompleteTS)
+
+	require.NoError(t, c.DeleteReminder(weekly.ID))
+	assert.Equal(t, "not_found", apiCode(c.DeleteReminder(weekly.ID)))
+	assert.Len(t, srv.Reminders(), 1)
+}
+
+func TestServer_StatusPresenceAndDND(t *testing.T) {
+	srv, _ := newTestServer(t)
+	c := srv.Client(UserToken)
+
+	assert.Equal(t, "not_allowed_token_type", apiCode(srv.Client(BotToken).ClearStatus()))
+
+	until := time.Now().Add(24 * time.Hour)
+	require.NoError(t, c.SetStatus(":palm_tree:", "On vacation", until))
+	u, err := c.GetUserInfo(UserID)
+	require.NoError(t, err)
+	assert.Equal(t, ":palm_tree:", u.Profile.StatusEmoji)
+	assert.Equal(t, "On vacation", u.Profile.StatusText)
+	assert.Equal(t, until.Unix(), u.Profile.StatusExpiration)
+	require.NoError(t, c.ClearStatus())
+	u, err = c.GetUserInfo(UserID)
+	require.NoError(t, err)
+	assert.Empty(t, u.Profile.StatusText)
+
+	require.NoError(t, c.SetPresence("away"))
+	p, err := c.GetPresence("")
+	require.NoError(t, err)
+	assert.Equal(t, "away", p.Presence)
+	assert.True(t, p.ManualAway)
+	assert.Equal(t, "invalid_presence", apiCode(c.SetPresence("busy")))
+	p, err = srv.Client(BotToken).GetPresence(UserID)
+	require.NoError(t, err)
+	assert.Equal(t, "away", p.Presence)
+
+	st, err := c.SetSnooze(60)
+	require.NoError(t, err)
+	assert.True(t, st.SnoozeEnabled)
+	assert.False(t, srv.SnoozeEnd(UserID).IsZero())
+	info, err := c.GetDNDInfo("")
+	require.NoError(t, err)
+	assert.True(t, info.SnoozeEnabled)
+	require.NoError(t, c.EndSnooze())
+	assert.Equal(t, "snooze_not_active", apiCode(c.EndSnooze()))
+}
+
+func TestServer_ChannelMembership(t *testing.T) {
+	srv, general := newTestServer(t)
+	c := srv.Client(BotToken)
+	srv.AddUser(slack.User{ID: "U0000ALICE", Name: "alice"})
+	require.NoError(t, c.InviteToChannel(general, []string{"U0000ALICE"}))
+
+	members, err := c.ListChannelMembers(general, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{BotUserID, UserID}, members)
+	members, err = c.ListChannelMembers(general, 100)
+	require.NoError(t, err)
+	assert.Len(t, members, 3)
+
+	require.NoError(t, c.KickFromChannel(general, "U0000ALICE"))
+	assert.Equal(t, "not_in_channel", apiCode(c.KickFromChannel(general, "U0000ALICE")))
+	assert.Equal(t, "cant_kick_self", apiCode(c.KickFromChannel(general, BotUserID)))
+
+	require.NoError(t, c.LeaveChannel(general))
+	ch, _ := srv.Channel(general)
+	assert.Equal(t, []string{UserID}, ch.Members)
+	require.NoError(t, c.LeaveChannel(general), "leaving twice is not an error")
+
+	joined, err := c.JoinChannel(general)
+	require.NoError(t, err)
+	assert.Equal(t, general, joined.ID)
+	ch, _ = srv.Channel(general)
+	assert.Contains(t, ch.Members, BotUserID)
+}
+
+func TestServer_Usergroups(t *testing.T) {
+	srv, _ := newTestServer(t)
+	c := srv.Client(BotToken)
+
+	g, err := c.CreateUsergroup("On-call", "oncall", "Whoever is on call", []string{"C1"})
+	require.NoError(t, err)
+	assert.Equal(t, "oncall", g.Handle)
+	assert.Equal(t, []string{"C1"}, g.Prefs.Channels)
+	_, err = c.CreateUsergroup("Other", "oncall", "", nil)
+	assert.Equal(t, "handle_already_exists", apiCode(err))
+	_, err = c.CreateUsergroup("Testers", "tester", "", nil)
+	assert.Equal(t, "handle_already_exists", apiCode(err), "handles share a namespace with users")
+
+	g, err = c.SetUsergroupMembers(g.ID, []string{UserID, BotUserID, UserID})
+	require.NoError(t, err)
+	assert.Equal(t, 2, g.UserCount)
+	_, err = c.SetUsergroupMembers(g.ID, nil)
+	assert.Equal(t, "invalid_users", apiCode(err))
+	_, err = c.SetUsergroupMembers(g.ID, []string{"U0000NOBODY"})
+	assert.Equal(t, "invalid_users", apiCode(err))
+
+	members, err := c.ListUsergroupMembers(g.ID, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{UserID, BotUserID}, members)
+
+	desc := "Primary on-call"
+	g, err = c.UpdateUsergroup(g.ID, slack.UsergroupUpdate{Description: &desc})
+	require.NoError(t, err)
+	assert.Equal(t, "Primary on-call", g.Description)
+	assert.Equal(t, "On-call", g.Name)
+
+	_, err = c.DisableUsergroup(g.ID)
+	require.NoError(t, err)
+	groups, err := c.ListUsergroups(false, false)
+	require.NoError(t, err)
+	assert.Empty(t, groups)
+	groups, err = c.ListUsergroups(true, true)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.True(t, groups[0].IsDisabled())
+	assert.Equal(t, []string{UserID, BotUserID}, groups[0].Users)
+	_, err = c.ListUsergroupMembers(g.ID, false)
+	assert.Equal(t, "no_such_subteam", apiCode(err))
+
+	_, err = c.EnableUsergroup(g.ID)
+	require.NoError(t, err)
+	stored, ok := srv.Usergroup(g.ID)
+	require.True(t, ok)
+	assert.False(t, stored.IsDisabled())
+
+	_, err = c.DisableUsergroup("S0000NONE")
+	assert.Equal(t, "no_such_subteam", apiCode(err))
+}
@@ -0,0 +1,277 @@
+package slacktest
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Channel seeds a conversation. Members lists the user IDs in the channel;
+// like the real API, posting and reading history require membership.
+type Channel struct {
+	ID          string
+	Name        string
+	IsPrivate   bool
+	IsArchived  bool
+	IsGeneral   bool
+	IsShared    bool
+	IsExtShared bool
+	Topic       string
+	Purpose     string
+	Creator     string
+	Created     int64
+	Members     []string
+}
+
+// Message seeds a message. TS is generated when empty; ThreadTS makes the
+// message a reply in that thread.
+type Message struct {
+	User     string
+	BotID    string
+	Subtype  string
+	Text     string
+	TS       string
+	ThreadTS string
+	Blocks   json.RawMessage
+
+	// EditedTS is when the message was last updated, if it was
+	EditedTS string
+
+	// Reactions maps emoji names to the users who reacted
+	Reactions map[string][]string
+
+	// Files holds the IDs of files shared with the message
+	Files []string
+}
+
+// Ephemeral is a message posted with chat.postEphemeral, visible only to
+// the user To. It never appears in channel history.
+type Ephemeral struct {
+	Channel string
+	To      string
+	Message
+}
+
+// File seeds a file shared in the workspace
+type File struct {
+	ID       string
+	Name     string
+	Title    string
+	Filetype string
+	User     string
+	Created  int64
+	Channels []string
+	Content  []byte
+}
+
+type channel struct {
+	Channel
+	messages  []*Message
+	pins      []pin
+	bookmarks []*slack.Bookmark
+}
+
+// pin records who pinned a message and when
+type pin struct {
+	ts      string
+	by      string
+	created int64
+}
+
+// AddChannel adds a conversation to the workspace. An empty ID is generated.
+func (s *Server) AddChannel(ch Channel) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ch.ID == "" {
+		s.seq++
+		ch.ID = idFor("C", s.seq)
+	}
+	if ch.Created == 0 {
+		ch.Created = s.epoch
+	}
+	s.channels = append(s.channels, &channel{Channel: ch})
+	return ch.ID
+}
+
+// AddUser adds a user to the workspace
+func (s *Server) AddUser(u slack.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = append(s.users, u)
+}
+
+// AddMessage posts a message to a channel without going through the API and
+// returns its timestamp. It panics if the channel does not exist.
+func (s *Server) AddMessage(channelID string, msg Message) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.channel(channelID)
+	if ch == nil {
+		panic("slacktest: no channel " + channelID)
+	}
+	if msg.TS == "" {
+		msg.TS = s.nextTS()
+	}
+	m := msg
+	ch.messages = append(ch.messages, &m)
+	sort.SliceStable(ch.messages, func(i, j int) bool { return tsLess(ch.messages[i].TS, ch.messages[j].TS) })
+	return msg.TS
+}
+
+// AddFile adds a file to the workspace. An empty ID is generated.
+func (s *Server) AddFile(f File) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f.ID == "" {
+		s.seq++
+		f.ID = idFor("F", s.seq)
+	}
+	if f.Created == 0 {
+		f.Created = s.epoch
+	}
+	file := f
+	s.files = append(s.files, &file)
+	return f.ID
+}
+
+// Channel returns the current state of a channel
+func (s *Server) Channel(id string) (Channel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.channel(id)
+	if ch == nil {
+		return Channel{}, false
+	}
+	c := ch.Channel
+	c.Members = append([]string(nil), ch.Members...)
+	return c, true
+}
+
+// Messages returns every message in a channel, including thread replies,
+// oldest first
+func (s *Server) Messages(channelID string) []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.channel(channelID)
+	if ch == nil {
+		return nil
+	}
+	msgs := make([]Message, 0, len(ch.messages))
+	for _, m := range ch.messages {
+		msgs = append(msgs, copyMessage(m))
+	}
+	return msgs
+}
+
+// EphemeralMessages returns the ephemeral messages shown so far, oldest first
+func (s *Server) EphemeralMessages() []Ephemeral {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Ephemeral(nil), s.ephemeral...)
+}
+
+// Files returns every file in the workspace
+func (s *Server) Files() []File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	files := make([]File, 0, len(s.files))
+	for _, f := range s.files {
+		files = append(files, *f)
+	}
+	return files
+}
+
+func (s *Server) channel(id string) *channel {
+	for _, ch := range s.channels {
+		if ch.ID == id {
+			return ch
+		}
+	}
+	return nil
+}
+
+func (s *Server) user(id string) *slack.User {
+	for i := range s.users {
+		if s.users[i].ID == id {
+			return &s.users[i]
+		}
+	}
+	return nil
+}
+
+func (ch *channel) message(ts string) *Message {
+	for _, m := range ch.messages {
+		if m.TS == ts {
+			return m
+		}
+	}
+	return nil
+}
+
+func (ch *channel) pinned(ts string) *pin {
+	for i := range ch.pins {
+		if ch.pins[i].ts == ts {
+			return &ch.pins[i]
+		}
+	}
+	return nil
+}
+
+func (ch *channel) isMember(userID string) bool {
+	for _, m := range ch.Members {
+		if m == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// replies returns the replies to the thread rooted at ts, oldest first
+func (ch *channel) removeMember(userID string) {
+	for i, id := range ch.Members {
+		if id == userID {
+			ch.Members = append(ch.Members[:i:i], ch.Members[i+1:]...)
+			return
+		}
+	}
+}
+
+func (ch *channel) replies(ts string) []*Message {
+	var replies []*Message
+	for _, m := range ch.messages {
+		if m.ThreadTS == ts && m.TS != ts {
+			replies = append(replies, m)
+		}
+	}
+	return replies
+}
+
+func copyMessage(m *Message) Message {
+	c := *m
+	if m.Reactions != nil {
+		c.Reactions = make(map[string][]string, len(m.Reactions))
+		for name, users := range m.Reactions {
+			c.Reactions[name] = append([]string(nil), users...)
+		}
+	}
+	return c
+}
+
+func idFor(prefix string, n int) string {
+	id := prefix + "0000000"
+	suffix := []byte(id)
+	for i := len(suffix) - 1; n > 0 && i > 0; i-- {
+		suffix[i] = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"[n%36]
+		n /= 36
+	}
+	return string(suffix)
+}
+
+// tsLess orders message timestamps numerically
+func tsLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
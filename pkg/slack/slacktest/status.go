@@ -0,0 +1,152 @@
+package slacktest
+
+import (
+	"encoding/json"
+	"time"
+	"unicode/utf8"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// Presence returns a user's presence: "away" if they set themselves away,
+// otherwise "active"
+func (s *Server) Presence(userID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.away[userID] {
+		return "away"
+	}
+	return "active"
+}
+
+// SnoozeEnd returns when a user's Do Not Disturb snooze ends, or the zero
+// time if they are not snoozing
+func (s *Server) SnoozeEnd(userID string) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if end := s.snoozes[userID]; end > time.Now().Unix() {
+		return time.Unix(end, 0)
+	}
+	return time.Time{}
+}
+
+// targetUser returns the user named by the user parameter, defaulting to
+// the caller
+func (s *Server) targetUser(r *request) (string, error) {
+	id := r.get("user")
+	if id == "" {
+		return r.token.userID, nil
+	}
+	if s.user(id) == nil {
+		return "", errCode("user_not_found")
+	}
+	return id, nil
+}
+
+func (s *Server) usersProfileSet(r *request) (response, error) {
+	var profile struct {
+		StatusText       *string `json:"status_text"`
+		StatusEmoji      *string `json:"status_emoji"`
+		StatusExpiration *int64  `json:"status_expiration"`
+	}
+	if err := json.Unmarshal([]byte(r.get("profile")), &profile); err != nil {
+		return nil, errCode("invalid_profile")
+	}
+	u := s.user(r.token.userID)
+	if u == nil {
+		return nil, errCode("user_not_found")
+	}
+	if profile.StatusText != nil {
+		if utf8.RuneCountInString(*profile.StatusText) > 100 {
+			return nil, errCode("too_long")
+		}
+		u.Profile.StatusText = *profile.StatusText
+	}
+	if profile.StatusEmoji != nil {
+		u.Profile.StatusEmoji = *profile.StatusEmoji
+	}
+	if profile.StatusExpiration != nil {
+		u.Profile.StatusExpiration = *profile.StatusExpiration
+	}
+	return response{"profile": u.Profile}, nil
+}
+
+func (s *Server) usersGetPresence(r *request) (response, error) {
+	id, err := s.targetUser(r)
+	if err != nil {
+		return nil, err
+	}
+	presence := "active"
+	if s.away[id] {
+		presence = "away"
+	}
+	resp := response{"presence": presence}
+	if id == r.token.userID {
+		resp["online"] = presence == "active"
+		resp["manual_away"] = s.away[id]
+		resp["connection_count"] = 1
+		resp["last_activity"] = s.epoch
+	}
+	return resp, nil
+}
+
+func (s *Server) usersSetPresence(r *request) (response, error) {
+	switch r.get("presence") {
+	case "auto":
+		delete(s.away, r.token.userID)
+	case "away":
+		s.away[r.token.userID] = true
+	default:
+		return nil, errCode("invalid_presence")
+	}
+	return nil, nil
+}
+
+// dndStatus renders a user's Do Not Disturb state. Users in the fake have
+// no scheduled DND window, only snoozes.
+func (s *Server) dndStatus(userID string) slack.DNDStatus {
+	st := slack.DNDStatus{}
+	if end := s.snoozes[userID]; end > time.Now().Unix() {
+		st.DNDEnabled = true
+		st.SnoozeEnabled = true
+		st.SnoozeEndTime = end
+		st.SnoozeRemaining = end - time.Now().Unix()
+	}
+	return st
+}
+
+func (s *Server) dndInfo(r *request) (response, error) {
+	id, err := s.targetUser(r)
+	if err != nil {
+		return nil, err
+	}
+	st := s.dndStatus(id)
+	resp := response{"dnd_enabled": st.DNDEnabled, "snooze_enabled": st.SnoozeEnabled}
+	if st.SnoozeEnabled && id == r.token.userID {
+		resp["snooze_endtime"] = st.SnoozeEndTime
+		resp["snooze_remaining"] = st.SnoozeRemaining
+	}
+	return resp, nil
+}
+
+func (s *Server) dndSetSnooze(r *request) (response, error) {
+	minutes := r.int("num_minutes", 0)
+	if minutes == 0 {
+		return nil, errCode("missing_duration")
+	}
+	s.snoozes[r.token.userID] = time.Now().Add(time.Duration(minutes) * time.Minute).Unix()
+	st := s.dndStatus(r.token.userID)
+	return response{
+		"snooze_enabled":   st.SnoozeEnabled,
+		"snooze_endtime":   st.SnoozeEndTime,
+		"snooze_remaining": st.SnoozeRemaining,
+	}, nil
+}
+
+func (s *Server) dndEndSnooze(r *request) (response, error) {
+	if end := s.snoozes[r.token.userID]; end <= time.Now().Unix() {
+		return nil, errCode("snooze_not_active")
+	}
+	delete(s.snoozes, r.token.userID)
+	return response{"dnd_enabled": false, "snooze_enabled": false}, nil
+}
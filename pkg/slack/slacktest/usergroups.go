@@ -0,0 +1,216 @@
+package slacktest
+
+import (
+	"strings"
+	"time"
+
+	"github.com/piekstra/slack-chat-api/pkg/slack"
+)
+
+// AddUsergroup adds a user group to the workspace and returns its ID. An
+// empty ID is generated.
+func (s *Server) AddUsergroup(g slack.Usergroup) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if g.ID == "" {
+		s.seq++
+		g.ID = idFor("S", s.seq)
+	}
+	if g.DateCreate == 0 {
+		g.DateCreate = s.epoch
+	}
+	g.Users = append([]string(nil), g.Users...)
+	g.UserCount = len(g.Users)
+	s.groups = append(s.groups, &g)
+	return g.ID
+}
+
+// Usergroup returns a user group's current state
+func (s *Server) Usergroup(id string) (slack.Usergroup, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, g := range s.groups {
+		if g.ID == id {
+			out := *g
+			out.Users = append([]string(nil), g.Users...)
+			return out, true
+		}
+	}
+	return slack.Usergroup{}, false
+}
+
+// usergroup returns the group named by the usergroup parameter
+func (s *Server) usergroup(r *request) (*slack.Usergroup, error) {
+	id := r.get("usergroup")
+	for _, g := range s.groups {
+		if g.ID == id {
+			return g, nil
+		}
+	}
+	return nil, errCode("no_such_subteam")
+}
+
+// checkUsergroupNames fails if another group, or a user for the handle,
+// already has the name or handle
+func (s *Server) checkUsergroupNames(self *slack.Usergroup, name, handle string) error {
+	for _, g := range s.groups {
+		if g == self {
+			continue
+		}
+		if name != "" && strings.EqualFold(g.Name, name) {
+			return errCode("name_already_exists")
+		}
+		if handle != "" && strings.EqualFold(g.Handle, handle) {
+			return errCode("handle_already_exists")
+		}
+	}
+	if handle != "" {
+		for _, u := range s.users {
+			if strings.EqualFold(u.Name, handle) {
+				return errCode("handle_already_exists")
+			}
+		}
+	}
+	return nil
+}
+
+// usergroupJSON copies a group for a response, with its members if asked
+func usergroupJSON(g *slack.Usergroup, withUsers bool) slack.Usergroup {
+	out := *g
+	out.UserCount = len(g.Users)
+	out.Users = nil
+	if withUsers {
+		out.Users = append([]string{}, g.Users...)
+	}
+	return out
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func (s *Server) usergroupsList(r *request) (response, error) {
+	out := []slack.Usergroup{}
+	for _, g := range s.groups {
+		if g.IsDisabled() && !r.bool("include_disabled") {
+			continue
+		}
+		out = append(out, usergroupJSON(g, r.bool("include_users")))
+	}
+	return response{"usergroups": out}, nil
+}
+
+func (s *Server) usergroupsCreate(r *request) (response, error) {
+	name := r.get("name")
+	if name == "" {
+		return nil, errCode("invalid_name")
+	}
+	handle := r.get("handle")
+	if err := s.checkUsergroupNames(nil, name, handle); err != nil {
+		return nil, err
+	}
+
+	s.seq++
+	g := &slack.Usergroup{
+		ID:          idFor("S", s.seq),
+		Name:        name,
+		Handle:      handle,
+		Description: r.get("description"),
+		DateCreate:  time.Now().Unix(),
+		CreatedBy:   r.token.userID,
+	}
+	g.Prefs.Channels = splitList(r.get("channels"))
+	s.groups = append(s.groups, g)
+	return response{"usergroup": usergroupJSON(g, false)}, nil
+}
+
+func (s *Server) usergroupsUpdate(r *request) (response, error) {
+	g, err := s.usergroup(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkUsergroupNames(g, r.get("name"), r.get("handle")); err != nil {
+		return nil, err
+	}
+
+	if r.has("name") {
+		if r.get("name") == "" {
+			return nil, errCode("invalid_name")
+		}
+		g.Name = r.get("name")
+	}
+	if r.has("handle") {
+		g.Handle = r.get("handle")
+	}
+	if r.has("description") {
+		g.Description = r.get("description")
+	}
+	if r.has("channels") {
+		g.Prefs.Channels = splitList(r.get("channels"))
+	}
+	g.DateUpdate = time.Now().Unix()
+	g.UpdatedBy = r.token.userID
+	return response{"usergroup": usergroupJSON(g, false)}, nil
+}
+
+func (s *Server) usergroupsDisable(r *request) (response, error) {
+	g, err := s.usergroup(r)
+	if err != nil {
+		return nil, err
+	}
+	g.DateDelete = time.Now().Unix()
+	return response{"usergroup": usergroupJSON(g, false)}, nil
+}
+
+func (s *Server) usergroupsEnable(r *request) (response, error) {
+	g, err := s.usergroup(r)
+	if err != nil {
+		return nil, err
+	}
+	g.DateDelete = 0
+	return response{"usergroup": usergroupJSON(g, false)}, nil
+}
+
+func (s *Server) usergroupsUsersList(r *request) (response, error) {
+	g, err := s.usergroup(r)
+	if err != nil {
+		return nil, err
+	}
+	if g.IsDisabled() && !r.bool("include_disabled") {
+		return nil, errCode("no_such_subteam")
+	}
+	return response{"users": append([]string{}, g.Users...)}, nil
+}
+
+func (s *Server) usergroupsUsersUpdate(r *request) (response, error) {
+	g, err := s.usergroup(r)
+	if err != nil {
+		return nil, err
+	}
+	users := splitList(r.get("users"))
+	if len(users) == 0 {
+		return nil, errCode("invalid_users")
+	}
+
+	seen := make(map[string]bool)
+	var members []string
+	for _, id := range users {
+		if s.user(id) == nil {
+			return nil, errCode("invalid_users")
+		}
+		if !seen[id] {
+			seen[id] = true
+			members = append(members, id)
+		}
+	}
+	g.Users = members
+	g.DateUpdate = time.Now().Unix()
+	g.UpdatedBy = r.token.userID
+	return response{"usergroup": usergroupJSON(g, true)}, nil
+}
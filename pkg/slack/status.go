@@ -0,0 +1,87 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// Presence is a user's presence. Online, AutoAway, ManualAway,
+// ConnectionCount and LastActivity are only reported for the token's owner.
+type Presence struct {
+	Presence        string `json:"presence"`
+	Online          bool   `json:"online,omitempty"`
+	AutoAway        bool   `json:"auto_away,omitempty"`
+	ManualAway      bool   `json:"manual_away,omitempty"`
+	ConnectionCount int    `json:"connection_count,omitempty"`
+	LastActivity    int64  `json:"last_activity,omitempty"`
+}
+
+// SetStatus sets the token owner's status. A zero expiration keeps the
+// status until it is changed; empty emoji and text clear it.
+func (c *Client) SetStatus(emoji, text string, expiration time.Time) error {
+	return c.SetStatusContext(context.Background(), emoji, text, expiration)
+}
+
+// SetStatusContext is SetStatus with a context
+func (c *Client) SetStatusContext(ctx context.Context, emoji, text string, expiration time.Time) error {
+	var exp int64
+	if !expiration.IsZero() {
+		exp = expiration.Unix()
+	}
+	_, err := c.post(ctx, "users.profile.set", map[string]interface{}{
+		"profile": map[string]interface{}{
+			"status_text":       text,
+			"status_emoji":      emoji,
+			"status_expiration": exp,
+		},
+	})
+	return err
+}
+
+// ClearStatus clears the token owner's status
+func (c *Client) ClearStatus() error {
+	return c.ClearStatusContext(context.Background())
+}
+
+// ClearStatusContext is ClearStatus with a context
+func (c *Client) ClearStatusContext(ctx context.Context) error {
+	return c.SetStatusContext(ctx, "", "", time.Time{})
+}
+
+// GetPresence returns a user's presence. An empty user means the token's
+// owner.
+func (c *Client) GetPresence(user string) (*Presence, error) {
+	return c.GetPresenceContext(context.Background(), user)
+}
+
+// GetPresenceContext is GetPresence with a context
+func (c *Client) GetPresenceContext(ctx context.Context, user string) (*Presence, error) {
+	params := url.Values{}
+	if user != "" {
+		params.Set("user", user)
+	}
+
+	body, err := c.get(ctx, "users.getPresence", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Presence
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SetPresence sets the token owner's presence to "auto" or "away"
+func (c *Client) SetPresence(presence string) error {
+	return c.SetPresenceContext(context.Background(), presence)
+}
+
+// SetPresenceContext is SetPresence with a context
+func (c *Client) SetPresenceContext(ctx context.Context, presence string) error {
+	_, err := c.post(ctx, "users.setPresence", map[string]interface{}{"presence": presence})
+	return err
+}
@@ -0,0 +1,182 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// refreshSkew is how long before expiry a rotating token is refreshed
+const refreshSkew = 5 * time.Minute
+
+// TokenSource supplies the token for each request
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// Refresher is implemented by token sources that can replace an expired
+// token. stale is the token Slack rejected.
+type Refresher interface {
+	Refresh(stale string) (string, error)
+}
+
+// StaticToken is a TokenSource that always returns the same token
+type StaticToken string
+
+// Token returns the token
+func (t StaticToken) Token() (string, error) {
+	return string(t), nil
+}
+
+// RotatingTokenSource supplies a rotating token (Slack token rotation),
+// exchanging the refresh token via oauth.v2.access shortly before the token
+// expires or when Slack reports token_expired. It is safe for concurrent use.
+type RotatingTokenSource struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+	ClientID     string
+	ClientSecret string
+
+	// APIBaseURL defaults to DefaultBaseURL, and HTTPClient to a client
+	// with a 30 second timeout
+	APIBaseURL string
+	HTTPClient *http.Client
+
+	// Persist, if set, is called with the rotated pair after every refresh
+	Persist func(accessToken, refreshToken string, expiresAt time.Time) error
+
+	mu sync.Mutex
+}
+
+// Token returns the current token, refreshing it first if it expires within
+// five minutes.
+func (s *RotatingTokenSource) Token() (string, error) {
+	return s.tokenContext(context.Background())
+}
+
+// Refresh exchanges the refresh token for a new token pair. If another
+// request already replaced stale, the new token is returned without
+// refreshing again.
+func (s *RotatingTokenSource) Refresh(stale string) (string, error) {
+	return s.refreshContext(context.Background(), stale)
+}
+
+func (s *RotatingTokenSource) tokenContext(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	token := s.AccessToken
+	needsRefresh := !s.ExpiresAt.IsZero() && time.Until(s.ExpiresAt) < refreshSkew
+	s.mu.Unlock()
+
+	if needsRefresh {
+		return s.refreshContext(ctx, token)
+	}
+	return token, nil
+}
+
+func (s *RotatingTokenSource) refreshContext(ctx context.Context, stale string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.AccessToken != stale {
+		return s.AccessToken, nil
+	}
+
+	resp, err := s.exchange(ctx)
+	if err != nil {
+		return "", fmt.Errorf("refreshing token: %w", err)
+	}
+
+	s.AccessToken = resp.AccessToken
+	s.RefreshToken = resp.RefreshToken
+	s.ExpiresAt = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+
+	if s.Persist != nil {
+		if err := s.Persist(s.AccessToken, s.RefreshToken, s.ExpiresAt); err != nil {
+			return "", fmt.Errorf("storing refreshed token: %w", err)
+		}
+	}
+
+	return s.AccessToken, nil
+}
+
+// refreshResponse is the part of an oauth.v2.access response a refresh uses
+type refreshResponse struct {
+	SlackResponse
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// exchange trades the refresh token for a new pair via oauth.v2.access
+func (s *RotatingTokenSource) exchange(ctx context.Context) (*refreshResponse, error) {
+	baseURL := s.APIBaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", s.RefreshToken)
+	form.Set("client_id", s.ClientID)
+	form.Set("client_secret", s.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", baseURL+"/oauth.v2.access", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result refreshResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	if !result.OK {
+		return nil, &APIError{Code: result.Error}
+	}
+	return &result, nil
+}
+
+// contextTokenSource is implemented by token sources whose refreshes follow
+// the context of the request that needs the token
+type contextTokenSource interface {
+	tokenContext(ctx context.Context) (string, error)
+	refreshContext(ctx context.Context, stale string) (string, error)
+}
+
+// sourceToken gets a token from ts, passing ctx on if ts takes one
+func sourceToken(ctx context.Context, ts TokenSource) (string, error) {
+	if cts, ok := ts.(contextTokenSource); ok {
+		return cts.tokenContext(ctx)
+	}
+	return ts.Token()
+}
+
+// refreshToken refreshes stale with r, passing ctx on if r takes one
+func refreshToken(ctx context.Context, r Refresher, stale string) (string, error) {
+	if cts, ok := r.(contextTokenSource); ok {
+		return cts.refreshContext(ctx, stale)
+	}
+	return r.Refresh(stale)
+}
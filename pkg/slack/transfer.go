@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// DefaultStallTimeout is how long a file upload or download may go without
+// sending or receiving data before it is abandoned
+const DefaultStallTimeout = 30 * time.Second
+
+// transfer tracks the progress of a file upload or download. File content
+// can take far longer to move than the HTTP client's overall timeout allows,
+// so transfers drop that timeout and are cancelled only when they stall.
+type transfer struct {
+	ctx     context.Context
+	timeout time.Duration
+	timer   *time.Timer
+	cancel  context.CancelCauseFunc
+}
+
+// newTransfer starts tracking a transfer that ends when ctx does, or when it
+// stalls
+func (c *Client) newTransfer(ctx context.Context) *transfer {
+	timeout := c.stall
+	if timeout <= 0 {
+		timeout = DefaultStallTimeout
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	t := &transfer{ctx: ctx, timeout: timeout, cancel: cancel}
+	t.timer = time.AfterFunc(timeout, func() {
+		cancel(fmt.Errorf("transfer stalled: no data for %s", timeout))
+	})
+	return t
+}
+
+// do sends req with the client's transport but without its overall timeout.
+// Connection, TLS and idle timeouts still apply.
+func (t *transfer) do(c *Client, req *http.Request) (*http.Response, error) {
+	hc := *c.httpClient
+	hc.Timeout = 0
+	resp, err := hc.Do(req.WithContext(t.ctx))
+	return resp, t.err(err)
+}
+
+// reader returns r, counting every read as progress
+func (t *transfer) reader(r io.Reader) io.Reader {
+	return &progressReader{r: r, t: t}
+}
+
+// err reports why the transfer was cancelled in place of the bare
+// "context canceled"
+func (t *transfer) err(err error) error {
+	if err != nil && t.ctx.Err() != nil {
+		return context.Cause(t.ctx)
+	}
+	return err
+}
+
+// done releases the transfer's timer
+func (t *transfer) done() {
+	t.timer.Stop()
+	t.cancel(nil)
+}
+
+type progressReader struct {
+	r io.Reader
+	t *transfer
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.t.timer.Reset(p.t.timeout)
+	}
+	return n, err
+}
@@ -0,0 +1,212 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Usergroup is a user group, mentioned in messages by its @handle.
+// DateDelete is set while the group is disabled.
+type Usergroup struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Handle      string `json:"handle"`
+	Description string `json:"description"`
+	DateCreate  int64  `json:"date_create"`
+	DateUpdate  int64  `json:"date_update"`
+	DateDelete  int64  `json:"date_delete"`
+	CreatedBy   string `json:"created_by"`
+	UpdatedBy   string `json:"updated_by"`
+	Prefs       struct {
+		Channels []string `json:"channels"`
+	} `json:"prefs"`
+	Users     []string `json:"users,omitempty"`
+	UserCount int      `json:"user_count"`
+}
+
+// IsDisabled reports whether the group has been disabled
+func (g *Usergroup) IsDisabled() bool {
+	return g.DateDelete != 0
+}
+
+// UsergroupUpdate lists the user group fields to change; nil fields are
+// left as they are. Channels are the group's default channels.
+type UsergroupUpdate struct {
+	Name        *string
+	Handle      *string
+	Description *string
+	Channels    *[]string
+}
+
+// ListUsergroups returns the workspace's user groups. includeUsers fills in
+// each group's member IDs.
+func (c *Client) ListUsergroups(includeDisabled, includeUsers bool) ([]Usergroup, error) {
+	return c.ListUsergroupsContext(context.Background(), includeDisabled, includeUsers)
+}
+
+// ListUsergroupsContext is ListUsergroups with a context
+func (c *Client) ListUsergroupsContext(ctx context.Context, includeDisabled, includeUsers bool) ([]Usergroup, error) {
+	params := url.Values{}
+	params.Set("include_count", "true")
+	params.Set("include_disabled", strconv.FormatBool(includeDisabled))
+	params.Set("include_users", strconv.FormatBool(includeUsers))
+
+	body, err := c.get(ctx, "usergroups.list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Usergroups []Usergroup `json:"usergroups"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result.Usergroups, nil
+}
+
+// CreateUsergroup creates a user group. handle and description may be
+// empty; channels are the group's default channels.
+func (c *Client) CreateUsergroup(name, handle, description string, channels []string) (*Usergroup, error) {
+	return c.CreateUsergroupContext(context.Background(), name, handle, description, channels)
+}
+
+// CreateUsergroupContext is CreateUsergroup with a context
+func (c *Client) CreateUsergroupContext(ctx context.Context, name, handle, description string, channels []string) (*Usergroup, error) {
+	data := map[string]interface{}{
+		"name": name,
+	}
+	if handle != "" {
+		data["handle"] = handle
+	}
+	if description != "" {
+		data["description"] = description
+	}
+	if len(channels) > 0 {
+		data["channels"] = strings.Join(channels, ",")
+	}
+
+	body, err := c.post(ctx, "usergroups.create", data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeUsergroup(body)
+}
+
+// UpdateUsergroup changes a user group's name, handle, description or
+// default channels
+func (c *Client) UpdateUsergroup(id string, update UsergroupUpdate) (*Usergroup, error) {
+	return c.UpdateUsergroupContext(context.Background(), id, update)
+}
+
+// UpdateUsergroupContext is UpdateUsergroup with a context
+func (c *Client) UpdateUsergroupContext(ctx context.Context, id string, update UsergroupUpdate) (*Usergroup, error) {
+	data := map[string]interface{}{
+		"usergroup": id,
+	}
+	if update.Name != nil {
+		data["name"] = *update.Name
+	}
+	if update.Handle != nil {
+		data["handle"] = *update.Handle
+	}
+	if update.Description != nil {
+		data["description"] = *update.Description
+	}
+	if update.Channels != nil {
+		data["channels"] = strings.Join(*update.Channels, ",")
+	}
+
+	body, err := c.post(ctx, "usergroups.update", data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeUsergroup(body)
+}
+
+// DisableUsergroup disables a user group, so its handle no longer notifies
+// anyone
+func (c *Client) DisableUsergroup(id string) (*Usergroup, error) {
+	return c.DisableUsergroupContext(context.Background(), id)
+}
+
+// DisableUsergroupContext is DisableUsergroup with a context
+func (c *Client) DisableUsergroupContext(ctx context.Context, id string) (*Usergroup, error) {
+	body, err := c.post(ctx, "usergroups.disable", map[string]interface{}{"usergroup": id})
+	if err != nil {
+		return nil, err
+	}
+	return decodeUsergroup(body)
+}
+
+// EnableUsergroup re-enables a disabled user group
+func (c *Client) EnableUsergroup(id string) (*Usergroup, error) {
+	return c.EnableUsergroupContext(context.Background(), id)
+}
+
+// EnableUsergroupContext is EnableUsergroup with a context
+func (c *Client) EnableUsergroupContext(ctx context.Context, id string) (*Usergroup, error) {
+	body, err := c.post(ctx, "usergroups.enable", map[string]interface{}{"usergroup": id})
+	if err != nil {
+		return nil, err
+	}
+	return decodeUsergroup(body)
+}
+
+// ListUsergroupMembers returns the IDs of a user group's members
+func (c *Client) ListUsergroupMembers(id string, includeDisabled bool) ([]string, error) {
+	return c.ListUsergroupMembersContext(context.Background(), id, includeDisabled)
+}
+
+// ListUsergroupMembersContext is ListUsergroupMembers with a context
+func (c *Client) ListUsergroupMembersContext(ctx context.Context, id string, includeDisabled bool) ([]string, error) {
+	params := url.Values{}
+	params.Set("usergroup", id)
+	params.Set("include_disabled", strconv.FormatBool(includeDisabled))
+
+	body, err := c.get(ctx, "usergroups.users.list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Users []string `json:"users"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return result.Users, nil
+}
+
+// SetUsergroupMembers replaces a user group's members. Slack requires at
+// least one.
+func (c *Client) SetUsergroupMembers(id string, users []string) (*Usergroup, error) {
+	return c.SetUsergroupMembersContext(context.Background(), id, users)
+}
+
+// SetUsergroupMembersContext is SetUsergroupMembers with a context
+func (c *Client) SetUsergroupMembersContext(ctx context.Context, id string, users []string) (*Usergroup, error) {
+	data := map[string]interface{}{
+		"usergroup": id,
+		"users":     strings.Join(users, ","),
+	}
+
+	body, err := c.post(ctx, "usergroups.users.update", data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeUsergroup(body)
+}
+
+func decodeUsergroup(body []byte) (*Usergroup, error) {
+	var result struct {
+		Usergroup Usergroup `json:"usergroup"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	return &result.Usergroup, nil
+}